@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// archidektAPIURL is the Archidekt API root for deck lookups.
+	archidektAPIURL = "https://archidekt.com/api"
+	// archidektCommanderCategory is the category Archidekt puts a deck's commanders in.
+	archidektCommanderCategory = "Commander"
+	// archidektSideboardCategory is Archidekt's default sideboard category.
+	archidektSideboardCategory = "Sideboard"
+	// archidektMaybeboardCategory is Archidekt's default maybeboard category.
+	archidektMaybeboardCategory = "Maybeboard"
+)
+
+// ArchidektDeck represents a deck from Archidekt's API.
+type ArchidektDeck struct {
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	DeckFormat  int                 `json:"deckFormat"`
+	ViewCount   int                 `json:"viewCount"`
+	CreatedAt   string              `json:"createdAt"`
+	UpdatedAt   string              `json:"updatedAt"`
+	Owner       ArchidektOwner      `json:"owner"`
+	Categories  []ArchidektCategory `json:"categories"`
+	Cards       []ArchidektCard     `json:"cards"`
+}
+
+// ArchidektOwner is the user who owns an Archidekt deck.
+type ArchidektOwner struct {
+	Username string `json:"username"`
+}
+
+// ArchidektCategory is a deck category, such as "Ramp" or "Sideboard". Cards in a
+// category that isn't included in the deck sit outside the mainboard.
+type ArchidektCategory struct {
+	Name           string `json:"name"`
+	IncludedInDeck bool   `json:"includedInDeck"`
+}
+
+// ArchidektCard is one entry in an Archidekt deck.
+type ArchidektCard struct {
+	Quantity   int               `json:"quantity"`
+	Categories []string          `json:"categories"`
+	Card       ArchidektCardInfo `json:"card"`
+}
+
+// ArchidektCardInfo is the printing of an Archidekt deck entry.
+type ArchidektCardInfo struct {
+	Edition    ArchidektEdition    `json:"edition"`
+	OracleCard ArchidektOracleCard `json:"oracleCard"`
+}
+
+// ArchidektEdition is the set of a printing.
+type ArchidektEdition struct {
+	EditionCode string `json:"editioncode"`
+}
+
+// ArchidektOracleCard is a card's printing-independent data.
+type ArchidektOracleCard struct {
+	Name     string  `json:"name"`
+	ManaCost string  `json:"manaCost"`
+	CMC      float64 `json:"cmc"`
+	// ColorIdentity lists full color names (e.g., ["White", "Blue"]).
+	ColorIdentity []string `json:"colorIdentity"`
+	SuperTypes    []string `json:"superTypes"`
+	Types         []string `json:"types"`
+	SubTypes      []string `json:"subTypes"`
+}
+
+// TypeLine joins the card's types into a Scryfall-style type line.
+func (c ArchidektOracleCard) TypeLine() string {
+	typeLine := strings.Join(slices.Concat(c.SuperTypes, c.Types), " ")
+	if len(c.SubTypes) > 0 {
+		typeLine += " — " + strings.Join(c.SubTypes, " ")
+	}
+	return typeLine
+}
+
+// archidektColorLetters maps Archidekt's color names to color letters.
+func archidektColorLetters(colors []string) []string {
+	letters := make([]string, 0, len(colors))
+	for _, color := range colors {
+		switch color {
+		case "White":
+			letters = append(letters, "W")
+		case "Blue":
+			letters = append(letters, "U")
+		case "Black":
+			letters = append(letters, "B")
+		case "Red":
+			letters = append(letters, "R")
+		case "Green":
+			letters = append(letters, "G")
+		}
+	}
+	return letters
+}
+
+// archidektFormatName returns the format name for Archidekt's numeric format ID.
+func archidektFormatName(id int) string {
+	switch id {
+	case 1:
+		return "standard"
+	case 2:
+		return "modern"
+	case 3:
+		return "commander"
+	case 4:
+		return "legacy"
+	case 5:
+		return "vintage"
+	case 6:
+		return "pauper"
+	default:
+		return "unknown"
+	}
+}
+
+// GetArchidektDeck fetches a deck by its ID.
+func GetArchidektDeck(ctx context.Context, deckID string) (*ArchidektDeck, error) {
+	return getArchidektDeckWithURL(ctx, deckID, archidektAPIURL)
+}
+
+// getArchidektDeckWithURL fetches a deck with a custom base URL.
+func getArchidektDeckWithURL(ctx context.Context, deckID, baseURL string) (*ArchidektDeck, error) {
+	requestURL := fmt.Sprintf("%s/decks/%s/", baseURL, url.PathEscape(deckID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError("archidekt API", deckID, resp.StatusCode)
+	}
+
+	var deck ArchidektDeck
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&deck); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+
+	return &deck, nil
+}
+
+// ExtractArchidektIDFromURL extracts the deck ID from an Archidekt URL such as
+// https://archidekt.com/decks/123456/deck-name, or returns the input as-is.
+func ExtractArchidektIDFromURL(deckURL string) string {
+	parsed, err := url.Parse(deckURL)
+	if err != nil {
+		return deckURL
+	}
+
+	parts := strings.Split(parsed.Path, "/")
+	for i, part := range parts {
+		if part == "decks" && i+1 < len(parts) && parts[i+1] != "" {
+			return parts[i+1]
+		}
+	}
+	return deckURL
+}
+
+// ToDeck converts the Archidekt deck to a Deck. Cards in the Commander category
+// become commanders; cards in the Sideboard or Maybeboard category, or only in
+// categories excluded from the deck, go to those boards.
+func (d *ArchidektDeck) ToDeck() *Deck {
+	excluded := make(map[string]bool)
+	for _, category := range d.Categories {
+		if !category.IncludedInDeck {
+			excluded[category.Name] = true
+		}
+	}
+
+	deck := &Deck{
+		ID:          strconv.Itoa(d.ID),
+		Name:        d.Name,
+		Format:      archidektFormatName(d.DeckFormat),
+		Description: d.Description,
+		URL:         fmt.Sprintf("https://archidekt.com/decks/%d", d.ID),
+		CreatedAt:   d.CreatedAt,
+		LastUpdated: d.UpdatedAt,
+		ViewCount:   d.ViewCount,
+	}
+	if d.Owner.Username != "" {
+		deck.Authors = []string{d.Owner.Username}
+	}
+
+	for _, card := range d.Cards {
+		board := &deck.Mainboard
+		switch {
+		case slices.Contains(card.Categories, archidektCommanderCategory):
+			board = &deck.Commanders
+		case slices.Contains(card.Categories, archidektSideboardCategory):
+			board = &deck.Sideboard
+		case slices.Contains(card.Categories, archidektMaybeboardCategory):
+			board = &deck.Maybeboard
+		case len(card.Categories) > 0 && !slices.ContainsFunc(card.Categories, func(name string) bool {
+			return !excluded[name]
+		}):
+			board = &deck.Maybeboard
+		}
+
+		oracle := card.Card.OracleCard
+		*board = append(*board, CardEntry{
+			Name:          oracle.Name,
+			TypeLine:      oracle.TypeLine(),
+			ManaCost:      oracle.ManaCost,
+			CMC:           oracle.CMC,
+			ColorIdentity: archidektColorLetters(oracle.ColorIdentity),
+			Set:           card.Card.Edition.EditionCode,
+			Quantity:      card.Quantity,
+		})
+	}
+
+	for _, board := range [][]CardEntry{deck.Commanders, deck.Mainboard, deck.Sideboard, deck.Maybeboard} {
+		sortCardEntries(board)
+	}
+	return deck
+}
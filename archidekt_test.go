@@ -0,0 +1,172 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// archidektDeckJSON is a trimmed Archidekt deck response.
+const archidektDeckJSON = `{
+	"id": 123456,
+	"name": "Krenko Tokens",
+	"description": "Goblins go wide",
+	"deckFormat": 3,
+	"viewCount": 42,
+	"updatedAt": "2024-05-01T12:00:00Z",
+	"owner": {"username": "goblinfan"},
+	"categories": [
+		{"name": "Commander", "includedInDeck": true},
+		{"name": "Creature", "includedInDeck": true},
+		{"name": "Land", "includedInDeck": true},
+		{"name": "Sideboard", "includedInDeck": false},
+		{"name": "Considering", "includedInDeck": false}
+	],
+	"cards": [
+		{"quantity": 1, "categories": ["Commander"], "card": {"edition": {"editioncode": "m19"}, "oracleCard": {
+			"name": "Krenko, Mob Boss", "manaCost": "{2}{R}{R}", "cmc": 4, "colorIdentity": ["Red"],
+			"superTypes": ["Legendary"], "types": ["Creature"], "subTypes": ["Goblin", "Warrior"]}}},
+		{"quantity": 1, "categories": ["Creature"], "card": {"oracleCard": {
+			"name": "Goblin Matron", "manaCost": "{2}{R}", "cmc": 3, "colorIdentity": ["Red"],
+			"types": ["Creature"], "subTypes": ["Goblin"]}}},
+		{"quantity": 30, "categories": ["Land"], "card": {"oracleCard": {
+			"name": "Mountain", "cmc": 0, "superTypes": ["Basic"], "types": ["Land"], "subTypes": ["Mountain"]}}},
+		{"quantity": 1, "categories": ["Sideboard"], "card": {"oracleCard": {
+			"name": "Pyroblast", "manaCost": "{R}", "cmc": 1, "types": ["Instant"]}}},
+		{"quantity": 1, "categories": ["Considering"], "card": {"oracleCard": {
+			"name": "Goblin Bombardment", "manaCost": "{1}{R}", "cmc": 2, "types": ["Enchantment"]}}}
+	]
+}`
+
+func TestExtractArchidektIDFromURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "full URL", input: "https://archidekt.com/decks/123456/krenko_tokens", want: "123456"},
+		{name: "URL without slug", input: "https://archidekt.com/decks/123456", want: "123456"},
+		{name: "URL with query", input: "https://archidekt.com/decks/123456?view=stacks", want: "123456"},
+		{name: "just ID", input: "123456", want: "123456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractArchidektIDFromURL(tt.input); got != tt.want {
+				t.Errorf("ExtractArchidektIDFromURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetArchidektDeck(t *testing.T) {
+	tests := []struct {
+		name       string
+		deckID     string
+		mockStatus int
+		wantErr    bool
+	}{
+		{name: "successful fetch", deckID: "123456", mockStatus: http.StatusOK},
+		{name: "deck not found", deckID: "999999", mockStatus: http.StatusNotFound, wantErr: true},
+		{name: "server error", deckID: "123456", mockStatus: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/decks/"+tt.deckID+"/" {
+					t.Errorf("GetArchidektDeck() requested %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.mockStatus)
+				if tt.mockStatus == http.StatusOK {
+					_, _ = w.Write([]byte(archidektDeckJSON))
+				}
+			}))
+			defer server.Close()
+
+			got, err := getArchidektDeckWithURL(t.Context(), tt.deckID, server.URL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetArchidektDeck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != "Krenko Tokens" || len(got.Cards) != 5 || got.Owner.Username != "goblinfan" {
+				t.Errorf("GetArchidektDeck() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestArchidektDeckToDeck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(archidektDeckJSON))
+	}))
+	defer server.Close()
+
+	archidektDeck, err := getArchidektDeckWithURL(t.Context(), "123456", server.URL)
+	if err != nil {
+		t.Fatalf("GetArchidektDeck() error = %v", err)
+	}
+	got := archidektDeck.ToDeck()
+
+	if got.Format != "commander" || got.ID != "123456" || got.URL != "https://archidekt.com/decks/123456" {
+		t.Errorf("ToDeck() format = %q, id = %q, url = %q", got.Format, got.ID, got.URL)
+	}
+	if len(got.Commanders) != 1 {
+		t.Fatalf("ToDeck() commanders = %+v, want Krenko", got.Commanders)
+	}
+	krenko := got.Commanders[0]
+	if krenko.Name != "Krenko, Mob Boss" || krenko.TypeLine != "Legendary Creature — Goblin Warrior" ||
+		len(krenko.ColorIdentity) != 1 || krenko.ColorIdentity[0] != "R" || krenko.Set != "m19" {
+		t.Errorf("ToDeck() commander = %+v", krenko)
+	}
+	if len(got.Mainboard) != 2 || got.Mainboard[0].Name != "Goblin Matron" || got.Mainboard[1].Quantity != 30 {
+		t.Errorf("ToDeck() mainboard = %+v, want Goblin Matron and 30 Mountain", got.Mainboard)
+	}
+	if len(got.Sideboard) != 1 || got.Sideboard[0].Name != "Pyroblast" {
+		t.Errorf("ToDeck() sideboard = %+v", got.Sideboard)
+	}
+	if len(got.Maybeboard) != 1 || got.Maybeboard[0].Name != "Goblin Bombardment" {
+		t.Errorf("ToDeck() maybeboard = %+v", got.Maybeboard)
+	}
+}
+
+func TestHandleGetArchidektDeck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/decks/123456/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(archidektDeckJSON))
+	}))
+	defer server.Close()
+
+	s := &MTGCommanderServer{archidektBaseURL: server.URL}
+
+	got, isErr := callTool(t, s.handleGetArchidektDeck, map[string]any{
+		"deck_id": "https://archidekt.com/decks/123456/krenko_tokens",
+	})
+	if isErr {
+		t.Fatalf("handleGetArchidektDeck() returned error: %s", got)
+	}
+	for _, want := range []string{
+		"# Krenko Tokens",
+		"**Format:** commander",
+		"**Author:** goblinfan",
+		"- 1x Krenko, Mob Boss",
+		"**Total Cards:** 32",
+		"## Sideboard",
+		"## Maybeboard",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleGetArchidektDeck() missing %q in output: %s", want, got)
+		}
+	}
+
+	got, isErr = callTool(t, s.handleGetArchidektDeck, map[string]any{"deck_id": "999"})
+	if !isErr || !strings.Contains(got, "archidekt API returned status 404") {
+		t.Errorf("handleGetArchidektDeck() for a missing deck = %q, want a not found error", got)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// bannedListQuery is the Scryfall search for every card banned in Commander.
+	bannedListQuery = "banned:commander"
+	// bannedListMaxCards bounds how many banned cards are fetched; the real list is under a hundred.
+	bannedListMaxCards = 1000
+	// defaultBannedListRefresh is how often the cached banned list is refreshed. Bans are
+	// announced a few times a year, so a daily check catches them without loading Scryfall.
+	defaultBannedListRefresh = 24 * time.Hour
+	// bannedListRefreshEnvVar overrides defaultBannedListRefresh with a Go duration string (e.g. "6h").
+	bannedListRefreshEnvVar = "MTG_BANNED_LIST_REFRESH"
+)
+
+// bannedListSnapshot is the Commander banned list as of one fetch.
+type bannedListSnapshot struct {
+	cards     []scryfall.Card
+	fetchedAt time.Time
+}
+
+// bannedListCache holds the most recently fetched banned list, shared by the
+// get_banned_list tool and the banned list resource. It is safe for concurrent use.
+type bannedListCache struct {
+	mu       sync.Mutex
+	snapshot *bannedListSnapshot
+	interval time.Duration
+	fetch    func(context.Context) ([]scryfall.Card, error)
+	now      func() time.Time
+}
+
+// newBannedListCache creates an empty banned list cache that refreshes every interval.
+func newBannedListCache(interval time.Duration, fetch func(context.Context) ([]scryfall.Card, error)) *bannedListCache {
+	return &bannedListCache{interval: interval, fetch: fetch, now: time.Now}
+}
+
+// get returns the cached banned list, fetching it first if it is missing or older
+// than the refresh interval. If a refetch fails, the stale snapshot is returned.
+func (c *bannedListCache) get(ctx context.Context) (bannedListSnapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.snapshot != nil && c.now().Sub(c.snapshot.fetchedAt) < c.interval {
+		return *c.snapshot, nil
+	}
+
+	if err := c.refreshLocked(ctx); err != nil {
+		if c.snapshot == nil {
+			return bannedListSnapshot{}, err
+		}
+		GetLogger().Warn().
+			Err(err).
+			Time("fetched_at", c.snapshot.fetchedAt).
+			Msg("Banned list refresh failed, using cached list")
+	}
+	return *c.snapshot, nil
+}
+
+// refresh fetches the banned list now, replacing the cached snapshot.
+func (c *bannedListCache) refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshLocked(ctx)
+}
+
+// refreshLocked fetches the banned list and logs the cards added to or removed from
+// the previous snapshot. The caller must hold c.mu.
+func (c *bannedListCache) refreshLocked(ctx context.Context) error {
+	cards, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.snapshot != nil {
+		added, removed := diffBannedLists(c.snapshot.cards, cards)
+		if len(added) > 0 || len(removed) > 0 {
+			GetLogger().Info().
+				Strs("added", added).
+				Strs("removed", removed).
+				Time("previous_fetch", c.snapshot.fetchedAt).
+				Msg("Commander banned list changed")
+		}
+	}
+
+	c.snapshot = &bannedListSnapshot{cards: cards, fetchedAt: c.now()}
+	return nil
+}
+
+// run refreshes the banned list every interval until ctx is canceled.
+func (c *bannedListCache) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.refresh(ctx); err != nil {
+				GetLogger().Warn().Err(err).Msg("Scheduled banned list refresh failed")
+			}
+		}
+	}
+}
+
+// diffBannedLists returns the card names in current but not previous, and those in
+// previous but not current, each sorted.
+func diffBannedLists(previous, current []scryfall.Card) ([]string, []string) {
+	names := func(cards []scryfall.Card) map[string]bool {
+		set := make(map[string]bool, len(cards))
+		for _, card := range cards {
+			set[card.Name] = true
+		}
+		return set
+	}
+	before, after := names(previous), names(current)
+
+	var added, removed []string
+	for name := range after {
+		if !before[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range before {
+		if !after[name] {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+// bannedListRefreshFromEnv returns the banned list refresh interval configured through
+// the environment, falling back to defaultBannedListRefresh when unset or invalid.
+func bannedListRefreshFromEnv() time.Duration {
+	value := os.Getenv(bannedListRefreshEnvVar)
+	if value == "" {
+		return defaultBannedListRefresh
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		GetLogger().Warn().Str("value", value).Msgf("Invalid %s, using default", bannedListRefreshEnvVar)
+		return defaultBannedListRefresh
+	}
+
+	return interval
+}
+
+// fetchBannedList fetches every card banned in Commander from Scryfall, sorted by name.
+func (s *MTGCommanderServer) fetchBannedList(ctx context.Context) ([]scryfall.Card, error) {
+	cards, _, err := s.searchCardsWindow(ctx, bannedListQuery,
+		scryfall.SearchCardsOptions{Order: scryfall.OrderName}, 0, bannedListMaxCards)
+	return cards, err
+}
+
+// getBannedList returns the cached banned list, or fetches it directly when the
+// server has no banned list cache.
+func (s *MTGCommanderServer) getBannedList(ctx context.Context) (bannedListSnapshot, error) {
+	if s.bannedList != nil {
+		return s.bannedList.get(ctx)
+	}
+
+	cards, err := s.fetchBannedList(ctx)
+	if err != nil {
+		return bannedListSnapshot{}, err
+	}
+	return bannedListSnapshot{cards: cards, fetchedAt: time.Now()}, nil
+}
+
+// bannedCardJSON is one card in the banned list resource.
+type bannedCardJSON struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	ManaCost      string   `json:"mana_cost"`
+	CMC           float64  `json:"cmc"`
+	ColorIdentity []string `json:"color_identity"`
+	ScryfallID    string   `json:"scryfall_id"`
+	OracleID      string   `json:"oracle_id,omitempty"`
+	ScryfallURI   string   `json:"scryfall_uri,omitempty"`
+}
+
+// bannedListJSON is the banned list resource document. LastUpdated is when the list
+// was fetched from Scryfall and CacheAgeSeconds how long ago that was.
+type bannedListJSON struct {
+	Format          string           `json:"format"`
+	TotalBanned     int              `json:"total_banned"`
+	Cards           []bannedCardJSON `json:"cards"`
+	LastUpdated     string           `json:"last_updated"`
+	CacheAgeSeconds int64            `json:"cache_age_seconds"`
+}
+
+// newBannedListJSON builds the banned list resource document for a snapshot as of now.
+func newBannedListJSON(snapshot bannedListSnapshot, now time.Time) bannedListJSON {
+	cards := make([]bannedCardJSON, len(snapshot.cards))
+	for i, card := range snapshot.cards {
+		identity := make([]string, len(card.ColorIdentity))
+		for j, color := range card.ColorIdentity {
+			identity[j] = string(color)
+		}
+		cards[i] = bannedCardJSON{
+			Name:          card.Name,
+			Type:          card.TypeLine,
+			ManaCost:      card.ManaCost,
+			CMC:           card.CMC,
+			ColorIdentity: identity,
+			ScryfallID:    card.ID,
+			OracleID:      card.OracleID,
+			ScryfallURI:   card.ScryfallURI,
+		}
+	}
+
+	return bannedListJSON{
+		Format:          "commander",
+		TotalBanned:     len(cards),
+		Cards:           cards,
+		LastUpdated:     snapshot.fetchedAt.UTC().Format(time.RFC3339),
+		CacheAgeSeconds: int64(max(now.Sub(snapshot.fetchedAt), 0) / time.Second),
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBannedListCache(t *testing.T) {
+	now := time.Date(2025, 9, 22, 12, 0, 0, 0, time.UTC)
+	lists := [][]scryfall.Card{
+		{{Name: "Mana Crypt"}, {Name: "Jeweled Lotus"}},
+		{{Name: "Mana Crypt"}, {Name: "Dockside Extortionist"}},
+	}
+
+	fetches := 0
+	var fetchErr error
+	cache := newBannedListCache(time.Hour, func(context.Context) ([]scryfall.Card, error) {
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		cards := lists[min(fetches, len(lists)-1)]
+		fetches++
+		return cards, nil
+	})
+	cache.now = func() time.Time { return now }
+
+	snapshot, err := cache.get(t.Context())
+	if err != nil || len(snapshot.cards) != 2 || !snapshot.fetchedAt.Equal(now) {
+		t.Fatalf("get() = %+v, %v", snapshot, err)
+	}
+
+	// Fresh snapshots are served from the cache
+	now = now.Add(30 * time.Minute)
+	if _, err = cache.get(t.Context()); err != nil || fetches != 1 {
+		t.Errorf("get() within the interval fetched %d times, err %v", fetches, err)
+	}
+
+	// Stale snapshots are refetched
+	now = now.Add(time.Hour)
+	snapshot, err = cache.get(t.Context())
+	if err != nil || fetches != 2 || snapshot.cards[1].Name != "Dockside Extortionist" {
+		t.Errorf("get() after the interval = %+v, %v (fetches %d)", snapshot, err, fetches)
+	}
+
+	// A failed refetch falls back to the stale snapshot
+	now = now.Add(2 * time.Hour)
+	fetchErr = errors.New("scryfall unavailable")
+	snapshot, err = cache.get(t.Context())
+	if err != nil || snapshot.cards[1].Name != "Dockside Extortionist" {
+		t.Errorf("get() with a failing fetch = %+v, %v", snapshot, err)
+	}
+	if err = cache.refresh(t.Context()); err == nil {
+		t.Error("refresh() with a failing fetch returned no error")
+	}
+
+	empty := newBannedListCache(time.Hour, func(context.Context) ([]scryfall.Card, error) { return nil, fetchErr })
+	if _, err = empty.get(t.Context()); err == nil {
+		t.Error("get() with no snapshot and a failing fetch returned no error")
+	}
+}
+
+func TestDiffBannedLists(t *testing.T) {
+	previous := []scryfall.Card{{Name: "Mana Crypt"}, {Name: "Jeweled Lotus"}, {Name: "Nadu, Winged Wisdom"}}
+	current := []scryfall.Card{{Name: "Mana Crypt"}, {Name: "Dockside Extortionist"}}
+
+	added, removed := diffBannedLists(previous, current)
+	if !slices.Equal(added, []string{"Dockside Extortionist"}) {
+		t.Errorf("diffBannedLists() added = %v", added)
+	}
+	if !slices.Equal(removed, []string{"Jeweled Lotus", "Nadu, Winged Wisdom"}) {
+		t.Errorf("diffBannedLists() removed = %v", removed)
+	}
+}
+
+func TestHandleGetBannedList(t *testing.T) {
+	searches := 0
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/search" || r.URL.Query().Get("q") != bannedListQuery {
+			writeScryfallNotFound(w)
+			return
+		}
+		searches++
+		_, _ = w.Write([]byte(`{"object": "list", "total_cards": 2, "has_more": false, "data": [
+			{"object": "card", "name": "Dockside Extortionist"},
+			{"object": "card", "name": "Mana Crypt"}
+		]}`))
+	}))
+	s.bannedList = newBannedListCache(time.Hour, s.fetchBannedList)
+
+	for range 2 {
+		got, isErr := callTool(t, s.handleGetBannedList, nil)
+		if isErr {
+			t.Fatalf("handleGetBannedList() returned error: %s", got)
+		}
+		for _, want := range []string{"Total banned cards: 2", "1. Dockside Extortionist", "2. Mana Crypt"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("handleGetBannedList() missing %q in output: %s", want, got)
+			}
+		}
+
+		_, stamp, _ := strings.Cut(got, "*Last updated: ")
+		stamp, _, _ = strings.Cut(stamp, "*")
+		if _, err := time.Parse(time.RFC3339, stamp); err != nil {
+			t.Errorf("handleGetBannedList() last updated %q is not RFC3339: %v", stamp, err)
+		}
+	}
+
+	if searches != 1 {
+		t.Errorf("handleGetBannedList() searched Scryfall %d times, want 1 (cached)", searches)
+	}
+}
+
+func TestHandleBannedListResource(t *testing.T) {
+	s := &MTGCommanderServer{}
+	fetchedAt := time.Now().Add(-90 * time.Minute)
+	s.bannedList = newBannedListCache(24*time.Hour, func(context.Context) ([]scryfall.Card, error) {
+		return []scryfall.Card{{
+			ID:            "1b6ec5fb-5b0b-4fb4-8c16-1d7a1b7d5b8a",
+			Name:          "Mana Crypt",
+			TypeLine:      "Artifact",
+			ManaCost:      "{0}",
+			ColorIdentity: []scryfall.Color{},
+			ScryfallURI:   "https://scryfall.com/card/2xm/270/mana-crypt",
+		}}, nil
+	})
+	s.bannedList.now = func() time.Time { return fetchedAt }
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "commander://banned-list"
+	contents, err := s.handleBannedListResource(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handleBannedListResource() error = %v", err)
+	}
+
+	var doc bannedListJSON
+	if err = json.Unmarshal([]byte(contents[0].(*mcp.TextResourceContents).Text), &doc); err != nil {
+		t.Fatalf("handleBannedListResource() returned invalid JSON: %v", err)
+	}
+
+	if doc.TotalBanned != 1 || doc.Cards[0].Name != "Mana Crypt" || doc.Cards[0].ScryfallID == "" {
+		t.Errorf("handleBannedListResource() cards = %+v", doc)
+	}
+	if doc.LastUpdated != fetchedAt.UTC().Format(time.RFC3339) {
+		t.Errorf("handleBannedListResource() last_updated = %q, want fetch time", doc.LastUpdated)
+	}
+	if doc.CacheAgeSeconds < 90*60 || doc.CacheAgeSeconds > 91*60 {
+		t.Errorf("handleBannedListResource() cache_age_seconds = %d, want about 5400", doc.CacheAgeSeconds)
+	}
+}
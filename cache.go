@@ -0,0 +1,222 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// defaultCardCacheTTL is how long a cached card stays fresh. Oracle data rarely
+	// changes, so a day keeps lookups fast without serving stale text for long.
+	defaultCardCacheTTL = 24 * time.Hour
+	// defaultCardCacheMaxEntries bounds the cache; the least recently used card is
+	// evicted once it is full.
+	defaultCardCacheMaxEntries = 1000
+	// cardCacheTTLEnvVar overrides defaultCardCacheTTL with a Go duration string (e.g. "1h").
+	cardCacheTTLEnvVar = "MTG_CARD_CACHE_TTL"
+	// defaultEDHRECCacheTTL is how long a cached EDHREC commander page stays fresh.
+	// EDHREC rebuilds its pages about once a day.
+	defaultEDHRECCacheTTL = 12 * time.Hour
+	// defaultEDHRECCacheMaxEntries bounds the EDHREC cache; commander pages are large,
+	// so it holds far fewer entries than the card cache.
+	defaultEDHRECCacheMaxEntries = 100
+	// edhrecCacheTTLEnvVar overrides defaultEDHRECCacheTTL with a Go duration string.
+	edhrecCacheTTLEnvVar = "MTG_EDHREC_CACHE_TTL"
+)
+
+// ttlCacheEntry is a cached value and the time it expires.
+type ttlCacheEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// ttlCache is an in-memory TTL cache with LRU eviction. It is safe for concurrent use.
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List // front is most recently used
+	entries    map[string]*list.Element
+	now        func() time.Time
+}
+
+// cardCache caches Scryfall cards by normalized name.
+type cardCache = ttlCache[scryfall.Card]
+
+// edhrecCache caches EDHREC commander recommendations by sanitized commander name.
+type edhrecCache = ttlCache[*EDHRECData]
+
+// newTTLCache creates a cache with the given TTL and maximum size.
+func newTTLCache[V any](ttl time.Duration, maxEntries int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// newCardCache creates a card cache with the given TTL and maximum size.
+func newCardCache(ttl time.Duration, maxEntries int) *cardCache {
+	return newTTLCache[scryfall.Card](ttl, maxEntries)
+}
+
+// newEDHRECCache creates an EDHREC cache with the given TTL and maximum size.
+func newEDHRECCache(ttl time.Duration, maxEntries int) *edhrecCache {
+	return newTTLCache[*EDHRECData](ttl, maxEntries)
+}
+
+// get returns the cached value for key if present and not expired.
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := elem.Value.(*ttlCacheEntry[V])
+	if c.now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set stores value under key, evicting the least recently used entry if the cache is full.
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*ttlCacheEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&ttlCacheEntry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*ttlCacheEntry[V]).key)
+	}
+}
+
+// len returns the number of cached entries, including expired ones not yet evicted.
+func (c *ttlCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// cardCacheTTLFromEnv returns the card cache TTL configured through the environment,
+// falling back to defaultCardCacheTTL when unset or invalid.
+func cardCacheTTLFromEnv() time.Duration {
+	return cacheTTLFromEnv(cardCacheTTLEnvVar, defaultCardCacheTTL)
+}
+
+// edhrecCacheTTLFromEnv returns the EDHREC cache TTL configured through the
+// environment, falling back to defaultEDHRECCacheTTL when unset or invalid.
+func edhrecCacheTTLFromEnv() time.Duration {
+	return cacheTTLFromEnv(edhrecCacheTTLEnvVar, defaultEDHRECCacheTTL)
+}
+
+// cacheTTLFromEnv parses the Go duration in envVar, falling back to fallback when
+// it is unset, invalid, or not positive.
+func cacheTTLFromEnv(envVar string, fallback time.Duration) time.Duration {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return fallback
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		GetLogger().Warn().Str("value", value).Msgf("Invalid %s, using default", envVar)
+		return fallback
+	}
+
+	return ttl
+}
+
+// cachedGetCardByName fetches a card by fuzzy name, serving repeated lookups from the card cache.
+// In offline mode the name must match a card or face exactly (ignoring case) and
+// Scryfall is never called.
+func (s *MTGCommanderServer) cachedGetCardByName(ctx context.Context, name string) (scryfall.Card, error) {
+	if s.offlineIndex != nil {
+		card, ok := s.offlineIndex.get(name)
+		if !ok {
+			return scryfall.Card{}, fmt.Errorf("%q is not in the offline card index", name)
+		}
+		return card.Card, nil
+	}
+
+	if s.cardCache == nil {
+		return s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
+	}
+
+	key := normalizeCardName(name)
+	if card, ok := s.cardCache.get(key); ok {
+		GetLogger().Debug().Str("card", name).Msg("Card cache hit")
+		return card, nil
+	}
+
+	card, err := s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
+	if err != nil {
+		return scryfall.Card{}, err
+	}
+
+	s.cardCache.set(key, card)
+	return card, nil
+}
+
+// cachedCommanderRecommendations fetches a commander's EDHREC recommendations, or
+// those from its budget page, serving repeated lookups from the EDHREC cache. fresh
+// skips the cached copy and refetches, caching the result.
+func (s *MTGCommanderServer) cachedCommanderRecommendations(
+	ctx context.Context,
+	commanderName string,
+	budget, fresh bool,
+) (*EDHRECData, error) {
+	fetch := getCommanderRecommendationsWithURL
+	key := SanitizeCardName(commanderName)
+	if budget {
+		fetch = getBudgetRecommendationsWithURL
+		key += "/budget"
+	}
+
+	if s.edhrecCache == nil {
+		return fetch(ctx, commanderName, s.edhrecBaseURL)
+	}
+
+	if !fresh {
+		if data, ok := s.edhrecCache.get(key); ok {
+			GetLogger().Debug().Str("commander", commanderName).Msg("EDHREC cache hit")
+			return data, nil
+		}
+	}
+
+	data, err := fetch(ctx, commanderName, s.edhrecBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.edhrecCache.set(key, data)
+	return data, nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestCardCacheTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newCardCache(time.Hour, 10)
+	cache.now = func() time.Time { return now }
+
+	cache.set("sol ring", scryfall.Card{Name: "Sol Ring"})
+
+	if card, ok := cache.get("sol ring"); !ok || card.Name != "Sol Ring" {
+		t.Errorf("get() = %v, %v, want Sol Ring", card.Name, ok)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := cache.get("sol ring"); ok {
+		t.Error("get() returned an expired entry")
+	}
+
+	if cache.len() != 0 {
+		t.Errorf("len() = %d after expiry, want 0", cache.len())
+	}
+}
+
+func TestCardCacheLRUEviction(t *testing.T) {
+	cache := newCardCache(time.Hour, 2)
+
+	cache.set("a", scryfall.Card{Name: "A"})
+	cache.set("b", scryfall.Card{Name: "B"})
+	cache.get("a") // a is now most recently used
+	cache.set("c", scryfall.Card{Name: "C"})
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("get(b) found entry, want it evicted as least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("get(%s) missing, want cached", key)
+		}
+	}
+}
+
+func TestCardCacheConcurrentAccess(t *testing.T) {
+	cache := newCardCache(time.Hour, 50)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				key := fmt.Sprintf("card %d", (i+j)%80)
+				cache.set(key, scryfall.Card{Name: key})
+				cache.get(key)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cache.len() > 50 {
+		t.Errorf("len() = %d, want at most 50", cache.len())
+	}
+}
+
+func TestCardCacheTTLFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: defaultCardCacheTTL},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "soon", want: defaultCardCacheTTL},
+		{value: "-1h", want: defaultCardCacheTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(cardCacheTTLEnvVar, tt.value)
+			if got := cardCacheTTLFromEnv(); got != tt.want {
+				t.Errorf("cardCacheTTLFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEDHRECCacheTTLFromEnv(t *testing.T) {
+	t.Setenv(edhrecCacheTTLEnvVar, "")
+	if got := edhrecCacheTTLFromEnv(); got != defaultEDHRECCacheTTL {
+		t.Errorf("edhrecCacheTTLFromEnv() = %v, want %v", got, defaultEDHRECCacheTTL)
+	}
+
+	t.Setenv(edhrecCacheTTLEnvVar, "30m")
+	if got := edhrecCacheTTLFromEnv(); got != 30*time.Minute {
+		t.Errorf("edhrecCacheTTLFromEnv() = %v, want 30m", got)
+	}
+}
+
+func TestCachedGetCardByName(t *testing.T) {
+	var requests atomic.Int32
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("fuzzy") != "Sol Ring" && r.URL.Query().Get("fuzzy") != "sol ring" {
+			writeScryfallNotFound(w)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&scryfall.Card{Name: "Sol Ring"})
+	}))
+	s.cardCache = newCardCache(time.Hour, 10)
+
+	for _, name := range []string{"Sol Ring", "sol ring", " Sol Ring "} {
+		card, err := s.cachedGetCardByName(t.Context(), name)
+		if err != nil || card.Name != "Sol Ring" {
+			t.Fatalf("cachedGetCardByName(%q) = %v, %v", name, card.Name, err)
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("cachedGetCardByName() made %d requests, want 1", got)
+	}
+
+	// Failed lookups are not cached
+	for range 2 {
+		if _, err := s.cachedGetCardByName(t.Context(), "Not A Card"); err == nil {
+			t.Error("cachedGetCardByName() error = nil, want not found")
+		}
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("cachedGetCardByName() made %d requests, want 3", got)
+	}
+}
+
+func TestCachedCommanderRecommendations(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/commanders/krenko-mob-boss") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+			Card:     EDHRECCardInfo{Name: "Krenko, Mob Boss"},
+			NumDecks: 100,
+		}}})
+	}))
+	defer server.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: server.URL, edhrecCache: newEDHRECCache(time.Hour, 10)}
+
+	for _, name := range []string{"Krenko, Mob Boss", "krenko mob boss"} {
+		data, err := s.cachedCommanderRecommendations(t.Context(), name, false, false)
+		if err != nil || data.Card.Name != "Krenko, Mob Boss" {
+			t.Fatalf("cachedCommanderRecommendations(%q) = %v, %v", name, data, err)
+		}
+	}
+	if len(paths) != 1 {
+		t.Errorf("cachedCommanderRecommendations() made requests %v, want one", paths)
+	}
+
+	// The budget page is cached separately, and fresh bypasses the cached copy
+	data, err := s.cachedCommanderRecommendations(t.Context(), "Krenko, Mob Boss", true, false)
+	if err != nil || !data.Budget {
+		t.Fatalf("cachedCommanderRecommendations() budget = %v, %v, want a budget build", data, err)
+	}
+	if _, err = s.cachedCommanderRecommendations(t.Context(), "Krenko, Mob Boss", false, true); err != nil {
+		t.Fatalf("cachedCommanderRecommendations() fresh error = %v", err)
+	}
+	if _, err = s.cachedCommanderRecommendations(t.Context(), "Krenko, Mob Boss", false, false); err != nil {
+		t.Fatalf("cachedCommanderRecommendations() error = %v", err)
+	}
+	want := []string{
+		"/commanders/krenko-mob-boss.json",
+		"/commanders/krenko-mob-boss/budget.json",
+		"/commanders/krenko-mob-boss.json",
+	}
+	if !slices.Equal(paths, want) {
+		t.Errorf("cachedCommanderRecommendations() requested %v, want %v", paths, want)
+	}
+
+	// Failed lookups are not cached
+	for range 2 {
+		if _, err = s.cachedCommanderRecommendations(t.Context(), "Not A Commander", false, false); err == nil {
+			t.Error("cachedCommanderRecommendations() error = nil, want not found")
+		}
+	}
+	if len(paths) != 5 {
+		t.Errorf("cachedCommanderRecommendations() made %d requests, want 5", len(paths))
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// defaultCommanderLimit is how many commanders find_commanders lists by default.
+	defaultCommanderLimit = 20
+	// colorlessIdentity is the color letter Scryfall uses for a colorless identity.
+	colorlessIdentity = "c"
+)
+
+// colorLettersPattern matches a color identity written as WUBRG letters, or C for colorless.
+var colorLettersPattern = regexp.MustCompile(`^[wubrgc]+$`) //nolint:gochecknoglobals // compiled once
+
+// colorIdentityNames maps the common names for color combinations to their letters.
+func colorIdentityNames() map[string]string {
+	return map[string]string{
+		"white": "w", "blue": "u", "black": "b", "red": "r", "green": "g", "colorless": colorlessIdentity,
+		"azorius": "wu", "dimir": "ub", "rakdos": "br", "gruul": "rg", "selesnya": "gw",
+		"orzhov": "wb", "izzet": "ur", "golgari": "bg", "boros": "rw", "simic": "gu",
+		"bant": "gwu", "esper": "wub", "grixis": "ubr", "jund": "brg", "naya": "rgw",
+		"abzan": "wbg", "jeskai": "urw", "sultai": "bgu", "mardu": "rwb", "temur": "gur",
+		"wubrg": "wubrg", "rainbow": "wubrg",
+	}
+}
+
+// parseColorIdentity normalizes a color identity given as letters ("wur", "W,U,R")
+// or a name ("Jeskai") to lowercase WUBRG-ordered letters.
+func parseColorIdentity(input string) (string, error) {
+	cleaned := strings.ToLower(strings.NewReplacer(" ", "", ",", "", "{", "", "}", "").Replace(input))
+	if letters, ok := colorIdentityNames()[cleaned]; ok {
+		cleaned = letters
+	}
+	if !colorLettersPattern.MatchString(cleaned) {
+		return "", fmt.Errorf(
+			"invalid colors %q (use WUBRG letters like 'wur' or a name like 'Jeskai')", input,
+		)
+	}
+
+	var letters strings.Builder
+	for _, color := range "wubrg" {
+		if strings.ContainsRune(cleaned, color) {
+			letters.WriteRune(color)
+		}
+	}
+	if letters.Len() == 0 {
+		return colorlessIdentity, nil
+	}
+	if strings.Contains(cleaned, colorlessIdentity) {
+		return "", fmt.Errorf("invalid colors %q (colorless can't be combined with colors)", input)
+	}
+	return letters.String(), nil
+}
+
+// commanderSearchQuery builds the Scryfall query for Commander-legal commanders whose
+// color identity is exactly colors, or fits within it.
+func commanderSearchQuery(colors string, exact bool) string {
+	operator := "<="
+	if exact {
+		operator = "="
+	}
+	return fmt.Sprintf("is:commander f:commander id%s%s", operator, colors)
+}
+
+// FormatCommandersForDisplay lists commanders with their type line, color identity,
+// and EDHREC rank when Scryfall has one.
+func FormatCommandersForDisplay(colors string, exact bool, cards []scryfall.Card, totalCards int) string {
+	match := "within"
+	if exact {
+		match = "exactly"
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Commanders with color identity %s %s\n\n", match, strings.ToUpper(colors)))
+	output.WriteString(fmt.Sprintf("Showing %d of %d commanders, most popular on EDHREC first:\n\n",
+		len(cards), totalCards))
+
+	for i, card := range cards {
+		identity := "Colorless"
+		if len(card.ColorIdentity) > 0 {
+			letters := make([]string, len(card.ColorIdentity))
+			for j, c := range card.ColorIdentity {
+				letters[j] = string(c)
+			}
+			identity = strings.Join(letters, ", ")
+		}
+
+		output.WriteString(fmt.Sprintf("%d. **%s** %s\n", i+1, card.Name, card.ManaCost))
+		output.WriteString(fmt.Sprintf("   Type: %s\n", card.TypeLine))
+		output.WriteString(fmt.Sprintf("   Color Identity: %s\n", identity))
+		if card.EDHRECRank != nil {
+			output.WriteString(fmt.Sprintf("   EDHREC Rank: #%d\n", *card.EDHRECRank))
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
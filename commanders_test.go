@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestParseColorIdentity(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "wur", want: "wur"},
+		{input: "R,W,U", want: "wur"},
+		{input: "{G}{B}", want: "bg"},
+		{input: "Jeskai", want: "wur"},
+		{input: "golgari", want: "bg"},
+		{input: "c", want: "c"},
+		{input: "Colorless", want: "c"},
+		{input: "wc", wantErr: true},
+		{input: "purple", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseColorIdentity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseColorIdentity(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseColorIdentity(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleFindCommanders(t *testing.T) {
+	rank := 12
+	commanders := []scryfall.Card{
+		{
+			Name:          "Narset, Enlightened Exile",
+			ManaCost:      "{1}{U}{R}{W}",
+			TypeLine:      "Legendary Creature — Cat Monk",
+			ColorIdentity: []scryfall.Color{"W", "U", "R"},
+			EDHRECRank:    &rank,
+		},
+		{Name: "Kykar, Wind's Fury", TypeLine: "Legendary Creature — Bird Wizard"},
+	}
+
+	var gotQuery, gotOrder string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/search" {
+			writeScryfallNotFound(w)
+			return
+		}
+		gotQuery = r.URL.Query().Get("q")
+		gotOrder = r.URL.Query().Get("order")
+		if strings.Contains(gotQuery, "id=wubrg") {
+			writeScryfallNotFound(w)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list", "total_cards": len(commanders), "has_more": false, "data": commanders,
+		})
+	}))
+
+	got, isErr := callTool(t, s.handleFindCommanders, map[string]any{"colors": "Jeskai"})
+	if isErr {
+		t.Fatalf("handleFindCommanders() returned error: %s", got)
+	}
+	if gotQuery != "is:commander f:commander id<=wur" || gotOrder != "edhrec" {
+		t.Errorf("handleFindCommanders() searched q=%q order=%q", gotQuery, gotOrder)
+	}
+	for _, want := range []string{
+		"# Commanders with color identity within WUR",
+		"1. **Narset, Enlightened Exile** {1}{U}{R}{W}",
+		"Type: Legendary Creature — Cat Monk",
+		"Color Identity: W, U, R",
+		"EDHREC Rank: #12",
+		"2. **Kykar, Wind's Fury**",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleFindCommanders() missing %q in output: %s", want, got)
+		}
+	}
+
+	got, _ = callTool(t, s.handleFindCommanders, map[string]any{"colors": "wur", "exact": true})
+	if gotQuery != "is:commander f:commander id=wur" || !strings.Contains(got, "exactly WUR") {
+		t.Errorf("handleFindCommanders() exact searched q=%q: %s", gotQuery, got)
+	}
+
+	got, isErr = callTool(t, s.handleFindCommanders, map[string]any{"colors": "wubrg", "exact": true})
+	if isErr || !strings.Contains(got, "No commanders found for color identity WUBRG.") {
+		t.Errorf("handleFindCommanders() with no matches = %s", got)
+	}
+
+	if got, isErr = callTool(t, s.handleFindCommanders, map[string]any{"colors": "purple"}); !isErr {
+		t.Errorf("handleFindCommanders() accepted invalid colors: %s", got)
+	}
+}
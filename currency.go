@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// frankfurterBaseURL is the Frankfurter exchange rate API (free, no API key needed).
+	frankfurterBaseURL = "https://api.frankfurter.app"
+	// exchangeRateTTL is how long a fetched exchange rate is reused. Frankfurter
+	// publishes reference rates once a day, so refetching more often gains nothing.
+	exchangeRateTTL = 6 * time.Hour
+)
+
+// currencyCodePattern matches an ISO 4217 currency code.
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`) //nolint:gochecknoglobals // compiled once
+
+// defaultPriceCurrencies are the currencies get_card_price shows alongside USD by default.
+func defaultPriceCurrencies() []string {
+	return []string{"EUR", "BRL"}
+}
+
+// cachedRate is a fetched exchange rate and when it was fetched.
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+// exchangeRateCache holds the most recently fetched USD exchange rates by currency.
+// It is safe for concurrent use.
+type exchangeRateCache struct {
+	mu    sync.Mutex
+	rates map[string]cachedRate
+}
+
+// newExchangeRateCache creates an empty exchange rate cache.
+func newExchangeRateCache() *exchangeRateCache {
+	return &exchangeRateCache{rates: make(map[string]cachedRate)}
+}
+
+// usdExchangeRates caches USD exchange rates across price lookups.
+var usdExchangeRates = newExchangeRateCache() //nolint:gochecknoglobals // shared rate cache
+
+// get returns a rate for each currency, reusing cached rates fresher than
+// exchangeRateTTL and fetching the rest in a single call. If the fetch fails, stale
+// cached rates are used instead; currencies with no rate at all are left out of the
+// result and reported in the returned error.
+func (c *exchangeRateCache) get(
+	ctx context.Context,
+	now time.Time,
+	currencies []string,
+	fetch func(context.Context, []string) (map[string]float64, error),
+) (map[string]float64, error) {
+	// Holding the lock while fetching keeps concurrent callers from all refreshing at once
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make(map[string]float64, len(currencies))
+	var stale []string
+	for _, currency := range currencies {
+		if cached, ok := c.rates[currency]; ok && now.Sub(cached.fetchedAt) < exchangeRateTTL {
+			result[currency] = cached.rate
+		} else {
+			stale = append(stale, currency)
+		}
+	}
+
+	if len(stale) == 0 {
+		return result, nil
+	}
+
+	fetched, fetchErr := fetch(ctx, stale)
+	if fetchErr == nil {
+		for currency, rate := range fetched {
+			c.rates[currency] = cachedRate{rate: rate, fetchedAt: now}
+		}
+	}
+
+	var missing []string
+	for _, currency := range stale {
+		if rate, ok := fetched[currency]; ok && fetchErr == nil {
+			result[currency] = rate
+		} else if cached, hasCached := c.rates[currency]; hasCached {
+			GetLogger().Warn().
+				Err(fetchErr).
+				Str("currency", currency).
+				Time("fetched_at", cached.fetchedAt).
+				Msg("Exchange rate refresh failed, using cached rate")
+			result[currency] = cached.rate
+		} else {
+			missing = append(missing, currency)
+		}
+	}
+
+	if len(missing) > 0 {
+		if fetchErr == nil {
+			fetchErr = errors.New("not returned by the exchange rate API")
+		}
+		return result, fmt.Errorf("no exchange rate for %s: %w", strings.Join(missing, ", "), fetchErr)
+	}
+
+	return result, nil
+}
+
+// getExchangeRates returns the USD exchange rate for each currency, fetching stale
+// or missing rates at most once every exchangeRateTTL.
+func getExchangeRates(ctx context.Context, currencies []string) (map[string]float64, error) {
+	fetch := func(ctx context.Context, stale []string) (map[string]float64, error) {
+		return fetchExchangeRates(ctx, frankfurterBaseURL, stale)
+	}
+	return usdExchangeRates.get(ctx, time.Now(), currencies, fetch)
+}
+
+// getUSDToBRLRate returns the current USD to BRL exchange rate.
+func getUSDToBRLRate(ctx context.Context) (float64, error) {
+	rates, err := getExchangeRates(ctx, []string{"BRL"})
+	if err != nil {
+		return 0, err
+	}
+	return rates["BRL"], nil
+}
+
+// fetchExchangeRates fetches USD exchange rates for the given currencies from
+// Frankfurter in one request.
+func fetchExchangeRates(ctx context.Context, baseURL string, currencies []string) (map[string]float64, error) {
+	resp, err := HTTPGet(ctx, fmt.Sprintf("%s/latest?from=USD&to=%s", baseURL, strings.Join(currencies, ",")))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("frankfurter API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	rates := make(map[string]float64, len(result.Rates))
+	for currency, rate := range result.Rates {
+		if rate > 0 {
+			rates[currency] = rate
+		}
+	}
+
+	return rates, nil
+}
+
+// parseCurrencies parses a comma-separated list of currency codes, uppercasing and
+// deduplicating them. An empty list yields defaultPriceCurrencies.
+func parseCurrencies(list string) ([]string, error) {
+	var currencies []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(list, ",") {
+		code := strings.ToUpper(strings.TrimSpace(part))
+		if code == "" || seen[code] {
+			continue
+		}
+		if !currencyCodePattern.MatchString(code) {
+			return nil, fmt.Errorf("invalid currency code %q (use ISO codes like BRL, CAD, GBP)", code)
+		}
+		seen[code] = true
+		currencies = append(currencies, code)
+	}
+
+	if len(currencies) == 0 {
+		return defaultPriceCurrencies(), nil
+	}
+	return currencies, nil
+}
+
+// currencySymbol returns the display prefix for a currency's amounts.
+func currencySymbol(currency string) string {
+	switch currency {
+	case "USD":
+		return "$"
+	case "EUR":
+		return "€"
+	case "GBP":
+		return "£"
+	case "JPY":
+		return "¥"
+	case "BRL":
+		return "R$ "
+	case "CAD":
+		return "C$ "
+	case "AUD":
+		return "A$ "
+	default:
+		return currency + " "
+	}
+}
+
+// convertFromUSD converts a USD price string using the given exchange rate.
+func convertFromUSD(priceStr string, rate float64) float64 {
+	var price float64
+	_, _ = fmt.Sscanf(priceStr, "%f", &price)
+	return price * rate
+}
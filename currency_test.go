@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExchangeRateCache(t *testing.T) {
+	cache := newExchangeRateCache()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var fetched [][]string
+	rates := map[string]float64{"BRL": 5.0, "CAD": 1.4}
+	var fetchErr error
+	fetch := func(_ context.Context, currencies []string) (map[string]float64, error) {
+		fetched = append(fetched, currencies)
+		result := make(map[string]float64)
+		for _, c := range currencies {
+			if rate, ok := rates[c]; ok {
+				result[c] = rate
+			}
+		}
+		return result, fetchErr
+	}
+
+	got, err := cache.get(t.Context(), start, []string{"BRL"}, fetch)
+	if err != nil || got["BRL"] != 5.0 {
+		t.Fatalf("get() = %v, %v, want BRL 5.0", got, err)
+	}
+
+	// BRL is fresh, so only CAD is fetched
+	rates["BRL"] = 6.0
+	got, _ = cache.get(t.Context(), start.Add(time.Hour), []string{"BRL", "CAD"}, fetch)
+	if got["BRL"] != 5.0 || got["CAD"] != 1.4 {
+		t.Errorf("get() within TTL = %v, want BRL 5.0 and CAD 1.4", got)
+	}
+	if last := fetched[len(fetched)-1]; strings.Join(last, ",") != "CAD" {
+		t.Errorf("get() fetched %v, want only CAD", last)
+	}
+
+	// Stale: both refreshed in one call
+	got, _ = cache.get(t.Context(), start.Add(7*time.Hour), []string{"BRL", "CAD"}, fetch)
+	if got["BRL"] != 6.0 || len(fetched) != 3 || len(fetched[2]) != 2 {
+		t.Errorf("get() after TTL = %v with fetches %v, want BRL 6.0 from one combined fetch", got, fetched)
+	}
+
+	// Stale and the refresh fails: last known rates are kept
+	fetchErr = errors.New("unavailable")
+	got, err = cache.get(t.Context(), start.Add(14*time.Hour), []string{"BRL"}, fetch)
+	if err != nil || got["BRL"] != 6.0 {
+		t.Errorf("get() with failing refresh = %v, %v, want cached 6.0", got, err)
+	}
+}
+
+func TestExchangeRateCacheMissingRates(t *testing.T) {
+	cache := newExchangeRateCache()
+	fetch := func(context.Context, []string) (map[string]float64, error) {
+		return map[string]float64{"GBP": 0.8}, nil
+	}
+
+	got, err := cache.get(t.Context(), time.Now(), []string{"GBP", "XYZ"}, fetch)
+	if err == nil || !strings.Contains(err.Error(), "XYZ") {
+		t.Errorf("get() error = %v, want error naming XYZ", err)
+	}
+	if got["GBP"] != 0.8 {
+		t.Errorf("get() = %v, want GBP 0.8 despite the missing currency", got)
+	}
+
+	failing := func(context.Context, []string) (map[string]float64, error) { return nil, errors.New("unavailable") }
+	if _, err := newExchangeRateCache().get(t.Context(), time.Now(), []string{"BRL"}, failing); err == nil {
+		t.Error("get() error = nil, want error when nothing is cached")
+	}
+}
+
+func TestExchangeRateCacheConcurrent(t *testing.T) {
+	cache := newExchangeRateCache()
+	var mu sync.Mutex
+	fetches := 0
+	fetch := func(context.Context, []string) (map[string]float64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetches++
+		return map[string]float64{"BRL": 5.0}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if got, err := cache.get(context.Background(), time.Now(), []string{"BRL"}, fetch); err != nil || got["BRL"] != 5.0 {
+				t.Errorf("get() = %v, %v, want BRL 5.0", got, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if fetches != 1 {
+		t.Errorf("concurrent get() fetched %d times, want 1", fetches)
+	}
+}
+
+func TestFetchExchangeRates(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantRates map[string]float64
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			status:    http.StatusOK,
+			body:      `{"rates":{"BRL":5.1234,"CAD":1.37}}`,
+			wantRates: map[string]float64{"BRL": 5.1234, "CAD": 1.37},
+		},
+		{name: "zero rate dropped", status: http.StatusOK, body: `{"rates":{"BRL":0}}`, wantRates: map[string]float64{}},
+		{name: "bad status", status: http.StatusNotFound, body: `{}`, wantErr: true},
+		{name: "bad json", status: http.StatusOK, body: `not json`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/latest" || r.URL.Query().Get("to") != "BRL,CAD" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := fetchExchangeRates(t.Context(), server.URL, []string{"BRL", "CAD"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchExchangeRates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantRates) {
+				t.Fatalf("fetchExchangeRates() = %v, want %v", got, tt.wantRates)
+			}
+			for currency, want := range tt.wantRates {
+				if math.Abs(got[currency]-want) > 1e-9 {
+					t.Errorf("fetchExchangeRates()[%s] = %v, want %v", currency, got[currency], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCurrencies(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: "EUR,BRL"},
+		{input: "cad", want: "CAD"},
+		{input: "BRL, cad ,GBP,brl", want: "BRL,CAD,GBP"},
+		{input: " , ", want: "EUR,BRL"},
+		{input: "CAD,dollars", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseCurrencies(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCurrencies(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(got, ",") != tt.want {
+				t.Errorf("parseCurrencies(%q) = %v, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertFromUSD(t *testing.T) {
+	if got := convertFromUSD("10.00", 1.35); math.Abs(got-13.5) > 1e-9 {
+		t.Errorf("convertFromUSD() = %v, want 13.5", got)
+	}
+	if got := convertFromUSD("", 5.0); got != 0 {
+		t.Errorf("convertFromUSD() of empty price = %v, want 0", got)
+	}
+}
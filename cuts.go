@@ -0,0 +1,178 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// defaultSuggestedCuts is how many cuts suggest_cuts lists when the deck isn't over 100 cards.
+	defaultSuggestedCuts = 5
+	// offThemeHighCMC is the mana value from which a card EDHREC doesn't recommend is
+	// treated as an expensive off-theme card, the first thing to cut.
+	offThemeHighCMC = 5
+)
+
+// Cut tiers, most cuttable first.
+const (
+	cutTierOffThemeExpensive = iota
+	cutTierNegativeSynergy
+	cutTierOffTheme
+	cutTierLowSynergy
+)
+
+// cutSuggestion is a card suggested for removal and why.
+type cutSuggestion struct {
+	name      string
+	cmc       int
+	reason    string
+	tier      int
+	synergy   float64
+	inclusion int
+	// typeRank is the card type's place in heuristicCutTypeOrder.
+	typeRank int
+}
+
+// heuristicCutTypeOrder ranks card types by how readily they are cut when there is
+// no EDHREC data: instants and artifacts come last since they are usually
+// interaction and mana rocks.
+func heuristicCutTypeOrder() []string {
+	return []string{"Creatures", "Sorceries", "Enchantments", "Planeswalkers", "Other", "Artifacts", "Instants"}
+}
+
+// edhrecCardViews returns the EDHREC card views by normalized name. A card listed in
+// several categories keeps its first view.
+func edhrecCardViews(data *EDHRECData) map[string]EDHRECCardView {
+	views := make(map[string]EDHRECCardView)
+	for _, cardList := range data.CardLists {
+		for _, view := range cardList.CardViews {
+			name := normalizeCardName(view.Name)
+			if _, seen := views[name]; !seen {
+				views[name] = view
+			}
+		}
+	}
+	return views
+}
+
+// suggestCuts ranks the deck's nonland mainboard cards as candidates for removal.
+// With EDHREC data, expensive cards EDHREC doesn't recommend come first, then cards
+// with negative synergy, then other unrecommended cards, then the remaining
+// recommendations by synergy and inclusion. With nil data, cards are ranked by mana
+// value and then by card type.
+func suggestCuts(deck *Deck, data *EDHRECData) []cutSuggestion {
+	var views map[string]EDHRECCardView
+	if data != nil {
+		views = edhrecCardViews(data)
+	}
+
+	var cuts []cutSuggestion
+	for _, entry := range deck.Mainboard {
+		if isLandCard(entry) {
+			continue
+		}
+
+		cut := cutSuggestion{
+			name:     entry.Name,
+			cmc:      cardManaValue(entry),
+			typeRank: slices.Index(heuristicCutTypeOrder(), deckCardType(entry.TypeLine)),
+		}
+		view, recommended := views[normalizeCardName(entry.Name)]
+		switch {
+		case data == nil:
+			cut.reason = fmt.Sprintf("costs %d mana", cut.cmc)
+		case !recommended && cut.cmc >= offThemeHighCMC:
+			cut.tier = cutTierOffThemeExpensive
+			cut.reason = fmt.Sprintf("not among EDHREC's recommendations and costs %d mana", cut.cmc)
+		case !recommended:
+			cut.tier = cutTierOffTheme
+			cut.reason = "not among EDHREC's recommendations"
+		default:
+			cut.tier = cutTierLowSynergy
+			if view.Synergy < 0 {
+				cut.tier = cutTierNegativeSynergy
+			}
+			cut.synergy = view.Synergy
+			cut.inclusion = view.Inclusion
+			cut.reason = fmt.Sprintf("synergy %+.2f", view.Synergy)
+			if data.NumDecks > 0 {
+				percentage := float64(view.Inclusion) / float64(data.NumDecks) * percentageMultiplier
+				cut.reason += fmt.Sprintf(", in %.1f%% of decks", percentage)
+			}
+		}
+		cuts = append(cuts, cut)
+	}
+
+	if data == nil {
+		slices.SortStableFunc(cuts, func(a, b cutSuggestion) int {
+			return cmp.Or(cmp.Compare(b.cmc, a.cmc), cmp.Compare(a.typeRank, b.typeRank),
+				strings.Compare(a.name, b.name))
+		})
+		return cuts
+	}
+
+	slices.SortStableFunc(cuts, func(a, b cutSuggestion) int {
+		if a.tier != b.tier {
+			return cmp.Compare(a.tier, b.tier)
+		}
+		switch a.tier {
+		case cutTierNegativeSynergy, cutTierLowSynergy:
+			return cmp.Or(cmp.Compare(a.synergy, b.synergy), cmp.Compare(a.inclusion, b.inclusion),
+				strings.Compare(a.name, b.name))
+		default:
+			return cmp.Or(cmp.Compare(b.cmc, a.cmc), strings.Compare(a.name, b.name))
+		}
+	})
+	return cuts
+}
+
+// deckSize returns the number of cards in the deck's commanders and mainboard.
+func deckSize(deck *Deck) int {
+	total := 0
+	for _, board := range [][]CardEntry{deck.Commanders, deck.Mainboard} {
+		for _, entry := range board {
+			total += entry.Quantity
+		}
+	}
+	return total
+}
+
+// FormatSuggestedCutsForDisplay lists up to count suggested cuts with their reasons.
+// data is nil when EDHREC recommendations weren't available, in which case the
+// output says the ranking only used mana value and card type.
+func FormatSuggestedCutsForDisplay(deck *Deck, data *EDHRECData, cuts []cutSuggestion, count int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Suggested Cuts: %s\n\n", deck.Name))
+
+	size := deckSize(deck)
+	if over := size - deckValidationCommanderCount; over > 0 {
+		output.WriteString(fmt.Sprintf("**Deck Size:** %d cards (%d over %d)\n",
+			size, over, deckValidationCommanderCount))
+	} else {
+		output.WriteString(fmt.Sprintf("**Deck Size:** %d cards\n", size))
+	}
+
+	if data != nil {
+		output.WriteString(fmt.Sprintf("**Commander:** %s (%d EDHREC decks)\n", data.Card.Name, data.NumDecks))
+	} else {
+		output.WriteString("\n⚠️ EDHREC recommendations weren't available, so cuts are ranked by mana value " +
+			"and card type only.\n")
+	}
+
+	if len(cuts) == 0 {
+		output.WriteString("\nThe mainboard has no nonland cards to suggest cutting.\n")
+		return output.String()
+	}
+
+	count = min(count, len(cuts))
+	output.WriteString(fmt.Sprintf("\n%d suggested removals, most cuttable first:\n\n", count))
+	for i, cut := range cuts[:count] {
+		output.WriteString(fmt.Sprintf("%d. **%s** - %s\n", i+1, cut.name, cut.reason))
+	}
+
+	output.WriteString("\n*Advisory only: these rankings can't see your deck's plan, combos, or pet cards. " +
+		"Lands are never suggested.*\n")
+	return output.String()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// cutsTestDeck is a 103-card Krenko deck for the suggest_cuts tests.
+func cutsTestDeck() *Deck {
+	return &Deck{
+		Name:       "Krenko Tokens",
+		Commanders: []CardEntry{{Name: "Krenko, Mob Boss", TypeLine: "Legendary Creature — Goblin", Quantity: 1}},
+		Mainboard: []CardEntry{
+			{Name: "Goblin Matron", TypeLine: "Creature — Goblin", CMC: 3, Quantity: 1},
+			{Name: "Shivan Dragon", TypeLine: "Creature — Dragon", CMC: 6, Quantity: 1},
+			{Name: "Lightning Bolt", TypeLine: "Instant", CMC: 1, Quantity: 1},
+			{Name: "Divination", TypeLine: "Sorcery", CMC: 3, Quantity: 1},
+			{Name: "Impact Tremors", TypeLine: "Enchantment", CMC: 2, Quantity: 1},
+			{Name: "Mountain", TypeLine: "Basic Land — Mountain", Quantity: 97},
+		},
+	}
+}
+
+func TestSuggestCuts(t *testing.T) {
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Krenko, Mob Boss"},
+		NumDecks: 1000,
+		CardLists: []EDHRECCardList{
+			{Header: "High Synergy Cards", Tag: "highsynergycards", CardViews: []EDHRECCardView{
+				{Name: "Impact Tremors", Inclusion: 700, Synergy: 0.6},
+				{Name: "Goblin Matron", Inclusion: 500, Synergy: 0.3},
+			}},
+			{Header: "Instants", Tag: "instants", CardViews: []EDHRECCardView{
+				{Name: "lightning bolt", Inclusion: 200, Synergy: -0.05},
+				{Name: "Impact Tremors", Inclusion: 1, Synergy: -1},
+			}},
+		},
+	}
+
+	cuts := suggestCuts(cutsTestDeck(), data)
+	var names []string
+	for _, cut := range cuts {
+		names = append(names, cut.name)
+	}
+	want := "Shivan Dragon, Lightning Bolt, Divination, Goblin Matron, Impact Tremors"
+	if got := strings.Join(names, ", "); got != want {
+		t.Fatalf("suggestCuts() = %s, want %s", got, want)
+	}
+
+	got := FormatSuggestedCutsForDisplay(cutsTestDeck(), data, cuts, 3)
+	for _, wantLine := range []string{
+		"# Suggested Cuts: Krenko Tokens",
+		"**Deck Size:** 103 cards (3 over 100)",
+		"**Commander:** Krenko, Mob Boss (1000 EDHREC decks)",
+		"3 suggested removals, most cuttable first:",
+		"1. **Shivan Dragon** - not among EDHREC's recommendations and costs 6 mana",
+		"2. **Lightning Bolt** - synergy -0.05, in 20.0% of decks",
+		"3. **Divination** - not among EDHREC's recommendations",
+		"Advisory only",
+	} {
+		if !strings.Contains(got, wantLine) {
+			t.Errorf("FormatSuggestedCutsForDisplay() missing %q in output: %s", wantLine, got)
+		}
+	}
+	if strings.Contains(got, "Goblin Matron") || strings.Contains(got, "Mountain") {
+		t.Errorf("FormatSuggestedCutsForDisplay() should list only 3 nonland cuts: %s", got)
+	}
+}
+
+func TestSuggestCutsWithoutEDHREC(t *testing.T) {
+	cuts := suggestCuts(cutsTestDeck(), nil)
+	var names []string
+	for _, cut := range cuts {
+		names = append(names, cut.name)
+	}
+	// Highest mana value first; at equal cost creatures go before sorceries
+	want := "Shivan Dragon, Goblin Matron, Divination, Impact Tremors, Lightning Bolt"
+	if got := strings.Join(names, ", "); got != want {
+		t.Fatalf("suggestCuts() without EDHREC = %s, want %s", got, want)
+	}
+
+	got := FormatSuggestedCutsForDisplay(cutsTestDeck(), nil, cuts, 2)
+	for _, wantLine := range []string{
+		"EDHREC recommendations weren't available",
+		"1. **Shivan Dragon** - costs 6 mana",
+		"2. **Goblin Matron** - costs 3 mana",
+	} {
+		if !strings.Contains(got, wantLine) {
+			t.Errorf("FormatSuggestedCutsForDisplay() missing %q in output: %s", wantLine, got)
+		}
+	}
+
+	empty := FormatSuggestedCutsForDisplay(&Deck{Name: "Lands"}, nil, nil, 5)
+	if !strings.Contains(empty, "no nonland cards") {
+		t.Errorf("FormatSuggestedCutsForDisplay() with no candidates = %s", empty)
+	}
+}
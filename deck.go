@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CardEntry is one line of a Deck: a card and how many copies the deck runs.
+type CardEntry struct {
+	Name     string
+	TypeLine string
+	ManaCost string
+	CMC      float64
+	// ColorIdentity lists the card's color identity as color letters (e.g., ["W", "U"]).
+	ColorIdentity []string
+	Set           string
+	Quantity      int
+}
+
+// Deck is a decklist independent of the site it came from, so the formatters,
+// validator, and analysis tools work the same for every deck source. Each board is
+// sorted by card name.
+type Deck struct {
+	ID           string
+	Name         string
+	Format       string
+	Description  string
+	URL          string
+	Authors      []string
+	CreatedAt    string
+	LastUpdated  string
+	ViewCount    int
+	LikeCount    int
+	CommentCount int
+	Commanders   []CardEntry
+	Companions   []CardEntry
+	Mainboard    []CardEntry
+	Sideboard    []CardEntry
+	Maybeboard   []CardEntry
+}
+
+// sortCardEntries sorts a board by card name.
+func sortCardEntries(entries []CardEntry) {
+	slices.SortFunc(entries, func(a, b CardEntry) int { return strings.Compare(a.Name, b.Name) })
+}
+
+// boardQuantities sums a board's quantities by card name.
+func boardQuantities(board []CardEntry) map[string]int {
+	quantities := make(map[string]int, len(board))
+	for _, entry := range board {
+		quantities[entry.Name] += entry.Quantity
+	}
+	return quantities
+}
+
+// moxfieldBoard converts a Moxfield board to a sorted board.
+func moxfieldBoard(board map[string]MoxfieldCardEntry) []CardEntry {
+	if len(board) == 0 {
+		return nil
+	}
+
+	entries := make([]CardEntry, 0, len(board))
+	for _, entry := range board {
+		entries = append(entries, CardEntry{
+			Name:          entry.Card.Name,
+			TypeLine:      entry.Card.TypeLine,
+			ManaCost:      entry.Card.ManaCost,
+			CMC:           entry.Card.CMC,
+			ColorIdentity: entry.Card.ColorIdentity,
+			Set:           entry.Card.Set,
+			Quantity:      entry.Quantity,
+		})
+	}
+	sortCardEntries(entries)
+	return entries
+}
+
+// moxfieldAuthors returns the author names of a Moxfield deck. Moxfield sends either
+// a list of names or a list of objects; only names are kept.
+func moxfieldAuthors(authors interface{}) []string {
+	list, ok := authors.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, author := range list {
+		if name, isString := author.(string); isString {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ToDeck converts the Moxfield deck to a Deck.
+func (d *MoxfieldDeck) ToDeck() *Deck {
+	return &Deck{
+		ID:           d.PublicID,
+		Name:         d.Name,
+		Format:       d.Format,
+		Description:  d.Description,
+		URL:          fmt.Sprintf("https://www.moxfield.com/decks/%s", d.PublicID),
+		Authors:      moxfieldAuthors(d.Authors),
+		CreatedAt:    d.CreatedAt,
+		LastUpdated:  d.LastUpdated,
+		ViewCount:    d.ViewCount,
+		LikeCount:    d.LikeCount,
+		CommentCount: d.CommentCount,
+		Commanders:   moxfieldBoard(d.Commanders),
+		Companions:   moxfieldBoard(d.Companions),
+		Mainboard:    moxfieldBoard(d.Mainboard),
+		Sideboard:    moxfieldBoard(d.Sideboard),
+		Maybeboard:   moxfieldBoard(d.Maybeboard),
+	}
+}
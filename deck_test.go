@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMoxfieldDeckToDeck(t *testing.T) {
+	moxfieldDeck := &MoxfieldDeck{
+		PublicID:     "abc123",
+		Name:         "Omnath Lands",
+		Format:       "commander",
+		Authors:      []interface{}{"landlover", map[string]interface{}{"userName": "ignored"}},
+		ViewCount:    10,
+		LikeCount:    2,
+		CommentCount: 1,
+		Commanders: map[string]MoxfieldCardEntry{
+			"Omnath": {Quantity: 1, Card: MoxfieldCardInfo{
+				Name: "Omnath, Locus of Rage", TypeLine: "Legendary Creature — Elemental", CMC: 5,
+				ColorIdentity: []string{"R", "G"},
+			}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"Sol Ring":    {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring", Set: "c21", TypeLine: "Artifact", CMC: 1}},
+			"Forest":      {Quantity: 20, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+			"Lotus Cobra": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Lotus Cobra", ManaCost: "{1}{G}"}},
+		},
+	}
+
+	got := moxfieldDeck.ToDeck()
+
+	if got.ID != "abc123" || got.URL != "https://www.moxfield.com/decks/abc123" || got.CommentCount != 1 {
+		t.Errorf("ToDeck() = %+v", got)
+	}
+	if strings.Join(got.Authors, ",") != "landlover" {
+		t.Errorf("ToDeck() authors = %v, want only the string authors", got.Authors)
+	}
+	if len(got.Commanders) != 1 || got.Commanders[0].Name != "Omnath, Locus of Rage" || got.Commanders[0].CMC != 5 {
+		t.Errorf("ToDeck() commanders = %+v", got.Commanders)
+	}
+
+	var names []string
+	for _, entry := range got.Mainboard {
+		names = append(names, entry.Name)
+	}
+	if strings.Join(names, ",") != "Forest,Lotus Cobra,Sol Ring" {
+		t.Errorf("ToDeck() mainboard = %v, want sorted by name", names)
+	}
+	if got.Mainboard[0].Quantity != 20 || got.Mainboard[2].Set != "c21" {
+		t.Errorf("ToDeck() mainboard entries = %+v", got.Mainboard)
+	}
+	if got.Sideboard != nil || got.Companions != nil {
+		t.Errorf("ToDeck() empty boards = %v, %v, want nil", got.Sideboard, got.Companions)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DecklistEntry is a single card line parsed from a decklist.
+type DecklistEntry struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Line     int    `json:"line"`
+}
+
+// DecklistParseResult holds the parsed entries of a decklist and any problems found.
+type DecklistParseResult struct {
+	Entries    []DecklistEntry `json:"entries"`
+	Warnings   []string        `json:"warnings"`
+	TotalCards int             `json:"total_cards"`
+}
+
+// Names expands the parsed entries into one card name per copy.
+func (r DecklistParseResult) Names() []string {
+	names := make([]string, 0, r.TotalCards)
+	for _, entry := range r.Entries {
+		for range entry.Quantity {
+			names = append(names, entry.Name)
+		}
+	}
+	return names
+}
+
+// maxDecklistQuantity is the largest leading number read as a quantity. Larger
+// numbers, as in "1996 World Champion", are read as part of the card name.
+const maxDecklistQuantity = 99
+
+// decklistQuantityPattern matches a leading quantity such as "1 ", "1x " or "10X ".
+var decklistQuantityPattern = regexp.MustCompile(`^(\S+?)[xX]?\s+(.+)$`) //nolint:gochecknoglobals // compiled once
+
+// decklistAnnotationPattern matches trailing set/collector/foil annotations,
+// e.g. "(C21) 263", "[C21]", "*F*" or "#123".
+var decklistAnnotationPattern = regexp.MustCompile( //nolint:gochecknoglobals // compiled once
+	`(\s+\([A-Za-z0-9]+\)(\s+[A-Za-z0-9-]+)?|\s+\[[A-Za-z0-9]+\]|\s+\*[A-Za-z]+\*|\s+#\S+)+$`,
+)
+
+// decklistSectionHeaders are lines that label a section of an exported decklist.
+func decklistSectionHeaders() []string {
+	return []string{"commander", "commanders", "deck", "mainboard", "main", "sideboard", "maybeboard", "companion"}
+}
+
+// ParseDecklist parses a decklist from a JSON array of names or from text with one card per line.
+func ParseDecklist(decklistStr string) DecklistParseResult {
+	result := DecklistParseResult{
+		Entries:  []DecklistEntry{},
+		Warnings: []string{},
+	}
+
+	var jsonNames []string
+	if unmarshalErr := json.Unmarshal([]byte(decklistStr), &jsonNames); unmarshalErr == nil {
+		for i, name := range jsonNames {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("entry %d: empty card name skipped", i+1))
+				continue
+			}
+			result.Entries = append(result.Entries, DecklistEntry{Name: name, Quantity: 1, Line: i + 1})
+			result.TotalCards++
+		}
+		return result
+	}
+
+	for i, line := range strings.Split(decklistStr, "\n") {
+		entry, warning, ok := parseDecklistLine(line, i+1)
+		if warning != "" {
+			result.Warnings = append(result.Warnings, warning)
+		}
+		if !ok {
+			continue
+		}
+		result.Entries = append(result.Entries, entry)
+		result.TotalCards += entry.Quantity
+	}
+
+	return result
+}
+
+// parseDecklistLine parses a single text decklist line. It returns ok=false for lines that
+// hold no card (blank, comments, section headers) or that could not be parsed.
+func parseDecklistLine(line string, lineNum int) (DecklistEntry, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+		return DecklistEntry{}, "", false
+	}
+
+	header := strings.ToLower(strings.TrimSuffix(line, ":"))
+	for _, section := range decklistSectionHeaders() {
+		if header == section {
+			return DecklistEntry{}, "", false
+		}
+	}
+
+	quantity := 1
+	name := line
+	var warning string
+	if match := decklistQuantityPattern.FindStringSubmatch(line); match != nil {
+		count := match[1]
+		switch {
+		case !looksNumeric(count):
+			// The line is a card name without a quantity
+		case !isPlainCount(count):
+			return DecklistEntry{}, fmt.Sprintf("line %d: invalid quantity %q in %q", lineNum, count, line), false
+		default:
+			qty, err := strconv.Atoi(strings.TrimRight(count, "xX"))
+			switch {
+			case err != nil || qty > maxDecklistQuantity:
+				warning = fmt.Sprintf(
+					"line %d: %q is too large for a quantity, so the whole line was read as a card name", lineNum, count,
+				)
+			case qty == 0:
+				return DecklistEntry{}, fmt.Sprintf("line %d: invalid quantity %d in %q", lineNum, qty, line), false
+			default:
+				quantity = qty
+				name = match[2]
+			}
+		}
+	} else if looksNumeric(line) {
+		return DecklistEntry{}, fmt.Sprintf("line %d: quantity without a card name: %q", lineNum, line), false
+	}
+
+	if stripped := decklistAnnotationPattern.ReplaceAllString(name, ""); stripped != name {
+		if warning == "" {
+			warning = fmt.Sprintf("line %d: ignored set/printing annotation in %q", lineNum, line)
+		}
+		name = stripped
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return DecklistEntry{}, fmt.Sprintf("line %d: could not find a card name in %q", lineNum, line), false
+	}
+
+	return DecklistEntry{Name: name, Quantity: quantity, Line: lineNum}, warning, true
+}
+
+// isPlainCount reports whether s is a plain decimal count such as "3" or "3x", with
+// no sign or decimal point.
+func isPlainCount(s string) bool {
+	s = strings.TrimRight(s, "xX")
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// looksNumeric reports whether s starts like a number (digits, sign or decimal point).
+func looksNumeric(s string) bool {
+	s = strings.TrimRight(s, "xX")
+	if s == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// FormatDecklistParseForDisplay formats a decklist parse result for text display.
+func FormatDecklistParseForDisplay(result DecklistParseResult) string {
+	var output strings.Builder
+
+	output.WriteString("# Decklist Parse Result\n\n")
+	output.WriteString(fmt.Sprintf("**Entries:** %d | **Total Cards:** %d\n\n", len(result.Entries), result.TotalCards))
+
+	for _, entry := range result.Entries {
+		output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
+	}
+
+	if len(result.Warnings) > 0 {
+		output.WriteString(fmt.Sprintf("\n## Warnings (%d)\n\n", len(result.Warnings)))
+		for _, warning := range result.Warnings {
+			output.WriteString(fmt.Sprintf("- ⚠️ %s\n", warning))
+		}
+	} else {
+		output.WriteString("\n✅ No problems found.\n")
+	}
+
+	return output.String()
+}
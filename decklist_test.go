@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDecklist(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantEntries  []DecklistEntry
+		wantTotal    int
+		wantWarnings int
+	}{
+		{
+			name:  "JSON array",
+			input: `["Sol Ring", "Arcane Signet", ""]`,
+			wantEntries: []DecklistEntry{
+				{Name: "Sol Ring", Quantity: 1, Line: 1},
+				{Name: "Arcane Signet", Quantity: 1, Line: 2},
+			},
+			wantTotal:    2,
+			wantWarnings: 1,
+		},
+		{
+			name:  "blank lines and comments",
+			input: "\n1 Sol Ring\n\n   \n// ramp\n# notes\n1 Arcane Signet\n",
+			wantEntries: []DecklistEntry{
+				{Name: "Sol Ring", Quantity: 1, Line: 2},
+				{Name: "Arcane Signet", Quantity: 1, Line: 7},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:  "quantity formats",
+			input: "1x Sol Ring\n10 Island\n3X Forest\nCommand Tower",
+			wantEntries: []DecklistEntry{
+				{Name: "Sol Ring", Quantity: 1, Line: 1},
+				{Name: "Island", Quantity: 10, Line: 2},
+				{Name: "Forest", Quantity: 3, Line: 3},
+				{Name: "Command Tower", Quantity: 1, Line: 4},
+			},
+			wantTotal: 15,
+		},
+		{
+			name:  "weird quantities",
+			input: "0 Sol Ring\n-1 Mana Crypt\n1.5 Mana Vault\n4\n1 Arcane Signet",
+			wantEntries: []DecklistEntry{
+				{Name: "Arcane Signet", Quantity: 1, Line: 5},
+			},
+			wantTotal:    1,
+			wantWarnings: 4,
+		},
+		{
+			name:  "name starting with a number",
+			input: "1996 World Champion\n1 1996 World Champion\n40 Relentless Rats",
+			wantEntries: []DecklistEntry{
+				{Name: "1996 World Champion", Quantity: 1, Line: 1},
+				{Name: "1996 World Champion", Quantity: 1, Line: 2},
+				{Name: "Relentless Rats", Quantity: 40, Line: 3},
+			},
+			wantTotal:    42,
+			wantWarnings: 1,
+		},
+		{
+			name:  "set annotations",
+			input: "1 Sol Ring (C21) 263\n1 Arcane Signet [CMR]\n1 Command Tower *F*\n1 Lightning Greaves (CMM) 379 *F*",
+			wantEntries: []DecklistEntry{
+				{Name: "Sol Ring", Quantity: 1, Line: 1},
+				{Name: "Arcane Signet", Quantity: 1, Line: 2},
+				{Name: "Command Tower", Quantity: 1, Line: 3},
+				{Name: "Lightning Greaves", Quantity: 1, Line: 4},
+			},
+			wantTotal:    4,
+			wantWarnings: 4,
+		},
+		{
+			name:  "section headers",
+			input: "Commander\n1 Atraxa, Praetors' Voice\n\nDeck:\n1 Sol Ring\nSideboard\n1 Swords to Plowshares",
+			wantEntries: []DecklistEntry{
+				{Name: "Atraxa, Praetors' Voice", Quantity: 1, Line: 2},
+				{Name: "Sol Ring", Quantity: 1, Line: 5},
+				{Name: "Swords to Plowshares", Quantity: 1, Line: 7},
+			},
+			wantTotal: 3,
+		},
+		{
+			name:         "empty input",
+			input:        "",
+			wantEntries:  []DecklistEntry{},
+			wantTotal:    0,
+			wantWarnings: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseDecklist(tt.input)
+
+			if len(got.Entries) != len(tt.wantEntries) {
+				t.Fatalf("ParseDecklist() entries = %+v, want %+v", got.Entries, tt.wantEntries)
+			}
+
+			for i, entry := range got.Entries {
+				if entry != tt.wantEntries[i] {
+					t.Errorf("ParseDecklist() entry[%d] = %+v, want %+v", i, entry, tt.wantEntries[i])
+				}
+			}
+
+			if got.TotalCards != tt.wantTotal {
+				t.Errorf("ParseDecklist() total = %v, want %v", got.TotalCards, tt.wantTotal)
+			}
+
+			if len(got.Warnings) != tt.wantWarnings {
+				t.Errorf("ParseDecklist() warnings = %v, want %d", got.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDecklistParseResultNames(t *testing.T) {
+	result := ParseDecklist("1 Sol Ring\n3 Island")
+	names := result.Names()
+
+	if len(names) != 4 {
+		t.Fatalf("Names() returned %d names, want 4", len(names))
+	}
+
+	if strings.Count(strings.Join(names, ","), "Island") != 3 {
+		t.Errorf("Names() = %v, want three copies of Island", names)
+	}
+}
+
+func TestFormatDecklistParseForDisplay(t *testing.T) {
+	got := FormatDecklistParseForDisplay(ParseDecklist("2 Island\n0 Sol Ring"))
+
+	for _, want := range []string{"Decklist Parse Result", "2x Island", "Warnings (1)", "invalid quantity"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDecklistParseForDisplay() missing %q in output", want)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// manaCurveTopBucket is the highest mana value bucket; it also holds everything above it.
+	manaCurveTopBucket = 7
+	// curveChartWidth is the longest bar in the curve chart, in characters; taller
+	// buckets are scaled down to fit.
+	curveChartWidth = 40
+	// curveChartHighCMC is the mana value from which a nonland card counts toward
+	// the top-heavy share in the curve chart.
+	curveChartHighCMC = 5
+)
+
+// manaSymbolPattern matches a single mana symbol such as {2}, {G}, or {W/U}.
+var manaSymbolPattern = regexp.MustCompile(`\{([^}]+)\}`) //nolint:gochecknoglobals // compiled once
+
+// manaSymbols returns the symbols of a mana cost without braces, e.g. "{2}{G}{G}" -> [2 G G].
+// Only the front face of a multi-faced card's cost ("{1}{U} // {U}") is considered.
+func manaSymbols(manaCost string) []string {
+	front, _, _ := strings.Cut(manaCost, " // ")
+
+	matches := manaSymbolPattern.FindAllStringSubmatch(front, -1)
+	symbols := make([]string, len(matches))
+	for i, m := range matches {
+		symbols[i] = strings.ToUpper(m[1])
+	}
+	return symbols
+}
+
+// manaValue computes the mana value of a mana cost. X costs count as zero and
+// hybrid symbols with a generic half ({2/W}) count as their generic amount.
+func manaValue(manaCost string) int {
+	total := 0
+	for _, symbol := range manaSymbols(manaCost) {
+		first, _, _ := strings.Cut(symbol, "/")
+		if n, err := strconv.Atoi(first); err == nil {
+			total += n
+			continue
+		}
+		if symbol == "X" || symbol == "Y" || symbol == "Z" {
+			continue
+		}
+		total++
+	}
+	return total
+}
+
+// isLandCard reports whether a deck card is a land, judged by its front face.
+func isLandCard(card CardEntry) bool {
+	front, _, _ := strings.Cut(card.TypeLine, " // ")
+	return strings.Contains(strings.ToLower(front), "land")
+}
+
+// isCreatureCard reports whether a deck card is a creature, judged by its front face.
+func isCreatureCard(card CardEntry) bool {
+	front, _, _ := strings.Cut(card.TypeLine, " // ")
+	return strings.Contains(strings.ToLower(front), "creature")
+}
+
+// cardManaValue returns the card's mana value, preferring the deck source's cmc and
+// falling back to parsing the mana cost when cmc is missing.
+func cardManaValue(card CardEntry) int {
+	if card.CMC > 0 {
+		return int(card.CMC)
+	}
+	return manaValue(card.ManaCost)
+}
+
+// manaCurve counts nonland cards by mana value. creatures counts the creatures
+// within each bucket.
+type manaCurve struct {
+	buckets   [manaCurveTopBucket + 1]int
+	creatures [manaCurveTopBucket + 1]int
+	lands     int
+}
+
+// computeManaCurve buckets mainboard cards by mana value, counting lands separately.
+func computeManaCurve(mainboard []CardEntry) manaCurve {
+	var curve manaCurve
+	for _, entry := range mainboard {
+		if isLandCard(entry) {
+			curve.lands += entry.Quantity
+			continue
+		}
+		bucket := min(cardManaValue(entry), manaCurveTopBucket)
+		curve.buckets[bucket] += entry.Quantity
+		if isCreatureCard(entry) {
+			curve.creatures[bucket] += entry.Quantity
+		}
+	}
+	return curve
+}
+
+// curveBucketLabel returns the label of a mana curve bucket, e.g. "3" or "7+".
+func curveBucketLabel(cmc int) string {
+	label := strconv.Itoa(cmc)
+	if cmc == manaCurveTopBucket {
+		label += "+"
+	}
+	return label
+}
+
+// formatManaCurve renders a mana curve as a text histogram.
+func formatManaCurve(curve manaCurve) string {
+	var output strings.Builder
+	output.WriteString("\n## Mana Curve\n\n```\n")
+	for cmc, count := range curve.buckets {
+		output.WriteString(fmt.Sprintf("%-2s CMC: %s (%d)\n", curveBucketLabel(cmc), strings.Repeat("#", count), count))
+	}
+	output.WriteString("```\n")
+	output.WriteString(fmt.Sprintf("\n**Lands:** %d (not included in the curve)\n", curve.lands))
+	return output.String()
+}
+
+// FormatCurveChartForDisplay renders a deck's mana curve as a Unicode bar chart for
+// monospace display, each bar split into creatures (█) and noncreatures (░). Bars
+// are scaled down when the tallest bucket is wider than curveChartWidth.
+func FormatCurveChartForDisplay(deck *Deck) string {
+	curve := computeManaCurve(deck.Mainboard)
+
+	tallest := slices.Max(curve.buckets[:])
+	scale := 1.0
+	if tallest > curveChartWidth {
+		scale = float64(curveChartWidth) / float64(tallest)
+	}
+	barWidth := int(math.Round(float64(tallest) * scale))
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Mana Curve: %s\n\n```\n", deck.Name))
+
+	nonland, high := 0, 0
+	for cmc, count := range curve.buckets {
+		creatures := curve.creatures[cmc]
+		creatureCells := int(math.Round(float64(creatures) * scale))
+		totalCells := int(math.Round(float64(count) * scale))
+		bar := strings.Repeat("█", creatureCells) + strings.Repeat("░", totalCells-creatureCells)
+
+		output.WriteString(fmt.Sprintf("%-2s │%s%s %2d (%d creatures, %d noncreatures)\n",
+			curveBucketLabel(cmc), bar, strings.Repeat(" ", barWidth-totalCells), count, creatures, count-creatures))
+
+		nonland += count
+		if cmc >= curveChartHighCMC {
+			high += count
+		}
+	}
+	output.WriteString("```\n\n█ creature  ░ noncreature")
+	if scale < 1 {
+		output.WriteString(fmt.Sprintf(" (bars scaled to fit %d characters)", curveChartWidth))
+	}
+	output.WriteString("\n\n")
+
+	creatureTotal := 0
+	for _, count := range curve.creatures {
+		creatureTotal += count
+	}
+	output.WriteString(fmt.Sprintf("**Nonland Cards:** %d (%d creatures, %d noncreatures)\n",
+		nonland, creatureTotal, nonland-creatureTotal))
+	if nonland > 0 {
+		output.WriteString(fmt.Sprintf("**%d+ CMC:** %d cards (%.1f%% of nonland cards)\n",
+			curveChartHighCMC, high, float64(high)/float64(nonland)*percentageMultiplier))
+	}
+	output.WriteString(fmt.Sprintf("**Lands:** %d (not included in the curve)\n", curve.lands))
+
+	return output.String()
+}
+
+// pipColors is the display order of colored and colorless mana pips.
+func pipColors() []string {
+	return []string{"W", "U", "B", "R", "G", "C"}
+}
+
+// colorPips tallies colored and colorless mana symbols.
+type colorPips struct {
+	counts map[string]int
+	total  int
+}
+
+// symbolColors returns the colors a mana symbol requires. Hybrid symbols ({W/U})
+// require each of their colors, Phyrexian ({G/P}) and two-generic hybrid ({2/W})
+// symbols require their one color, and generic or X symbols require none.
+func symbolColors(symbol string) []string {
+	var colors []string
+	for _, part := range strings.Split(symbol, "/") {
+		if slices.Contains(pipColors(), part) {
+			colors = append(colors, part)
+		}
+	}
+	return colors
+}
+
+// computeColorPips tallies pips across every face of each mainboard card's mana cost.
+func computeColorPips(mainboard []CardEntry) colorPips {
+	pips := colorPips{counts: make(map[string]int)}
+	for _, entry := range mainboard {
+		for _, face := range strings.Split(entry.ManaCost, " // ") {
+			for _, symbol := range manaSymbols(face) {
+				for _, color := range symbolColors(symbol) {
+					pips.counts[color] += entry.Quantity
+					pips.total += entry.Quantity
+				}
+			}
+		}
+	}
+	return pips
+}
+
+// formatColorPips renders each color's pip count and share of all pips.
+func formatColorPips(pips colorPips) string {
+	if pips.total == 0 {
+		return ""
+	}
+
+	var output strings.Builder
+	output.WriteString("\n## Color Requirements\n\n")
+	for _, color := range pipColors() {
+		count := pips.counts[color]
+		if count == 0 {
+			continue
+		}
+		percent := float64(count) / float64(pips.total) * percentageMultiplier
+		output.WriteString(fmt.Sprintf("- **%s:** %d pips (%.1f%%)\n", color, count, percent))
+	}
+	output.WriteString("\n*Hybrid symbols count once toward each of their colors; " +
+		"Phyrexian symbols count toward their color.*\n")
+	return output.String()
+}
+
+// deckColorIdentity returns the deck's color identity in WUBRG order: the union of
+// its commanders' identities, or of every mainboard card's when it has no commander.
+func deckColorIdentity(deck *Deck) []string {
+	board := deck.Commanders
+	if len(board) == 0 {
+		board = deck.Mainboard
+	}
+
+	present := make(map[string]bool)
+	for _, entry := range board {
+		for _, color := range entry.ColorIdentity {
+			present[strings.ToUpper(color)] = true
+		}
+	}
+
+	var colors []string
+	for _, color := range []string{"W", "U", "B", "R", "G"} {
+		if present[color] {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
+
+// FormatDeckStatsForDisplay formats a compact statistics summary of a deck.
+func FormatDeckStatsForDisplay(deck *Deck) string {
+	groups := groupDeckCards(deck.Mainboard)
+
+	commanderCount := 0
+	commanderNames := make([]string, 0, len(deck.Commanders))
+	for _, entry := range deck.Commanders {
+		commanderCount += entry.Quantity
+		commanderNames = append(commanderNames, entry.Name)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Deck Stats: %s\n\n", deck.Name))
+	if len(commanderNames) > 0 {
+		output.WriteString(fmt.Sprintf("**Commander:** %s\n", strings.Join(commanderNames, " & ")))
+	}
+
+	colors := "Colorless"
+	if identity := deckColorIdentity(deck); len(identity) > 0 {
+		colors = strings.Join(identity, ", ")
+	}
+	output.WriteString(fmt.Sprintf("**Color Identity:** %s\n", colors))
+	output.WriteString(fmt.Sprintf("**Total Cards:** %d\n", groups.totalCards+commanderCount))
+	output.WriteString(fmt.Sprintf("**Lands:** %d\n", groups.landCount))
+
+	if groups.nonlandCount > 0 {
+		average := float64(groups.nonlandCMCSum) / float64(groups.nonlandCount)
+		output.WriteString(fmt.Sprintf("**Average CMC (nonland):** %.2f\n", average))
+	}
+
+	output.WriteString("\n**Card Types:**\n")
+	for _, cardType := range deckCardTypes() {
+		if count := groups.typeCounts[cardType]; count > 0 {
+			output.WriteString(fmt.Sprintf("- %s: %d\n", cardType, count))
+		}
+	}
+
+	return output.String()
+}
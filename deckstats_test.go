@@ -0,0 +1,242 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestManaValue(t *testing.T) {
+	tests := []struct {
+		cost string
+		want int
+	}{
+		{cost: "", want: 0},
+		{cost: "{1}", want: 1},
+		{cost: "{2}{G}{G}", want: 4},
+		{cost: "{X}{R}{R}", want: 2},
+		{cost: "{10}", want: 10},
+		{cost: "{W/U}{W/U}", want: 2},
+		{cost: "{2/W}{2/W}", want: 4},
+		{cost: "{G/P}", want: 1},
+		{cost: "{1}{U} // {U}", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cost, func(t *testing.T) {
+			if got := manaValue(tt.cost); got != tt.want {
+				t.Errorf("manaValue(%q) = %d, want %d", tt.cost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeManaCurve(t *testing.T) {
+	mainboard := map[string]MoxfieldCardEntry{
+		"sol":     {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring", TypeLine: "Artifact", CMC: 1}},
+		"signet":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Arcane Signet", TypeLine: "Artifact", CMC: 2}},
+		"cult":    {Quantity: 1, Card: MoxfieldCardInfo{Name: "Cultivate", TypeLine: "Sorcery", ManaCost: "{2}{G}"}},
+		"ulamog":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Ulamog", TypeLine: "Legendary Creature", CMC: 10}},
+		"thopter": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Ornithopter", TypeLine: "Artifact Creature", CMC: 0}},
+		"forest":  {Quantity: 30, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+		"mdfc": {Quantity: 1, Card: MoxfieldCardInfo{
+			Name:     "Bala Ged Recovery // Bala Ged Sanctuary",
+			TypeLine: "Sorcery // Land",
+			CMC:      3,
+		}},
+	}
+
+	curve := computeManaCurve(moxfieldBoard(mainboard))
+
+	want := [manaCurveTopBucket + 1]int{1, 1, 1, 2, 0, 0, 0, 1}
+	if curve.buckets != want {
+		t.Errorf("computeManaCurve() buckets = %v, want %v", curve.buckets, want)
+	}
+	if curve.lands != 30 {
+		t.Errorf("computeManaCurve() lands = %d, want 30", curve.lands)
+	}
+}
+
+func TestFormatDeckForDisplayManaCurve(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Name: "Curve Test",
+		Mainboard: map[string]MoxfieldCardEntry{
+			"bear":   {Quantity: 3, Card: MoxfieldCardInfo{Name: "Grizzly Bears", TypeLine: "Creature — Bear", CMC: 2}},
+			"titan":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Craterhoof", TypeLine: "Creature", CMC: 8}},
+			"forest": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+		},
+	}
+
+	got := FormatDeckForDisplay(deck.ToDeck(), nil)
+
+	for _, want := range []string{"## Mana Curve", "2  CMC: ### (3)", "7+ CMC: # (1)", "0  CMC:  (0)", "**Lands:** 10"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDeckForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+}
+
+func TestSymbolColors(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{symbol: "G", want: "G"},
+		{symbol: "C", want: "C"},
+		{symbol: "2", want: ""},
+		{symbol: "X", want: ""},
+		{symbol: "W/U", want: "W,U"},
+		{symbol: "G/P", want: "G"},
+		{symbol: "2/W", want: "W"},
+		{symbol: "B/G/P", want: "B,G"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			if got := strings.Join(symbolColors(tt.symbol), ","); got != tt.want {
+				t.Errorf("symbolColors(%q) = %q, want %q", tt.symbol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeColorPips(t *testing.T) {
+	mainboard := map[string]MoxfieldCardEntry{
+		"cult":    {Quantity: 1, Card: MoxfieldCardInfo{ManaCost: "{2}{G}"}},
+		"wrath":   {Quantity: 2, Card: MoxfieldCardInfo{ManaCost: "{2}{W}{W}"}},
+		"hybrid":  {Quantity: 1, Card: MoxfieldCardInfo{ManaCost: "{G/W}"}},
+		"mutagen": {Quantity: 1, Card: MoxfieldCardInfo{ManaCost: "{G/P}"}},
+		"eldrazi": {Quantity: 1, Card: MoxfieldCardInfo{ManaCost: "{8}{C}"}},
+		"borrow":  {Quantity: 1, Card: MoxfieldCardInfo{ManaCost: "{1}{U}{U} // {U}"}},
+		"forest":  {Quantity: 10, Card: MoxfieldCardInfo{TypeLine: "Basic Land"}},
+	}
+
+	pips := computeColorPips(moxfieldBoard(mainboard))
+
+	want := map[string]int{"W": 5, "U": 3, "G": 3, "C": 1}
+	for _, color := range pipColors() {
+		if pips.counts[color] != want[color] {
+			t.Errorf("computeColorPips() %s = %d, want %d", color, pips.counts[color], want[color])
+		}
+	}
+	if pips.total != 12 {
+		t.Errorf("computeColorPips() total = %d, want 12", pips.total)
+	}
+
+	got := formatColorPips(pips)
+	for _, want := range []string{"## Color Requirements", "- **W:** 5 pips (41.7%)", "- **C:** 1 pips (8.3%)", "Hybrid"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatColorPips() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "**B:**") {
+		t.Errorf("formatColorPips() should omit colors with no pips: %s", got)
+	}
+}
+
+func TestFormatDeckStatsForDisplay(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Name: "Stats Test",
+		Commanders: map[string]MoxfieldCardEntry{
+			"tymna": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Tymna the Weaver", ColorIdentity: []string{"W", "B"}}},
+			"kraum": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Kraum, Ludevic's Opus", ColorIdentity: []string{"U", "R"}}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"ring":   {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring", TypeLine: "Artifact", CMC: 1}},
+			"bear":   {Quantity: 1, Card: MoxfieldCardInfo{Name: "Bear", TypeLine: "Creature — Bear", CMC: 2}},
+			"golem":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Golem", TypeLine: "Artifact Creature — Golem", CMC: 6}},
+			"bolt":   {Quantity: 1, Card: MoxfieldCardInfo{Name: "Bolt", TypeLine: "Instant", CMC: 1}},
+			"island": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Island", TypeLine: "Basic Land — Island"}},
+			"vault":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Vault", TypeLine: "Artifact Land"}},
+		},
+	}
+
+	got := FormatDeckStatsForDisplay(deck.ToDeck())
+
+	for _, want := range []string{
+		"# Deck Stats: Stats Test",
+		"**Commander:** Kraum, Ludevic's Opus & Tymna the Weaver",
+		"**Color Identity:** W, U, B, R",
+		"**Total Cards:** 17",
+		"**Lands:** 11",
+		"**Average CMC (nonland):** 2.50",
+		"- Creatures: 2",
+		"- Instants: 1",
+		"- Artifacts: 2",
+		"- Lands: 10",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDeckStatsForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "Sorceries") {
+		t.Errorf("FormatDeckStatsForDisplay() should omit empty card types: %s", got)
+	}
+}
+
+func TestDeckColorIdentityWithoutCommander(t *testing.T) {
+	deck := &MoxfieldDeck{Mainboard: map[string]MoxfieldCardEntry{
+		"a": {Quantity: 1, Card: MoxfieldCardInfo{ColorIdentity: []string{"g"}}},
+		"b": {Quantity: 1, Card: MoxfieldCardInfo{ColorIdentity: []string{"W", "G"}}},
+	}}
+
+	if got := strings.Join(deckColorIdentity(deck.ToDeck()), ","); got != "W,G" {
+		t.Errorf("deckColorIdentity() = %s, want W,G", got)
+	}
+	if got := deckColorIdentity((&MoxfieldDeck{}).ToDeck()); len(got) != 0 {
+		t.Errorf("deckColorIdentity() of an empty deck = %v, want none", got)
+	}
+}
+
+func TestFormatCurveChartForDisplay(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Name: "Chart Test",
+		Mainboard: map[string]MoxfieldCardEntry{
+			"bear":   {Quantity: 3, Card: MoxfieldCardInfo{Name: "Grizzly Bears", TypeLine: "Creature — Bear", CMC: 2}},
+			"signet": {Quantity: 2, Card: MoxfieldCardInfo{Name: "Arcane Signet", TypeLine: "Artifact", CMC: 2}},
+			"hoof":   {Quantity: 1, Card: MoxfieldCardInfo{Name: "Craterhoof", TypeLine: "Creature", CMC: 8}},
+			"wrath":  {Quantity: 1, Card: MoxfieldCardInfo{Name: "Wrath of God", TypeLine: "Sorcery", CMC: 4}},
+			"forest": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+		},
+	}
+
+	got := FormatCurveChartForDisplay(deck.ToDeck())
+
+	for _, want := range []string{
+		"# Mana Curve: Chart Test",
+		"0  │       0 (0 creatures, 0 noncreatures)",
+		"2  │███░░  5 (3 creatures, 2 noncreatures)",
+		"4  │░      1 (0 creatures, 1 noncreatures)",
+		"7+ │█      1 (1 creatures, 0 noncreatures)",
+		"**Nonland Cards:** 7 (4 creatures, 3 noncreatures)",
+		"**5+ CMC:** 1 cards (14.3% of nonland cards)",
+		"**Lands:** 10",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCurveChartForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "scaled") {
+		t.Errorf("FormatCurveChartForDisplay() should not scale a short curve: %s", got)
+	}
+}
+
+func TestFormatCurveChartForDisplayScalesTallBuckets(t *testing.T) {
+	deck := &Deck{
+		Name: "Tall",
+		Mainboard: []CardEntry{
+			{Name: "Elf", TypeLine: "Creature — Elf", CMC: 1, Quantity: 60},
+			{Name: "Ponder", TypeLine: "Sorcery", CMC: 1, Quantity: 20},
+			{Name: "Divination", TypeLine: "Sorcery", CMC: 3, Quantity: 4},
+		},
+	}
+
+	got := FormatCurveChartForDisplay(deck)
+
+	wantOne := "1  │" + strings.Repeat("█", 30) + strings.Repeat("░", 10) + " 80 (60 creatures, 20 noncreatures)"
+	wantThree := "3  │░░" + strings.Repeat(" ", 38) + "  4 (0 creatures, 4 noncreatures)"
+	for _, want := range []string{wantOne, wantThree, "(bars scaled to fit 40 characters)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCurveChartForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+}
@@ -6,14 +6,52 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 const percentageMultiplier = 100.0
 
+// edhrecPagesURL is the root of EDHREC's JSON page API.
+const edhrecPagesURL = "https://json.edhrec.com/pages"
+
 // EDHRECResponse represents the top-level response structure.
 type EDHRECResponse struct {
 	Container EDHRECContainer `json:"container"`
+	Panels    EDHRECPanels    `json:"panels"`
+}
+
+// EDHRECPanels holds the sidebar panels of a commander page.
+type EDHRECPanels struct {
+	TagLinks    []EDHRECTheme     `json:"taglinks"`
+	ComboCounts []EDHRECComboLink `json:"combocounts"`
+}
+
+// EDHRECComboLink is a combo listed on a commander page, e.g. "Card A + Card B".
+type EDHRECComboLink struct {
+	Value string `json:"value"`
+	Href  string `json:"href"`
+	Count int    `json:"count"`
+}
+
+// CommanderCombo is a combo played in a specific commander's decks.
+type CommanderCombo struct {
+	Cards []string
+	Decks int
+	URL   string
+}
+
+// EDHRECTheme is a theme or tribe build of a commander, such as "+1/+1 Counters".
+type EDHRECTheme struct {
+	Value string `json:"value"`
+	Slug  string `json:"slug"`
+	Count int    `json:"count"`
 }
 
 // EDHRECContainer wraps the JSON dictionary.
@@ -26,6 +64,9 @@ type EDHRECData struct {
 	Card      EDHRECCardInfo   `json:"card"`
 	CardLists []EDHRECCardList `json:"cardlists"`
 	NumDecks  int              `json:"num_decks"`
+	Similar   []EDHRECCardView `json:"similar,omitempty"`
+	// Budget reports whether the data came from the commander's budget page.
+	Budget bool `json:"-"`
 }
 
 // EDHRECCardInfo represents commander information.
@@ -55,6 +96,47 @@ type EDHRECCardView struct {
 	Prices    map[string]float64 `json:"prices"`
 }
 
+// EDHRECAverageDeck represents EDHREC's average decklist for a commander.
+type EDHRECAverageDeck struct {
+	Deck      []string        `json:"deck"`
+	Container EDHRECContainer `json:"container"`
+}
+
+// Entries parses the average decklist lines (e.g., "1 Sol Ring") into decklist entries.
+func (d *EDHRECAverageDeck) Entries() []DecklistEntry {
+	return ParseDecklist(strings.Join(d.Deck, "\n")).Entries
+}
+
+// ToDeck converts the average deck to a Deck so it can be exported with the deck
+// export formatters. The commander's own line, when present, goes on the commanders
+// board; it is added there when EDHREC leaves it out of the list.
+func (d *EDHRECAverageDeck) ToDeck(commander string) *Deck {
+	if name := d.Container.JSONDict.Card.Name; name != "" {
+		commander = name
+	}
+
+	mainboard := make(map[string]int)
+	for _, entry := range d.Entries() {
+		if normalizeCardName(entry.Name) == normalizeCardName(commander) {
+			continue
+		}
+		mainboard[entry.Name] += entry.Quantity
+	}
+
+	deck := &Deck{
+		Name:       commander + " (EDHREC Average Deck)",
+		Format:     "commander",
+		Commanders: []CardEntry{{Name: commander, Quantity: 1}},
+		Mainboard:  make([]CardEntry, 0, len(mainboard)),
+	}
+	for name, quantity := range mainboard {
+		deck.Mainboard = append(deck.Mainboard, CardEntry{Name: name, Quantity: quantity})
+	}
+	sortCardEntries(deck.Mainboard)
+
+	return deck
+}
+
 // EDHRECComboResponse represents combo data.
 type EDHRECComboResponse struct {
 	Container EDHRECComboContainer `json:"container"`
@@ -84,10 +166,26 @@ type EDHRECCombo struct {
 	Results []string `json:"results"`
 }
 
-// SanitizeCardName converts a card name to EDHREC URL format.
+// stripDiacritics removes accents from a card name ("Jötun Grunt" becomes "Jotun
+// Grunt") and spells out the Æ ligature, matching how EDHREC and Moxfield slug names.
+func stripDiacritics(name string) string {
+	name = strings.NewReplacer("Æ", "Ae", "æ", "ae").Replace(name)
+
+	// NFD splits accented letters into a base letter and combining marks, which are dropped
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn))), name)
+	if err != nil {
+		return name
+	}
+	return stripped
+}
+
+// SanitizeCardName converts a card name to EDHREC URL format. EDHREC names
+// double-faced, split, and adventure cards by their front face only.
 func SanitizeCardName(name string) string {
-	// Lowercase
-	sanitized := strings.ToLower(name)
+	name, _, _ = strings.Cut(name, " // ")
+
+	// Lowercase, without accents
+	sanitized := strings.ToLower(stripDiacritics(name))
 
 	// Remove special characters and replace spaces with hyphens
 	reg := regexp.MustCompile("[^a-z0-9-]+")
@@ -105,12 +203,49 @@ func SanitizeCardName(name string) string {
 
 // GetCommanderRecommendations fetches EDHREC recommendations for a commander.
 func GetCommanderRecommendations(ctx context.Context, commanderName string) (*EDHRECData, error) {
-	return getCommanderRecommendationsWithURL(ctx, commanderName, "https://json.edhrec.com/pages")
+	return getCommanderRecommendationsWithURL(ctx, commanderName, edhrecPagesURL)
 }
 
 // getCommanderRecommendationsWithURL fetches recommendations with a custom base URL.
 func getCommanderRecommendationsWithURL(ctx context.Context, commanderName, baseURL string) (*EDHRECData, error) {
+	page, err := getCommanderPageWithURL(ctx, commanderName, "", baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &page.Container.JSONDict, nil
+}
+
+// GetBudgetRecommendations fetches EDHREC recommendations from a commander's
+// budget page, which favors cheaper alternatives to expensive staples.
+func GetBudgetRecommendations(ctx context.Context, commanderName string) (*EDHRECData, error) {
+	return getBudgetRecommendationsWithURL(ctx, commanderName, edhrecPagesURL)
+}
+
+// getBudgetRecommendationsWithURL fetches budget recommendations with a custom base URL.
+func getBudgetRecommendationsWithURL(ctx context.Context, commanderName, baseURL string) (*EDHRECData, error) {
+	page, err := getCommanderPageWithURL(ctx, commanderName, "budget", baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &page.Container.JSONDict
+	data.Budget = true
+	return data, nil
+}
+
+// GetCommanderPage fetches the full EDHREC page for a commander, including its side panels.
+func GetCommanderPage(ctx context.Context, commanderName string) (*EDHRECResponse, error) {
+	return getCommanderPageWithURL(ctx, commanderName, "", edhrecPagesURL)
+}
+
+// getCommanderPageWithURL fetches a commander page with a custom base URL. A
+// non-empty variant (e.g., "budget") selects that sub-page of the commander.
+func getCommanderPageWithURL(ctx context.Context, commanderName, variant, baseURL string) (*EDHRECResponse, error) {
 	sanitized := SanitizeCardName(commanderName)
+	if variant != "" {
+		sanitized += "/" + variant
+	}
 	url := fmt.Sprintf("%s/commanders/%s.json", baseURL, sanitized)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -120,9 +255,10 @@ func getCommanderRecommendationsWithURL(ctx context.Context, commanderName, base
 
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
+	// EDHREC pages run to hundreds of kilobytes of JSON, so ask for them compressed
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -131,20 +267,152 @@ func getCommanderRecommendationsWithURL(ctx context.Context, commanderName, base
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("EDHREC API returned status %d for %s", resp.StatusCode, commanderName)
+		return nil, newStatusError("EDHREC API", commanderName, resp.StatusCode)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
 	}
 
 	var edhrecResp EDHRECResponse
-	if decodeErr := json.NewDecoder(resp.Body).Decode(&edhrecResp); decodeErr != nil {
+	if decodeErr := json.NewDecoder(body).Decode(&edhrecResp); decodeErr != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", decodeErr)
 	}
 
-	return &edhrecResp.Container.JSONDict, nil
+	return &edhrecResp, nil
+}
+
+// ExtractCommanderThemes returns the themes listed on a commander page, most
+// popular first.
+func ExtractCommanderThemes(page *EDHRECResponse) []EDHRECTheme {
+	themes := make([]EDHRECTheme, 0, len(page.Panels.TagLinks))
+	for _, theme := range page.Panels.TagLinks {
+		if theme.Value != "" {
+			themes = append(themes, theme)
+		}
+	}
+
+	sort.SliceStable(themes, func(i, j int) bool { return themes[i].Count > themes[j].Count })
+	return themes
+}
+
+// ExtractCommanderCombos returns the combos listed on a commander page, most
+// played first. Repeated listings of the same cards are merged.
+func ExtractCommanderCombos(page *EDHRECResponse) []CommanderCombo {
+	seen := make(map[string]bool)
+	var combos []CommanderCombo
+	for _, link := range page.Panels.ComboCounts {
+		var cards []string
+		for _, name := range strings.Split(link.Value, " + ") {
+			if name = strings.TrimSpace(name); name != "" {
+				cards = append(cards, name)
+			}
+		}
+		if len(cards) == 0 {
+			continue
+		}
+
+		key := slices.Clone(cards)
+		slices.Sort(key)
+		if seen[strings.Join(key, "|")] {
+			continue
+		}
+		seen[strings.Join(key, "|")] = true
+
+		url := link.Href
+		if strings.HasPrefix(url, "/") {
+			url = "https://edhrec.com" + url
+		}
+		combos = append(combos, CommanderCombo{Cards: cards, Decks: link.Count, URL: url})
+	}
+
+	sort.SliceStable(combos, func(i, j int) bool { return combos[i].Decks > combos[j].Decks })
+	return combos
+}
+
+// FormatCommanderCombosForDisplay formats the combos played in a commander's decks.
+func FormatCommanderCombosForDisplay(data *EDHRECData, combos []CommanderCombo, limit int) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# Combos for %s\n\n", data.Card.Name))
+
+	if len(combos) == 0 {
+		output.WriteString("EDHREC lists no combos for this commander.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("**Total Combos:** %d\n\n", len(combos)))
+
+	count := len(combos)
+	if limit > 0 && count > limit {
+		count = limit
+	}
+
+	for i, combo := range combos[:count] {
+		output.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, strings.Join(combo.Cards, " + ")))
+		if combo.Decks > 0 {
+			output.WriteString(fmt.Sprintf("   **Played in:** %d decks", combo.Decks))
+			if data.NumDecks > 0 {
+				percentage := float64(combo.Decks) / float64(data.NumDecks) * percentageMultiplier
+				output.WriteString(fmt.Sprintf(" (%.1f%%)", percentage))
+			}
+			output.WriteString("\n")
+		}
+		if combo.URL != "" {
+			output.WriteString(fmt.Sprintf("   %s\n", combo.URL))
+		}
+		output.WriteString("\n")
+	}
+
+	if len(combos) > count {
+		output.WriteString(fmt.Sprintf("*...and %d more combos*\n", len(combos)-count))
+	}
+
+	return output.String()
+}
+
+// FormatThemesForDisplay formats a commander's themes with their deck counts.
+func FormatThemesForDisplay(data *EDHRECData, themes []EDHRECTheme, limit int) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# EDHREC Themes for %s\n\n", data.Card.Name))
+	output.WriteString(fmt.Sprintf("**Total Decks:** %d\n\n", data.NumDecks))
+
+	if len(themes) == 0 {
+		output.WriteString("No themes found for this commander.\n")
+		return output.String()
+	}
+
+	count := len(themes)
+	if limit > 0 && count > limit {
+		count = limit
+	}
+
+	for i, theme := range themes[:count] {
+		output.WriteString(fmt.Sprintf("%d. **%s** - %d decks", i+1, theme.Value, theme.Count))
+		if data.NumDecks > 0 {
+			percentage := float64(theme.Count) / float64(data.NumDecks) * percentageMultiplier
+			output.WriteString(fmt.Sprintf(" (%.1f%%)", percentage))
+		}
+		output.WriteString("\n")
+		if theme.Slug != "" && data.Card.Sanitized != "" {
+			output.WriteString(fmt.Sprintf(
+				"   https://edhrec.com/commanders/%s/%s\n", data.Card.Sanitized, theme.Slug,
+			))
+		}
+	}
+
+	if len(themes) > count {
+		output.WriteString(fmt.Sprintf("\n*...and %d more themes*\n", len(themes)-count))
+	}
+
+	return output.String()
 }
 
 // GetCombosForColors fetches combos for a color combination.
 func GetCombosForColors(ctx context.Context, colors string) (*EDHRECComboData, error) {
-	return getCombosForColorsWithURL(ctx, colors, "https://json.edhrec.com/pages")
+	return getCombosForColorsWithURL(ctx, colors, edhrecPagesURL)
 }
 
 // getCombosForColorsWithURL fetches combos with a custom base URL.
@@ -160,9 +428,9 @@ func getCombosForColorsWithURL(ctx context.Context, colors, baseURL string) (*ED
 
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -171,33 +439,91 @@ func getCombosForColorsWithURL(ctx context.Context, colors, baseURL string) (*ED
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("EDHREC combos API returned status %d", resp.StatusCode)
+		return nil, newStatusError("EDHREC combos API", colors, resp.StatusCode)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
 	}
 
 	var comboResp EDHRECComboResponse
-	if decodeErr := json.NewDecoder(resp.Body).Decode(&comboResp); decodeErr != nil {
+	if decodeErr := json.NewDecoder(body).Decode(&comboResp); decodeErr != nil {
 		return nil, fmt.Errorf("failed to decode combo response: %w", decodeErr)
 	}
 
 	return &comboResp.Container.JSONDict, nil
 }
 
-// FormatCommanderRecsForDisplay formats EDHREC recommendations for text display.
-func FormatCommanderRecsForDisplay(data *EDHRECData, limit int) string {
+// Sort orders for recommendation card lists.
+const (
+	recsSortSynergyDesc   = "synergy_desc"
+	recsSortSynergyAsc    = "synergy_asc"
+	recsSortInclusionDesc = "inclusion_desc"
+)
+
+// recsSortOrders returns the sort orders get_edhrec_recommendations accepts.
+func recsSortOrders() []string {
+	return []string{recsSortSynergyDesc, recsSortSynergyAsc, recsSortInclusionDesc}
+}
+
+// sortCardViews returns a copy of the card views in the given sort order. Ties
+// fall back to inclusion, then synergy, so the order is stable across calls.
+func sortCardViews(views []EDHRECCardView, sortOrder string) []EDHRECCardView {
+	sorted := slices.Clone(views)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch sortOrder {
+		case recsSortSynergyAsc:
+			if a.Synergy != b.Synergy {
+				return a.Synergy < b.Synergy
+			}
+		case recsSortInclusionDesc:
+			if a.Inclusion != b.Inclusion {
+				return a.Inclusion > b.Inclusion
+			}
+		default:
+			if a.Synergy != b.Synergy {
+				return a.Synergy > b.Synergy
+			}
+		}
+		if a.Inclusion != b.Inclusion {
+			return a.Inclusion > b.Inclusion
+		}
+		return a.Synergy > b.Synergy
+	})
+	return sorted
+}
+
+// FormatCommanderRecsForDisplay formats EDHREC recommendations for text display,
+// ordering each category by sortOrder. Ascending synergy surfaces the cards that
+// are commonly cut from the commander's decks.
+func FormatCommanderRecsForDisplay(data *EDHRECData, limit int, sortOrder string) string {
 	var output strings.Builder
 
-	output.WriteString(fmt.Sprintf("# EDHREC Recommendations for %s\n\n", data.Card.Name))
+	if data.Budget {
+		output.WriteString(fmt.Sprintf("# EDHREC Budget Recommendations for %s\n\n", data.Card.Name))
+		output.WriteString("*Budget build: cheaper alternatives to the commander's expensive staples.*\n\n")
+	} else {
+		output.WriteString(fmt.Sprintf("# EDHREC Recommendations for %s\n\n", data.Card.Name))
+	}
 	output.WriteString(fmt.Sprintf("**Total Decks:** %d\n", data.NumDecks))
 
 	if len(data.Card.ColorID) > 0 {
 		output.WriteString(fmt.Sprintf("**Color Identity:** %s\n\n", strings.Join(data.Card.ColorID, ", ")))
 	}
 
+	if sortOrder == recsSortSynergyAsc {
+		output.WriteString("\n*Sorted by synergy, lowest first: these are commonly cut / low synergy cards " +
+			"to think twice about.*\n")
+	}
+
 	// Show each card list category
 	for _, cardList := range data.CardLists {
 		if len(cardList.CardViews) == 0 {
 			continue
 		}
+		cardList.CardViews = sortCardViews(cardList.CardViews, sortOrder)
 
 		output.WriteString(fmt.Sprintf("\n## %s\n\n", cardList.Header))
 
@@ -235,40 +561,170 @@ func FormatCommanderRecsForDisplay(data *EDHRECData, limit int) string {
 	return output.String()
 }
 
-// FormatCombosForDisplay formats combo data for text display.
-func FormatCombosForDisplay(data *EDHRECComboData, limit int) string {
+// FilterRecsByCards returns a copy of the recommendations keeping only the cards
+// for which keep returns true. Categories left empty are dropped.
+func FilterRecsByCards(data *EDHRECData, keep func(name string) bool) *EDHRECData {
+	filtered := *data
+	filtered.CardLists = make([]EDHRECCardList, 0, len(data.CardLists))
+
+	for _, cardList := range data.CardLists {
+		views := make([]EDHRECCardView, 0, len(cardList.CardViews))
+		for _, view := range cardList.CardViews {
+			if keep(view.Name) {
+				views = append(views, view)
+			}
+		}
+		if len(views) == 0 {
+			continue
+		}
+		cardList.CardViews = views
+		filtered.CardLists = append(filtered.CardLists, cardList)
+	}
+
+	return &filtered
+}
+
+// recsCategories returns the tags of the recommendation categories that have cards,
+// in EDHREC's order.
+func recsCategories(data *EDHRECData) []string {
+	var tags []string
+	for _, cardList := range data.CardLists {
+		if cardList.Tag != "" && len(cardList.CardViews) > 0 {
+			tags = append(tags, cardList.Tag)
+		}
+	}
+	return tags
+}
+
+// FilterRecsByCategory returns a copy of the recommendations keeping only the
+// category whose tag (e.g. "ramp", "carddraw") or header matches category, ignoring
+// case. It returns false when no category with cards matches.
+func FilterRecsByCategory(data *EDHRECData, category string) (*EDHRECData, bool) {
+	category = strings.TrimSpace(category)
+	for _, cardList := range data.CardLists {
+		if len(cardList.CardViews) == 0 {
+			continue
+		}
+		if strings.EqualFold(cardList.Tag, category) || strings.EqualFold(cardList.Header, category) {
+			filtered := *data
+			filtered.CardLists = []EDHRECCardList{cardList}
+			return &filtered, true
+		}
+	}
+	return nil, false
+}
+
+// recommendedCardNames returns the unique card names across all recommendation categories.
+func recommendedCardNames(data *EDHRECData) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, cardList := range data.CardLists {
+		for _, view := range cardList.CardViews {
+			if !seen[view.Name] {
+				seen[view.Name] = true
+				names = append(names, view.Name)
+			}
+		}
+	}
+	return names
+}
+
+// comboDeckCountPattern matches the deck count EDHREC appends to combo headers,
+// e.g. "(1,234 decks)".
+var comboDeckCountPattern = regexp.MustCompile(`\(([\d,]+) decks?\)`) //nolint:gochecknoglobals // compiled once
+
+// ComboCardNames returns the cards that make up a combo.
+func ComboCardNames(combo EDHRECComboList) []string {
+	if len(combo.CardViews) == 0 && combo.Combo != nil {
+		return combo.Combo.Cards
+	}
+
+	names := make([]string, len(combo.CardViews))
+	for i, card := range combo.CardViews {
+		names[i] = card.Name
+	}
+	return names
+}
+
+// comboKey identifies a combo by its content: EDHREC's combo ID when present,
+// otherwise its sorted card names.
+func comboKey(combo EDHRECComboList) string {
+	if combo.Combo != nil && combo.Combo.ComboID != "" {
+		return combo.Combo.ComboID
+	}
+
+	names := slices.Clone(ComboCardNames(combo))
+	slices.Sort(names)
+	return strings.Join(names, "|")
+}
+
+// uniqueCombos drops repeated listings of the same combo, keeping EDHREC's rank order.
+func uniqueCombos(combos []EDHRECComboList) []EDHRECComboList {
+	seen := make(map[string]bool, len(combos))
+	unique := make([]EDHRECComboList, 0, len(combos))
+	for _, combo := range combos {
+		key := comboKey(combo)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, combo)
+	}
+	return unique
+}
+
+// FormatCombosForDisplay formats combo data for text display. Each combo is
+// titled by its cards and lists its results one per line. manaCosts, keyed by
+// normalized card name, adds mana costs to the cards it knows; it may be nil.
+func FormatCombosForDisplay(data *EDHRECComboData, limit int, manaCosts map[string]string) string {
 	var output strings.Builder
 
+	combos := uniqueCombos(data.CardLists)
+
 	output.WriteString("# Popular Combos\n\n")
-	output.WriteString(fmt.Sprintf("**Total Combos:** %d\n\n", len(data.CardLists)))
+	output.WriteString(fmt.Sprintf("**Total Combos:** %d\n\n", len(combos)))
 
-	count := len(data.CardLists)
+	count := len(combos)
 	if limit > 0 && count > limit {
 		count = limit
 	}
 
-	for i := range count {
-		comboList := data.CardLists[i]
+	for i, comboList := range combos[:count] {
+		names := ComboCardNames(comboList)
 
-		output.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, comboList.Header))
+		title := strings.Join(names, " + ")
+		if title == "" {
+			title = comboList.Header
+		}
+		output.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, title))
 
-		if len(comboList.CardViews) > 0 {
-			cardNames := make([]string, len(comboList.CardViews))
-			for j, card := range comboList.CardViews {
-				cardNames[j] = card.Name
+		if match := comboDeckCountPattern.FindStringSubmatch(comboList.Header); match != nil {
+			output.WriteString(fmt.Sprintf("   **Played in:** %s decks\n", match[1]))
+		}
+
+		if len(names) > 0 {
+			output.WriteString("   **Cards:**\n")
+			for _, name := range names {
+				if cost := manaCosts[normalizeCardName(name)]; cost != "" {
+					output.WriteString(fmt.Sprintf("   - %s %s\n", name, cost))
+				} else {
+					output.WriteString(fmt.Sprintf("   - %s\n", name))
+				}
 			}
-			output.WriteString(fmt.Sprintf("   **Cards:** %s\n", strings.Join(cardNames, " + ")))
 		}
 
 		if comboList.Combo != nil && len(comboList.Combo.Results) > 0 {
-			output.WriteString(fmt.Sprintf("   **Results:** %s\n", strings.Join(comboList.Combo.Results, ", ")))
+			output.WriteString("   **Results:**\n")
+			for _, result := range comboList.Combo.Results {
+				output.WriteString(fmt.Sprintf("   - %s\n", result))
+			}
 		}
 
 		output.WriteString("\n")
 	}
 
-	if len(data.CardLists) > count {
-		output.WriteString(fmt.Sprintf("*...and %d more combos*\n", len(data.CardLists)-count))
+	if len(combos) > count {
+		output.WriteString(fmt.Sprintf("*...and %d more combos*\n", len(combos)-count))
 	}
 
 	return output.String()
@@ -276,7 +732,7 @@ func FormatCombosForDisplay(data *EDHRECComboData, limit int) string {
 
 // GetTopCardsForCategory fetches top cards for a specific category.
 func GetTopCardsForCategory(ctx context.Context, category string, page int) ([]EDHRECCardView, error) {
-	return getTopCardsForCategoryWithURL(ctx, category, page, "https://json.edhrec.com/pages")
+	return getTopCardsForCategoryWithURL(ctx, category, page, edhrecPagesURL)
 }
 
 // getTopCardsForCategoryWithURL fetches top cards with a custom base URL.
@@ -296,9 +752,9 @@ func getTopCardsForCategoryWithURL(
 
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -307,11 +763,16 @@ func getTopCardsForCategoryWithURL(
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("EDHREC top cards API returned status %d", resp.StatusCode)
+		return nil, newStatusError("EDHREC top cards API", category, resp.StatusCode)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
 	}
 
 	var edhrecResp EDHRECResponse
-	if decodeErr := json.NewDecoder(resp.Body).Decode(&edhrecResp); decodeErr != nil {
+	if decodeErr := json.NewDecoder(body).Decode(&edhrecResp); decodeErr != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", decodeErr)
 	}
 
@@ -323,3 +784,114 @@ func getTopCardsForCategoryWithURL(
 
 	return allCards, nil
 }
+
+// FormatSaltListForDisplay formats a page of EDHREC's salt list, the cards players
+// most dislike facing.
+func FormatSaltListForDisplay(cards []EDHRECCardView, page int) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# EDHREC Salt List (page %d)\n\n", page))
+
+	if len(cards) == 0 {
+		output.WriteString("No cards found on this page.\n")
+		return output.String()
+	}
+
+	for i, card := range cards {
+		output.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, card.Name))
+		output.WriteString(fmt.Sprintf("   - Salt Score: %.2f/4.0\n", card.Salt))
+		if card.NumDecks > 0 {
+			output.WriteString(fmt.Sprintf("   - Decks: %d\n", card.NumDecks))
+		}
+	}
+
+	output.WriteString("\n*Salt scores come from EDHREC's yearly community survey; higher is saltier.*\n")
+
+	return output.String()
+}
+
+// GetCardPage fetches the EDHREC page for an individual card.
+func GetCardPage(ctx context.Context, cardName string) (*EDHRECData, error) {
+	return getCardPageWithURL(ctx, cardName, edhrecPagesURL)
+}
+
+// getCardPageWithURL fetches a card page with a custom base URL.
+func getCardPageWithURL(ctx context.Context, cardName, baseURL string) (*EDHRECData, error) {
+	url := fmt.Sprintf("%s/cards/%s.json", baseURL, SanitizeCardName(cardName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError("EDHREC card API", cardName, resp.StatusCode)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+
+	var edhrecResp EDHRECResponse
+	if decodeErr := json.NewDecoder(body).Decode(&edhrecResp); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+
+	return &edhrecResp.Container.JSONDict, nil
+}
+
+// GetAverageDeck fetches the EDHREC average deck for a commander.
+func GetAverageDeck(ctx context.Context, commanderName string) (*EDHRECAverageDeck, error) {
+	return getAverageDeckWithURL(ctx, commanderName, edhrecPagesURL)
+}
+
+// getAverageDeckWithURL fetches the average deck with a custom base URL.
+func getAverageDeckWithURL(ctx context.Context, commanderName, baseURL string) (*EDHRECAverageDeck, error) {
+	url := fmt.Sprintf("%s/average-decks/%s.json", baseURL, SanitizeCardName(commanderName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := doWithRateLimitRetry(sharedHTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError("EDHREC average deck API", commanderName, resp.StatusCode)
+	}
+
+	body, err := responseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+
+	var avgDeck EDHRECAverageDeck
+	if decodeErr := json.NewDecoder(body).Decode(&avgDeck); decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+
+	return &avgDeck, nil
+}
@@ -149,7 +149,7 @@ func TestEDHRECFormatOutputE2E(t *testing.T) {
 	}
 
 	// Test formatting with limit
-	output := FormatCommanderRecsForDisplay(data, 5)
+	output := FormatCommanderRecsForDisplay(data, 5, recsSortSynergyDesc)
 	if output == "" {
 		t.Error("Expected non-empty formatted output")
 	}
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestSanitizeCardName(t *testing.T) {
@@ -60,6 +67,46 @@ func TestSanitizeCardName(t *testing.T) {
 			input: "Mox Opal 2",
 			want:  "mox-opal-2",
 		},
+		{
+			name:  "umlaut",
+			input: "Jötun Grunt",
+			want:  "jotun-grunt",
+		},
+		{
+			name:  "circumflex",
+			input: "Lim-Dûl the Necromancer",
+			want:  "lim-dul-the-necromancer",
+		},
+		{
+			name:  "ae ligature",
+			input: "Æther Vial",
+			want:  "aether-vial",
+		},
+		{
+			name:  "accented commander",
+			input: "Séance Board",
+			want:  "seance-board",
+		},
+		{
+			name:  "modal double-faced commander",
+			input: "Esika, God of the Tree // The Prismatic Bridge",
+			want:  "esika-god-of-the-tree",
+		},
+		{
+			name:  "transforming commander",
+			input: "Brok, the Crafty // Brok, the Crafty",
+			want:  "brok-the-crafty",
+		},
+		{
+			name:  "adventure commander",
+			input: "Lovestruck Beast // Heart's Desire",
+			want:  "lovestruck-beast",
+		},
+		{
+			name:  "another modal double-faced commander",
+			input: "Valki, God of Lies // Tibalt, Cosmic Impostor",
+			want:  "valki-god-of-lies",
+		},
 	}
 
 	for _, tt := range tests {
@@ -232,6 +279,62 @@ func TestGetCombosForColors(t *testing.T) {
 	}
 }
 
+// writeGzipJSON writes v as gzipped JSON when the request accepts gzip, and as
+// plain JSON otherwise.
+func writeGzipJSON(t *testing.T, w http.ResponseWriter, r *http.Request, v any) {
+	t.Helper()
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		_ = json.NewEncoder(w).Encode(v)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(v); err != nil {
+		t.Errorf("encoding gzipped response: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Errorf("closing gzip writer: %v", err)
+	}
+}
+
+func TestEDHRECGzipResponses(t *testing.T) {
+	var acceptEncodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncodings = append(acceptEncodings, r.Header.Get("Accept-Encoding"))
+		switch r.URL.Path {
+		case "/commanders/krenko-mob-boss.json":
+			writeGzipJSON(t, w, r, EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+				Card:     EDHRECCardInfo{Name: "Krenko, Mob Boss"},
+				NumDecks: 100,
+			}}})
+		case "/combos/r.json":
+			writeGzipJSON(t, w, r, EDHRECComboResponse{Container: EDHRECComboContainer{JSONDict: EDHRECComboData{
+				CardLists: []EDHRECComboList{{Header: "Krenko + Skirk Prospector"}},
+			}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	data, err := getCommanderRecommendationsWithURL(t.Context(), "Krenko, Mob Boss", server.URL)
+	if err != nil || data.Card.Name != "Krenko, Mob Boss" || data.NumDecks != 100 {
+		t.Errorf("getCommanderRecommendationsWithURL() = %+v, %v, want the decompressed page", data, err)
+	}
+
+	combos, err := getCombosForColorsWithURL(t.Context(), "R", server.URL)
+	if err != nil || len(combos.CardLists) != 1 {
+		t.Errorf("getCombosForColorsWithURL() = %+v, %v, want the decompressed combos", combos, err)
+	}
+
+	for _, got := range acceptEncodings {
+		if got != "gzip" {
+			t.Errorf("EDHREC request sent Accept-Encoding %q, want gzip", got)
+		}
+	}
+}
+
 func TestFormatCommanderRecsForDisplay(t *testing.T) {
 	data := &EDHRECData{
 		Card: EDHRECCardInfo{
@@ -295,7 +398,7 @@ func TestFormatCommanderRecsForDisplay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatCommanderRecsForDisplay(tt.data, tt.limit)
+			got := FormatCommanderRecsForDisplay(tt.data, tt.limit, recsSortSynergyDesc)
 
 			for _, want := range tt.wantContains {
 				if !strings.Contains(got, want) {
@@ -373,7 +476,7 @@ func TestFormatCombosForDisplay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatCombosForDisplay(tt.data, tt.limit)
+			got := FormatCombosForDisplay(tt.data, tt.limit, nil)
 
 			for _, want := range tt.wantContains {
 				if !strings.Contains(got, want) {
@@ -387,3 +490,800 @@ func TestFormatCombosForDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCardPage(t *testing.T) {
+	tests := []struct {
+		name         string
+		cardName     string
+		mockResponse EDHRECResponse
+		mockStatus   int
+		wantErr      bool
+		wantSimilar  int
+	}{
+		{
+			name:     "card page with similar cards",
+			cardName: "Wrath of God",
+			mockResponse: EDHRECResponse{
+				Container: EDHRECContainer{
+					JSONDict: EDHRECData{
+						Card: EDHRECCardInfo{Name: "Wrath of God"},
+						Similar: []EDHRECCardView{
+							{Name: "Day of Judgment"},
+							{Name: "Damnation"},
+						},
+					},
+				},
+			},
+			mockStatus:  http.StatusOK,
+			wantSimilar: 2,
+		},
+		{
+			name:       "404 not found",
+			cardName:   "Nonexistent Card",
+			mockStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				want := "/cards/" + SanitizeCardName(tt.cardName) + ".json"
+				if r.URL.Path != want {
+					t.Errorf("Request URL = %v, want %v", r.URL.Path, want)
+				}
+
+				w.WriteHeader(tt.mockStatus)
+				if tt.mockStatus == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(tt.mockResponse)
+				}
+			}))
+			defer server.Close()
+
+			got, err := getCardPageWithURL(context.Background(), tt.cardName, server.URL)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCardPage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && len(got.Similar) != tt.wantSimilar {
+				t.Errorf("GetCardPage() similar count = %v, want %v", len(got.Similar), tt.wantSimilar)
+			}
+		})
+	}
+}
+
+func TestGetAverageDeck(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockBody    string
+		mockStatus  int
+		wantErr     bool
+		wantEntries int
+	}{
+		{
+			name: "average deck",
+			mockBody: `{"deck": ["1 Sol Ring", "1 Arcane Signet", "10 Forest"],
+				"container": {"json_dict": {"card": {"name": "Test Commander"}}}}`,
+			mockStatus:  http.StatusOK,
+			wantEntries: 3,
+		},
+		{
+			name:       "404 not found",
+			mockStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/average-decks/test-commander.json" {
+					t.Errorf("Request URL = %v, want /average-decks/test-commander.json", r.URL.Path)
+				}
+				w.WriteHeader(tt.mockStatus)
+				_, _ = w.Write([]byte(tt.mockBody))
+			}))
+			defer server.Close()
+
+			got, err := getAverageDeckWithURL(context.Background(), "Test Commander", server.URL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAverageDeck() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			entries := got.Entries()
+			if len(entries) != tt.wantEntries {
+				t.Errorf("GetAverageDeck() entries = %d, want %d", len(entries), tt.wantEntries)
+			}
+			if got.Container.JSONDict.Card.Name != "Test Commander" {
+				t.Errorf("GetAverageDeck() commander = %v, want Test Commander", got.Container.JSONDict.Card.Name)
+			}
+		})
+	}
+}
+
+func TestHandleGetAverageDeck(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/average-decks/krenko-mob-boss.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deck": ["1 Krenko, Mob Boss", "1 Sol Ring", "1 Goblin Matron", "30 Mountain"],
+			"container": {"json_dict": {"card": {"name": "Krenko, Mob Boss"}}}}`))
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleGetAverageDeck, map[string]any{"commander": "krenko, mob boss"})
+	if isError {
+		t.Fatalf("handleGetAverageDeck() returned error: %s", got)
+	}
+	want := "1 Krenko, Mob Boss\n\n1 Goblin Matron\n30 Mountain\n1 Sol Ring\n"
+	if got != want {
+		t.Errorf("handleGetAverageDeck() = %q, want %q", got, want)
+	}
+
+	got, isError = callTool(t, s.handleGetAverageDeck, map[string]any{"commander": "Nobody"})
+	if !isError || !strings.Contains(got, "status 404") {
+		t.Errorf("handleGetAverageDeck() for unknown commander = %q, isError %v", got, isError)
+	}
+}
+
+func TestHandleAverageDeckPrice(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/average-decks/krenko-mob-boss.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deck": [], "container": {"json_dict": {"card": {"name": "Krenko, Mob Boss"}}}}`))
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleAverageDeckPrice, map[string]any{"commander": "Krenko, Mob Boss"})
+	if !isError || !strings.Contains(got, "EDHREC has no average deck for Krenko, Mob Boss") {
+		t.Errorf("handleAverageDeckPrice() for an empty deck = %q, isError %v", got, isError)
+	}
+
+	got, isError = callTool(t, s.handleAverageDeckPrice, map[string]any{"commander": "Nobody"})
+	if !isError || !strings.Contains(got, "status 404") {
+		t.Errorf("handleAverageDeckPrice() for unknown commander = %q, isError %v", got, isError)
+	}
+}
+
+func TestFilterRecsByCards(t *testing.T) {
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Test Commander"},
+		NumDecks: 100,
+		CardLists: []EDHRECCardList{
+			{Header: "New Cards", CardViews: []EDHRECCardView{{Name: "New Card A"}, {Name: "Old Card"}}},
+			{Header: "Creatures", CardViews: []EDHRECCardView{{Name: "Old Creature"}}},
+			{Header: "Artifacts", CardViews: []EDHRECCardView{{Name: "New Card B"}}},
+		},
+	}
+
+	keep := map[string]bool{"New Card A": true, "New Card B": true}
+	got := FilterRecsByCards(data, func(name string) bool { return keep[name] })
+
+	if len(got.CardLists) != 2 {
+		t.Fatalf("FilterRecsByCards() categories = %d, want 2", len(got.CardLists))
+	}
+	if got.CardLists[0].Header != "New Cards" || len(got.CardLists[0].CardViews) != 1 {
+		t.Errorf("FilterRecsByCards() first category = %+v", got.CardLists[0])
+	}
+	if got.CardLists[1].Header != "Artifacts" {
+		t.Errorf("FilterRecsByCards() second category = %v, want Artifacts", got.CardLists[1].Header)
+	}
+
+	// The original data must be left untouched
+	if len(data.CardLists) != 3 || len(data.CardLists[0].CardViews) != 2 {
+		t.Error("FilterRecsByCards() modified its input")
+	}
+
+	if names := recommendedCardNames(data); len(names) != 4 {
+		t.Errorf("recommendedCardNames() = %v, want 4 names", names)
+	}
+}
+
+func TestFilterRecsByCategory(t *testing.T) {
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Test Commander"},
+		NumDecks: 100,
+		CardLists: []EDHRECCardList{
+			{Header: "Ramp", Tag: "ramp", CardViews: []EDHRECCardView{{Name: "Sol Ring"}}},
+			{Header: "Card Draw", Tag: "carddraw", CardViews: []EDHRECCardView{{Name: "Rhystic Study"}}},
+			{Header: "Lands", Tag: "lands"},
+		},
+	}
+
+	for _, category := range []string{"carddraw", "CardDraw", " card draw "} {
+		got, ok := FilterRecsByCategory(data, category)
+		if !ok || len(got.CardLists) != 1 || got.CardLists[0].Tag != "carddraw" {
+			t.Errorf("FilterRecsByCategory(%q) = %+v, %v, want the carddraw category", category, got, ok)
+		}
+	}
+
+	if _, ok := FilterRecsByCategory(data, "lands"); ok {
+		t.Error("FilterRecsByCategory() matched a category without cards")
+	}
+	if got := recsCategories(data); !slices.Equal(got, []string{"ramp", "carddraw"}) {
+		t.Errorf("recsCategories() = %v, want [ramp carddraw]", got)
+	}
+	if len(data.CardLists) != 3 {
+		t.Error("FilterRecsByCategory() modified its input")
+	}
+}
+
+func TestHandleGetCommanderStaples(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/krenko-mob-boss.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"container": {"json_dict": {"card": {"name": "Krenko, Mob Boss"}, "num_decks": 100,
+			"cardlists": [
+				{"header": "Ramp", "tag": "ramp", "cardviews": [
+					{"name": "Sol Ring", "inclusion": 90, "synergy": 0.01},
+					{"name": "Goblin Anarchomancer", "inclusion": 20, "synergy": 0.35}
+				]},
+				{"header": "Removal", "tag": "removal", "cardviews": [{"name": "Lightning Bolt", "inclusion": 40}]}
+			]}}}`))
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleGetCommanderStaples, map[string]any{
+		"commander": "Krenko, Mob Boss",
+		"category":  "RAMP",
+	})
+	if isError {
+		t.Fatalf("handleGetCommanderStaples() returned error: %s", got)
+	}
+	if !strings.Contains(got, "## Ramp") || !strings.Contains(got, "Sol Ring") || strings.Contains(got, "Lightning Bolt") {
+		t.Errorf("handleGetCommanderStaples() should only list the ramp category: %s", got)
+	}
+	if strings.Index(got, "Goblin Anarchomancer") > strings.Index(got, "Sol Ring") {
+		t.Errorf("handleGetCommanderStaples() should sort by synergy by default: %s", got)
+	}
+
+	got, isError = callTool(t, s.handleGetCommanderStaples, map[string]any{
+		"commander": "Krenko, Mob Boss",
+		"category":  "tutors",
+	})
+	if !isError || !strings.Contains(got, `Unknown category "tutors" for Krenko, Mob Boss (use one of: ramp, removal)`) {
+		t.Errorf("handleGetCommanderStaples() for unknown category = %q, isError %v", got, isError)
+	}
+}
+
+func TestSetScopedEDHRECRecommendations(t *testing.T) {
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Test Commander"},
+		NumDecks: 100,
+		CardLists: []EDHRECCardList{
+			{Header: "New Cards", CardViews: []EDHRECCardView{
+				{Name: "Fresh Bomb", Inclusion: 50},
+				{Name: "Sol Ring", Inclusion: 90},
+			}},
+			{Header: "Creatures", CardViews: []EDHRECCardView{{Name: "Old Creature", Inclusion: 10}}},
+		},
+	}
+
+	// Only "Fresh Bomb" has a printing in the synthetic "NEW" set
+	server := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Identifiers []struct {
+				Name string `json:"name"`
+				Set  string `json:"set"`
+			} `json:"identifiers"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		var found []map[string]string
+		for _, id := range body.Identifiers {
+			if id.Set != "new" {
+				t.Errorf("identifier set = %q, want %q", id.Set, "new")
+			}
+			if id.Name == "Fresh Bomb" {
+				found = append(found, map[string]string{"name": id.Name, "set": id.Set})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": found})
+	}))
+
+	t.Run("filters to set", func(t *testing.T) {
+		result, err := server.setScopedEDHRECRecommendations(context.Background(), data, "NEW", 10, recsSortSynergyDesc)
+		if err != nil {
+			t.Fatalf("setScopedEDHRECRecommendations() error = %v", err)
+		}
+
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, "Fresh Bomb") {
+			t.Errorf("output missing Fresh Bomb: %s", text)
+		}
+		if strings.Contains(text, "Sol Ring") || strings.Contains(text, "Old Creature") {
+			t.Errorf("output should only contain cards from the set: %s", text)
+		}
+		if !strings.Contains(text, "1 of 3 recommended cards printed in set NEW") {
+			t.Errorf("output missing filter note: %s", text)
+		}
+	})
+
+	t.Run("no cards in set", func(t *testing.T) {
+		noMatch := FilterRecsByCards(data, func(name string) bool { return name == "Sol Ring" || name == "Old Creature" })
+		result, err := server.setScopedEDHRECRecommendations(
+			context.Background(), noMatch, "NEW", 10, recsSortSynergyDesc,
+		)
+		if err != nil {
+			t.Fatalf("setScopedEDHRECRecommendations() error = %v", err)
+		}
+
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, "None of the 2 recommended cards are printed in set NEW") {
+			t.Errorf("output missing no-match message: %s", text)
+		}
+	})
+}
+
+func TestGetCommanderRecommendations_RateLimited(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := getCommanderRecommendationsWithURL(context.Background(), "Atraxa", server.URL)
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("getCommanderRecommendationsWithURL() error = %v, want status 429", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("getCommanderRecommendationsWithURL() made %d attempts, want 2", attempts)
+	}
+}
+
+func TestGetCommanderPageThemes(t *testing.T) {
+	const pageJSON = `{
+		"container": {"json_dict": {"card": {"name": "Atraxa, Praetors' Voice", "sanitized": "atraxa-praetors-voice"},
+			"num_decks": 40000}},
+		"panels": {"taglinks": [
+			{"value": "Proliferate", "slug": "proliferate", "count": 5000},
+			{"value": "+1/+1 Counters", "slug": "p1p1", "count": 9000},
+			{"value": "", "slug": "empty", "count": 1},
+			{"value": "Superfriends", "slug": "superfriends", "count": 7000}
+		]}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/atraxa-praetors-voice.json" {
+			t.Errorf("Request URL = %v, want /commanders/atraxa-praetors-voice.json", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(pageJSON))
+	}))
+	defer server.Close()
+
+	page, err := getCommanderPageWithURL(t.Context(), "Atraxa, Praetors' Voice", "", server.URL)
+	if err != nil {
+		t.Fatalf("getCommanderPageWithURL() error = %v", err)
+	}
+
+	themes := ExtractCommanderThemes(page)
+	var got []string
+	for _, theme := range themes {
+		got = append(got, theme.Value)
+	}
+	if want := "+1/+1 Counters,Superfriends,Proliferate"; strings.Join(got, ",") != want {
+		t.Errorf("ExtractCommanderThemes() = %v, want %s", got, want)
+	}
+
+	output := FormatThemesForDisplay(&page.Container.JSONDict, themes, 2)
+	for _, want := range []string{
+		"# EDHREC Themes for Atraxa, Praetors' Voice",
+		"1. **+1/+1 Counters** - 9000 decks (22.5%)",
+		"https://edhrec.com/commanders/atraxa-praetors-voice/p1p1",
+		"2. **Superfriends** - 7000 decks",
+		"*...and 1 more themes*",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatThemesForDisplay() missing %q in output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "Proliferate") {
+		t.Errorf("FormatThemesForDisplay() should respect the limit: %s", output)
+	}
+
+	empty := FormatThemesForDisplay(&EDHRECData{Card: EDHRECCardInfo{Name: "Nobody"}}, nil, 0)
+	if !strings.Contains(empty, "No themes found") {
+		t.Errorf("FormatThemesForDisplay() with no themes = %q", empty)
+	}
+}
+
+func TestHandleGetCommanderThemes(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/krenko-mob-boss.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"container": {"json_dict": {"card": {"name": "Krenko, Mob Boss"}, "num_decks": 200}},
+			"panels": {"taglinks": [{"value": "Goblins", "slug": "goblins", "count": 150}]}}`))
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleGetCommanderThemes, map[string]any{"commander": "Krenko, Mob Boss"})
+	if isError || !strings.Contains(got, "1. **Goblins** - 150 decks (75.0%)") {
+		t.Errorf("handleGetCommanderThemes() = %q, isError %v", got, isError)
+	}
+}
+
+func TestHandleGetCommanderCombos(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/kinnan-bonder-prodigy.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"container": {"json_dict": {"card": {"name": "Kinnan, Bonder Prodigy"},
+			"num_decks": 2000}},
+			"panels": {"combocounts": [
+				{"value": "Basalt Monolith + Kinnan, Bonder Prodigy", "href": "/combos/kinnan/1-2", "count": 900}
+			]}}`))
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleGetCommanderCombos, map[string]any{"commander": "Kinnan, Bonder Prodigy"})
+	if isError || !strings.Contains(got, "1. **Basalt Monolith + Kinnan, Bonder Prodigy**") {
+		t.Errorf("handleGetCommanderCombos() = %q, isError %v", got, isError)
+	}
+}
+
+func TestGetBudgetRecommendations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/krenko-mob-boss/budget.json" {
+			t.Errorf("Request URL = %v, want /commanders/krenko-mob-boss/budget.json", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+			Card:     EDHRECCardInfo{Name: "Krenko, Mob Boss"},
+			NumDecks: 100,
+			CardLists: []EDHRECCardList{
+				{Header: "Top Cards", CardViews: []EDHRECCardView{{Name: "Goblin Matron", Inclusion: 50}}},
+			},
+		}}})
+	}))
+	defer server.Close()
+
+	data, err := getBudgetRecommendationsWithURL(t.Context(), "Krenko, Mob Boss", server.URL)
+	if err != nil {
+		t.Fatalf("getBudgetRecommendationsWithURL() error = %v", err)
+	}
+	if !data.Budget {
+		t.Error("getBudgetRecommendationsWithURL() should mark the data as a budget build")
+	}
+
+	output := FormatCommanderRecsForDisplay(data, 10, recsSortSynergyDesc)
+	for _, want := range []string{
+		"# EDHREC Budget Recommendations for Krenko, Mob Boss",
+		"Budget build",
+		"Goblin Matron",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatCommanderRecsForDisplay() missing %q in output: %s", want, output)
+		}
+	}
+}
+
+func TestFormatCommanderRecsSortOrder(t *testing.T) {
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Test Commander"},
+		NumDecks: 1000,
+		CardLists: []EDHRECCardList{
+			{
+				Header: "Top Cards",
+				CardViews: []EDHRECCardView{
+					{Name: "Staple", Inclusion: 900, Synergy: 0.05},
+					{Name: "Signature", Inclusion: 300, Synergy: 0.60},
+					{Name: "Filler", Inclusion: 500, Synergy: -0.20},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		sortOrder string
+		want      []string
+		wantNote  bool
+	}{
+		{sortOrder: recsSortSynergyDesc, want: []string{"Signature", "Staple", "Filler"}},
+		{sortOrder: recsSortSynergyAsc, want: []string{"Filler", "Staple", "Signature"}, wantNote: true},
+		{sortOrder: recsSortInclusionDesc, want: []string{"Staple", "Filler", "Signature"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortOrder, func(t *testing.T) {
+			got := FormatCommanderRecsForDisplay(data, 0, tt.sortOrder)
+
+			for i, name := range tt.want {
+				if entry := fmt.Sprintf("%d. **%s**", i+1, name); !strings.Contains(got, entry) {
+					t.Errorf("FormatCommanderRecsForDisplay() missing %q in output: %s", entry, got)
+				}
+			}
+			if hasNote := strings.Contains(got, "commonly cut / low synergy"); hasNote != tt.wantNote {
+				t.Errorf("FormatCommanderRecsForDisplay() low synergy note = %v, want %v", hasNote, tt.wantNote)
+			}
+		})
+	}
+
+	if data.CardLists[0].CardViews[0].Name != "Staple" {
+		t.Error("FormatCommanderRecsForDisplay() should not reorder the caller's data")
+	}
+}
+
+func TestGetSaltList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/top/salt--2.json" {
+			t.Errorf("Request URL = %v, want /top/salt--2.json", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+			CardLists: []EDHRECCardList{{CardViews: []EDHRECCardView{
+				{Name: "Stasis", Salt: 3.07, NumDecks: 1200},
+				{Name: "Armageddon", Salt: 2.95},
+			}}},
+		}}})
+	}))
+	defer server.Close()
+
+	cards, err := getTopCardsForCategoryWithURL(t.Context(), "salt", 2, server.URL)
+	if err != nil {
+		t.Fatalf("getTopCardsForCategoryWithURL() error = %v", err)
+	}
+
+	output := FormatSaltListForDisplay(cards, 2)
+	for _, want := range []string{
+		"# EDHREC Salt List (page 2)",
+		"1. **Stasis**",
+		"Salt Score: 3.07/4.0",
+		"Decks: 1200",
+		"2. **Armageddon**",
+		"Salt Score: 2.95/4.0",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatSaltListForDisplay() missing %q in output: %s", want, output)
+		}
+	}
+
+	if empty := FormatSaltListForDisplay(nil, 99); !strings.Contains(empty, "No cards found") {
+		t.Errorf("FormatSaltListForDisplay() with no cards = %q", empty)
+	}
+}
+
+func TestHandleGetSaltList(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/top/salt--3.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+			CardLists: []EDHRECCardList{{CardViews: []EDHRECCardView{{Name: "Stasis", Salt: 3.07}}}},
+		}}})
+	}))
+	defer edhrec.Close()
+
+	s := &MTGCommanderServer{edhrecBaseURL: edhrec.URL}
+
+	got, isError := callTool(t, s.handleGetSaltList, map[string]any{"page": 3.0})
+	if isError || !strings.Contains(got, "1. **Stasis**") {
+		t.Errorf("handleGetSaltList() = %q, isError %v", got, isError)
+	}
+
+	got, isError = callTool(t, s.handleGetSaltList, map[string]any{"page": 4.0})
+	if !isError || !strings.Contains(got, "status 404") {
+		t.Errorf("handleGetSaltList() for a missing page = %q, isError %v", got, isError)
+	}
+}
+
+func TestFormatCombosForDisplayByContent(t *testing.T) {
+	oracle := EDHRECComboList{
+		Header:    "Thassa's Oracle + Demonic Consultation (12,345 decks)",
+		CardViews: []EDHRECCardView{{Name: "Thassa's Oracle"}, {Name: "Demonic Consultation"}},
+		Combo: &EDHRECCombo{
+			ComboID: "1-2",
+			Results: []string{"Exile your library", "Win the game"},
+		},
+	}
+	data := &EDHRECComboData{
+		CardLists: []EDHRECComboList{
+			oracle,
+			oracle,
+			{Header: "Mystery combo", Combo: &EDHRECCombo{Cards: []string{"Dramatic Reversal", "Isochron Scepter"}}},
+			{Header: "Unlisted combo"},
+		},
+	}
+	costs := map[string]string{"thassa's oracle": "{U}{U}", "demonic consultation": "{B}"}
+
+	got := FormatCombosForDisplay(data, 0, costs)
+
+	for _, want := range []string{
+		"**Total Combos:** 3",
+		"1. **Thassa's Oracle + Demonic Consultation**",
+		"**Played in:** 12,345 decks",
+		"   - Thassa's Oracle {U}{U}",
+		"   - Demonic Consultation {B}",
+		"   - Exile your library\n   - Win the game",
+		"2. **Dramatic Reversal + Isochron Scepter**",
+		"   - Isochron Scepter\n",
+		"3. **Unlisted combo**",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCombosForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Count(got, "Thassa's Oracle + Demonic Consultation**") != 1 {
+		t.Errorf("FormatCombosForDisplay() should list a repeated combo once: %s", got)
+	}
+}
+
+func TestComboManaCosts(t *testing.T) {
+	known := map[string]scryfall.Card{
+		"thassa's oracle":      {Name: "Thassa's Oracle", ManaCost: "{U}{U}"},
+		"demonic consultation": {Name: "Demonic Consultation", ManaCost: "{B}"},
+	}
+	requests := 0
+	s := newTestMTGServer(t, collectionHandler(t, known, &requests))
+
+	combos := []EDHRECComboList{
+		{CardViews: []EDHRECCardView{{Name: "Thassa's Oracle"}, {Name: "Demonic Consultation"}}},
+		{CardViews: []EDHRECCardView{{Name: "Not Shown"}}},
+	}
+
+	costs := s.comboManaCosts(t.Context(), combos, 1)
+	if costs["thassa's oracle"] != "{U}{U}" || costs["demonic consultation"] != "{B}" {
+		t.Errorf("comboManaCosts() = %v, want costs for both combo cards", costs)
+	}
+	if _, ok := costs["not shown"]; ok {
+		t.Errorf("comboManaCosts() looked up cards beyond the limit: %v", costs)
+	}
+	if requests != 1 {
+		t.Errorf("comboManaCosts() made %d collection requests, want 1", requests)
+	}
+}
+
+func TestExtractCommanderCombos(t *testing.T) {
+	page := &EDHRECResponse{
+		Container: EDHRECContainer{JSONDict: EDHRECData{
+			Card:     EDHRECCardInfo{Name: "Kinnan, Bonder Prodigy"},
+			NumDecks: 2000,
+		}},
+		Panels: EDHRECPanels{ComboCounts: []EDHRECComboLink{
+			{Value: "Basalt Monolith + Kinnan, Bonder Prodigy", Href: "/combos/kinnan/1-2", Count: 900},
+			{Value: "Kinnan, Bonder Prodigy + Basalt Monolith", Href: "/combos/kinnan/2-1", Count: 10},
+			{Value: "", Count: 5},
+			{Value: "Freed from the Real + Kinnan, Bonder Prodigy + Pemmin's Aura", Count: 1200},
+		}},
+	}
+
+	combos := ExtractCommanderCombos(page)
+	if len(combos) != 2 {
+		t.Fatalf("ExtractCommanderCombos() returned %d combos, want 2: %+v", len(combos), combos)
+	}
+	if combos[0].Decks != 1200 || len(combos[0].Cards) != 3 {
+		t.Errorf("ExtractCommanderCombos()[0] = %+v, want the three-card combo played in 1200 decks", combos[0])
+	}
+	if combos[1].URL != "https://edhrec.com/combos/kinnan/1-2" {
+		t.Errorf("ExtractCommanderCombos()[1].URL = %q, want absolute EDHREC URL", combos[1].URL)
+	}
+
+	output := FormatCommanderCombosForDisplay(&page.Container.JSONDict, combos, 1)
+	for _, want := range []string{
+		"# Combos for Kinnan, Bonder Prodigy",
+		"**Total Combos:** 2",
+		"1. **Freed from the Real + Kinnan, Bonder Prodigy + Pemmin's Aura**",
+		"**Played in:** 1200 decks (60.0%)",
+		"*...and 1 more combos*",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatCommanderCombosForDisplay() missing %q in output: %s", want, output)
+		}
+	}
+
+	empty := FormatCommanderCombosForDisplay(&EDHRECData{Card: EDHRECCardInfo{Name: "Nobody"}}, nil, 0)
+	if !strings.Contains(empty, "no combos") {
+		t.Errorf("FormatCommanderCombosForDisplay() with no combos = %q", empty)
+	}
+}
+
+func TestEDHRECCommanderResource(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/atraxa-praetors-voice.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(EDHRECResponse{Container: EDHRECContainer{JSONDict: EDHRECData{
+			Card:     EDHRECCardInfo{Name: "Atraxa, Praetors' Voice"},
+			NumDecks: 40000,
+			CardLists: []EDHRECCardList{
+				{Header: "High Synergy Cards", CardViews: []EDHRECCardView{{Name: "Tekuthal", Synergy: 0.6}}},
+			},
+		}}})
+	}))
+	defer edhrec.Close()
+
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	(&MTGCommanderServer{edhrecBaseURL: edhrec.URL}).registerResources(mcpServer)
+
+	tests := []struct {
+		name         string
+		uri          string
+		wantErr      bool
+		wantContains []string
+	}{
+		{
+			name:         "slug",
+			uri:          "edhrec://commander/atraxa-praetors-voice",
+			wantContains: []string{`"name": "Atraxa, Praetors' Voice"`, `"num_decks": 40000`, `"Tekuthal"`},
+		},
+		{
+			name:         "escaped name",
+			uri:          "edhrec://commander/Atraxa%2C%20Praetors%27%20Voice",
+			wantContains: []string{`"num_decks": 40000`},
+		},
+		{
+			name:    "unknown commander",
+			uri:     "edhrec://commander/nobody",
+			wantErr: true,
+		},
+		{
+			name:    "unusable name",
+			uri:     "edhrec://commander/%21%21",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, _ := json.Marshal(map[string]any{
+				"jsonrpc": "2.0", "id": 1, "method": "resources/read", "params": map[string]any{"uri": tt.uri},
+			})
+			response := mcpServer.HandleMessage(t.Context(), message)
+
+			if tt.wantErr {
+				if _, isErr := response.(mcp.JSONRPCError); !isErr {
+					t.Errorf("resources/read %s = %+v, want an error", tt.uri, response)
+				}
+				return
+			}
+
+			rpcResponse, ok := response.(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("resources/read %s returned %T: %+v", tt.uri, response, response)
+			}
+			result, ok := rpcResponse.Result.(mcp.ReadResourceResult)
+			if !ok || len(result.Contents) != 1 {
+				t.Fatalf("resources/read %s result = %+v", tt.uri, rpcResponse.Result)
+			}
+			text, ok := result.Contents[0].(*mcp.TextResourceContents)
+			if !ok {
+				t.Fatalf("resources/read %s content is %T", tt.uri, result.Contents[0])
+			}
+			if text.MIMEType != "application/json" || text.URI != tt.uri {
+				t.Errorf("resources/read %s MIME type %q, URI %q", tt.uri, text.MIMEType, text.URI)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(text.Text, want) {
+					t.Errorf("resources/read %s missing %q in: %s", tt.uri, want, text.Text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// maxOverviewRulings caps how many rulings explain_commander lists.
+	maxOverviewRulings = 10
+	// maxOverviewThemes caps how many EDHREC themes explain_commander lists.
+	maxOverviewThemes = 5
+)
+
+// faceAbilities is the abilities printed on one face of a card.
+type faceAbilities struct {
+	// face is the face name, empty for single-faced cards.
+	face  string
+	lines []string
+}
+
+// oracleLines splits Oracle text into its abilities, one per line.
+func oracleLines(text string) []string {
+	var lines []string
+	for line := range strings.SplitSeq(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// commanderAbilities returns a card's abilities, grouped by face for multi-faced cards.
+func commanderAbilities(card scryfall.Card) []faceAbilities {
+	if len(card.CardFaces) == 0 {
+		return []faceAbilities{{lines: oracleLines(card.OracleText)}}
+	}
+
+	abilities := make([]faceAbilities, 0, len(card.CardFaces))
+	for _, face := range card.CardFaces {
+		var text string
+		if face.OracleText != nil {
+			text = *face.OracleText
+		}
+		abilities = append(abilities, faceAbilities{face: face.Name, lines: oracleLines(text)})
+	}
+	return abilities
+}
+
+// FormatCommanderOverviewForDisplay summarizes a commander: its abilities line by line,
+// its rulings, and its top EDHREC themes. data is nil when EDHREC couldn't be reached,
+// in which case the themes section says so.
+func FormatCommanderOverviewForDisplay(
+	card scryfall.Card,
+	rulings []scryfall.Ruling,
+	data *EDHRECData,
+	themes []EDHRECTheme,
+) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Commander Overview: %s %s\n\n", card.Name, cardManaCost(card)))
+	output.WriteString(fmt.Sprintf("**Type:** %s\n", card.TypeLine))
+	output.WriteString(fmt.Sprintf("**Color Identity:** %s\n", formatColors(card.ColorIdentity)))
+	if !canBeCommander(card) {
+		output.WriteString(fmt.Sprintf("\n⚠️ **WARNING:** %s can't be a commander (it isn't legendary).\n",
+			card.Name))
+	}
+
+	output.WriteString("\n## Abilities\n\n")
+	for i, face := range commanderAbilities(card) {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		if face.face != "" {
+			output.WriteString(fmt.Sprintf("**%s:**\n", face.face))
+		}
+		if len(face.lines) == 0 {
+			output.WriteString("No rules text.\n")
+		}
+		for j, line := range face.lines {
+			output.WriteString(fmt.Sprintf("%d. %s\n", j+1, line))
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\n## Rulings (%d)\n\n", len(rulings)))
+	if len(rulings) == 0 {
+		output.WriteString("No official rulings for this card.\n")
+	}
+	for _, ruling := range rulings[:min(len(rulings), maxOverviewRulings)] {
+		output.WriteString(fmt.Sprintf("- **%s:** %s\n", ruling.PublishedAt.Format(time.DateOnly), ruling.Comment))
+	}
+	if len(rulings) > maxOverviewRulings {
+		output.WriteString(fmt.Sprintf("\n*...and %d more (see get_card_rulings)*\n",
+			len(rulings)-maxOverviewRulings))
+	}
+
+	output.WriteString("\n## EDHREC Themes\n\n")
+	switch {
+	case data == nil:
+		output.WriteString("EDHREC data wasn't available for this commander.\n")
+	case len(themes) == 0:
+		output.WriteString("No themes found for this commander.\n")
+	default:
+		output.WriteString(fmt.Sprintf("Popular build directions across %d decks:\n\n", data.NumDecks))
+		for i, theme := range themes[:min(len(themes), maxOverviewThemes)] {
+			output.WriteString(fmt.Sprintf("%d. **%s** - %d decks", i+1, theme.Value, theme.Count))
+			if data.NumDecks > 0 {
+				percentage := float64(theme.Count) / float64(data.NumDecks) * percentageMultiplier
+				output.WriteString(fmt.Sprintf(" (%.1f%%)", percentage))
+			}
+			output.WriteString("\n")
+		}
+	}
+
+	return output.String()
+}
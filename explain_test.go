@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestCommanderAbilities(t *testing.T) {
+	single := scryfall.Card{OracleText: "Flying\n\nWhenever Krenko attacks, draw a card.\n"}
+	got := commanderAbilities(single)
+	if len(got) != 1 || got[0].face != "" || len(got[0].lines) != 2 {
+		t.Fatalf("commanderAbilities() = %+v, want one unnamed face with two lines", got)
+	}
+
+	front, back := "Transform at the beginning of each upkeep.", ""
+	doubleFaced := scryfall.Card{CardFaces: []scryfall.CardFace{
+		{Name: "Front", OracleText: &front},
+		{Name: "Back", OracleText: &back},
+		{Name: "No Text"},
+	}}
+	got = commanderAbilities(doubleFaced)
+	if len(got) != 3 || got[0].face != "Front" || len(got[0].lines) != 1 || len(got[1].lines) != 0 {
+		t.Errorf("commanderAbilities() for a double-faced card = %+v", got)
+	}
+}
+
+func TestFormatCommanderOverviewForDisplay(t *testing.T) {
+	card := scryfall.Card{
+		Name:          "Krenko, Mob Boss",
+		ManaCost:      "{2}{R}{R}",
+		TypeLine:      "Legendary Creature — Goblin Warrior",
+		OracleText:    "{T}: Create X 1/1 red Goblin creature tokens, where X is the number of Goblins you control.",
+		ColorIdentity: []scryfall.Color{"R"},
+	}
+	rulings := make([]scryfall.Ruling, maxOverviewRulings+2)
+	for i := range rulings {
+		rulings[i] = scryfall.Ruling{Comment: fmt.Sprintf("Ruling %d.", i+1)}
+	}
+
+	got := FormatCommanderOverviewForDisplay(card, rulings, nil, nil)
+	for _, want := range []string{
+		"# Commander Overview: Krenko, Mob Boss {2}{R}{R}",
+		"**Color Identity:** R",
+		"1. {T}: Create X 1/1 red Goblin creature tokens",
+		"## Rulings (12)",
+		"Ruling 10.",
+		"...and 2 more (see get_card_rulings)",
+		"EDHREC data wasn't available",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCommanderOverviewForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "Ruling 11.") || strings.Contains(got, "WARNING") {
+		t.Errorf("FormatCommanderOverviewForDisplay() should cap rulings and not warn: %s", got)
+	}
+
+	card.TypeLine = "Creature — Goblin"
+	if got := FormatCommanderOverviewForDisplay(card, nil, nil, nil); !strings.Contains(got, "can't be a commander") {
+		t.Errorf("FormatCommanderOverviewForDisplay() should warn about a nonlegendary card: %s", got)
+	}
+}
+
+func TestHandleExplainCommander(t *testing.T) {
+	const krenkoID = "cd9fec9d-23c8-4d35-97c1-9499527198fb"
+
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/commanders/krenko-mob-boss.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"container": {"json_dict": {"card": {"name": "Krenko, Mob Boss"}, "num_decks": 200}},
+			"panels": {"taglinks": [
+				{"value": "Tokens", "slug": "tokens", "count": 50},
+				{"value": "Goblins", "slug": "goblins", "count": 150}
+			]}}`))
+	}))
+	defer edhrec.Close()
+
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/named":
+			if !strings.EqualFold(r.URL.Query().Get("fuzzy"), "krenko") {
+				writeScryfallNotFound(w)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{
+				ID:         krenkoID,
+				Name:       "Krenko, Mob Boss",
+				TypeLine:   "Legendary Creature — Goblin Warrior",
+				OracleText: "{T}: Create X 1/1 red Goblin creature tokens.",
+			})
+		case "/cards/" + krenkoID + "/rulings":
+			_, _ = w.Write([]byte(`{"object": "list", "has_more": false, "data": [
+				{"source": "wotc", "published_at": "2012-07-01", "comment": "X is counted on resolution."}
+			]}`))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+	s.edhrecBaseURL = edhrec.URL
+
+	got, isErr := callTool(t, s.handleExplainCommander, map[string]any{"commander": "krenko"})
+	if isErr {
+		t.Fatalf("handleExplainCommander() returned error: %s", got)
+	}
+	for _, want := range []string{
+		"1. {T}: Create X 1/1 red Goblin creature tokens.",
+		"- **2012-07-01:** X is counted on resolution.",
+		"1. **Goblins** - 150 decks (75.0%)",
+		"2. **Tokens** - 50 decks (25.0%)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleExplainCommander() missing %q in output: %s", want, got)
+		}
+	}
+
+	s.edhrecBaseURL = edhrec.URL + "/missing"
+	got, isErr = callTool(t, s.handleExplainCommander, map[string]any{"commander": "krenko"})
+	if isErr || !strings.Contains(got, "EDHREC data wasn't available") {
+		t.Errorf("handleExplainCommander() without EDHREC = %q, isError %v", got, isErr)
+	}
+}
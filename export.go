@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// deckExportFormats returns the formats export_deck supports.
+func deckExportFormats() []string {
+	return []string{"text", "mtgo"}
+}
+
+// FormatDeckAsText exports a deck as plain "<qty> <name>" lines, with commanders,
+// mainboard, and sideboard separated by blank lines. This is the format Archidekt,
+// MTGO, and Arena accept for pasted decklists.
+func FormatDeckAsText(deck *Deck) string {
+	var sections []string
+	for _, board := range [][]CardEntry{deck.Commanders, deck.Mainboard, deck.Sideboard} {
+		if len(board) == 0 {
+			continue
+		}
+
+		var section strings.Builder
+		for _, entry := range board {
+			section.WriteString(fmt.Sprintf("%d %s\n", entry.Quantity, entry.Name))
+		}
+		sections = append(sections, section.String())
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// mtgoDeck is the root element of an MTGO .dek file.
+type mtgoDeck struct {
+	XMLName              xml.Name   `xml:"Deck"`
+	XSD                  string     `xml:"xmlns:xsd,attr"`
+	XSI                  string     `xml:"xmlns:xsi,attr"`
+	NetDeckID            int        `xml:"NetDeckID"`
+	PreconstructedDeckID int        `xml:"PreconstructedDeckID"`
+	Cards                []mtgoCard `xml:"Cards"`
+}
+
+// mtgoCard is a single card entry in an MTGO .dek file. CatID is MTGO's catalog
+// ID, which deck sites don't provide; MTGO resolves cards by name when it's empty.
+type mtgoCard struct {
+	CatID     string `xml:"CatID,attr"`
+	Quantity  int    `xml:"Quantity,attr"`
+	Sideboard bool   `xml:"Sideboard,attr"`
+	Name      string `xml:"Name,attr"`
+}
+
+// FormatDeckAsMTGODek exports a deck in MTGO's .dek XML format. Commanders are
+// listed with the mainboard, following MTGO's convention.
+func FormatDeckAsMTGODek(deck *Deck) (string, error) {
+	dek := mtgoDeck{
+		XSD: "http://www.w3.org/2001/XMLSchema",
+		XSI: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+
+	for _, board := range [][]CardEntry{deck.Commanders, deck.Mainboard} {
+		for _, entry := range board {
+			dek.Cards = append(dek.Cards, mtgoCard{Quantity: entry.Quantity, Name: entry.Name})
+		}
+	}
+	for _, entry := range deck.Sideboard {
+		dek.Cards = append(dek.Cards, mtgoCard{Quantity: entry.Quantity, Sideboard: true, Name: entry.Name})
+	}
+
+	data, err := xml.MarshalIndent(dek, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode MTGO deck: %w", err)
+	}
+
+	return xml.Header + string(data) + "\n", nil
+}
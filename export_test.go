@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatDeckAsText(t *testing.T) {
+	tests := []struct {
+		name string
+		deck *MoxfieldDeck
+		want string
+	}{
+		{
+			name: "commander, mainboard, and sideboard",
+			deck: &MoxfieldDeck{
+				Commanders: map[string]MoxfieldCardEntry{
+					"c": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Omnath, Locus of Mana"}},
+				},
+				Mainboard: map[string]MoxfieldCardEntry{
+					"2": {Quantity: 30, Card: MoxfieldCardInfo{Name: "Forest"}},
+					"1": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Cultivate"}},
+				},
+				Sideboard: map[string]MoxfieldCardEntry{
+					"s": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Beast Within"}},
+				},
+			},
+			want: "1 Omnath, Locus of Mana\n\n1 Cultivate\n30 Forest\n\n1 Beast Within\n",
+		},
+		{
+			name: "no commander or sideboard",
+			deck: &MoxfieldDeck{
+				Mainboard: map[string]MoxfieldCardEntry{
+					"1": {Quantity: 4, Card: MoxfieldCardInfo{Name: "Lightning Bolt"}},
+				},
+			},
+			want: "4 Lightning Bolt\n",
+		},
+		{
+			name: "empty deck",
+			deck: &MoxfieldDeck{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDeckAsText(tt.deck.ToDeck()); got != tt.want {
+				t.Errorf("FormatDeckAsText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDeckAsTextRoundTrip(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Commanders: map[string]MoxfieldCardEntry{
+			"c": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Omnath, Locus of Mana"}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"1": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring"}},
+			"2": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Forest"}},
+		},
+	}
+
+	parsed := ParseDecklist(FormatDeckAsText(deck.ToDeck()))
+	if len(parsed.Warnings) != 0 || parsed.TotalCards != 12 {
+		t.Errorf("ParseDecklist(FormatDeckAsText()) = %+v, want 12 cards without warnings", parsed)
+	}
+}
+
+func TestFormatDeckAsMTGODek(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Commanders: map[string]MoxfieldCardEntry{
+			"c": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Omnath, Locus of Mana"}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"1": {Quantity: 30, Card: MoxfieldCardInfo{Name: "Forest"}},
+			"2": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Kodama's Reach"}},
+			"3": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Fire & Ice"}},
+		},
+		Sideboard: map[string]MoxfieldCardEntry{
+			"s": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Beast Within"}},
+		},
+	}
+
+	got, err := FormatDeckAsMTGODek(deck.ToDeck())
+	if err != nil {
+		t.Fatalf("FormatDeckAsMTGODek() error = %v", err)
+	}
+
+	// Well-formed: the whole document tokenizes without error
+	decoder := xml.NewDecoder(strings.NewReader(got))
+	for {
+		_, tokenErr := decoder.Token()
+		if errors.Is(tokenErr, io.EOF) {
+			break
+		}
+		if tokenErr != nil {
+			t.Fatalf("FormatDeckAsMTGODek() produced malformed XML: %v\n%s", tokenErr, got)
+		}
+	}
+
+	var parsed struct {
+		Cards []struct {
+			CatID     string `xml:"CatID,attr"`
+			Quantity  int    `xml:"Quantity,attr"`
+			Sideboard bool   `xml:"Sideboard,attr"`
+			Name      string `xml:"Name,attr"`
+		} `xml:"Cards"`
+	}
+	if unmarshalErr := xml.Unmarshal([]byte(got), &parsed); unmarshalErr != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", unmarshalErr)
+	}
+
+	want := []string{
+		"1 Omnath, Locus of Mana false",
+		"1 Fire & Ice false",
+		"30 Forest false",
+		"1 Kodama's Reach false",
+		"1 Beast Within true",
+	}
+	if len(parsed.Cards) != len(want) {
+		t.Fatalf("FormatDeckAsMTGODek() has %d cards, want %d:\n%s", len(parsed.Cards), len(want), got)
+	}
+	for i, card := range parsed.Cards {
+		if entry := fmt.Sprintf("%d %s %t", card.Quantity, card.Name, card.Sideboard); entry != want[i] {
+			t.Errorf("FormatDeckAsMTGODek() card[%d] = %q, want %q", i, entry, want[i])
+		}
+	}
+
+	for _, want := range []string{`<?xml version="1.0" encoding="UTF-8"?>`, "<Deck ", `CatID=""`, "&amp;"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDeckAsMTGODek() missing %q in output:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keywordGlossary maps lowercase keyword names to a one-line reminder of what they do.
+// Add entries here as new keywords come up; keywords without an entry are still listed.
+func keywordGlossary() map[string]string {
+	return map[string]string{
+		// Evergreen keyword abilities
+		"deathtouch":     "Any amount of damage this deals to a creature is enough to destroy it.",
+		"defender":       "This creature can't attack.",
+		"double strike":  "Deals both first-strike and regular combat damage.",
+		"first strike":   "Deals combat damage before creatures without first strike.",
+		"flash":          "You may cast this any time you could cast an instant.",
+		"flying":         "Can't be blocked except by creatures with flying or reach.",
+		"haste":          "Can attack and use tap abilities the turn it comes under your control.",
+		"hexproof":       "Can't be the target of spells or abilities your opponents control.",
+		"indestructible": "Damage and \"destroy\" effects don't destroy it.",
+		"lifelink":       "Damage dealt by this also causes you to gain that much life.",
+		"menace":         "Can't be blocked except by two or more creatures.",
+		"reach":          "Can block creatures with flying.",
+		"trample":        "Combat damage beyond what's lethal to its blockers carries over to the player.",
+		"vigilance":      "Attacking doesn't cause this creature to tap.",
+		"ward":           "Spells and abilities opponents target this with are countered unless they pay the ward cost.",
+		"protection":     "Can't be damaged, enchanted, blocked, or targeted by anything with the stated quality.",
+		"equip":          "Pay the cost to attach this to a creature you control. Only as a sorcery.",
+		"enchant":        "Says what this Aura can be attached to.",
+
+		// Keyword actions
+		"scry":        "Look at that many cards from the top of your library; put any on the bottom.",
+		"surveil":     "Look at that many cards from the top of your library; put any into your graveyard.",
+		"mill":        "Put that many cards from the top of your library into your graveyard.",
+		"fight":       "Each of two creatures deals damage equal to its power to the other.",
+		"investigate": "Create a Clue token: an artifact with \"{2}, Sacrifice this: Draw a card.\"",
+		"proliferate": "Give each of any number of permanents and players another counter of a kind it has.",
+		"explore":     "Reveal your top card: take it if it's a land, otherwise this gets a +1/+1 counter.",
+		"amass":       "Put +1/+1 counters on your Army, creating a 0/0 Army token first if needed.",
+		"connive":     "Draw, then discard. If you discarded a nonland card, put a +1/+1 counter on this.",
+		"goad":        "Until your next turn, that creature attacks each combat, and not you if able.",
+
+		// Common in Commander
+		"convoke":             "Each creature you tap while casting this pays for {1} or one mana of its color.",
+		"cascade":             "When cast, exile cards until you hit a cheaper nonland card; you may cast it free.",
+		"storm":               "When cast, copy this for each spell cast before it this turn.",
+		"flashback":           "You may cast this from your graveyard for its flashback cost, then exile it.",
+		"kicker":              "You may pay an additional cost as you cast this for an extra effect.",
+		"cycling":             "Pay the cycling cost and discard this card to draw a card.",
+		"landfall":            "Triggers whenever a land enters the battlefield under your control.",
+		"partner":             "You can have two commanders if both have partner.",
+		"partner with":        "You can have this and the named partner as your two commanders.",
+		"affinity":            "Costs {1} less for each of the stated permanents you control.",
+		"delve":               "Each card you exile from your graveyard while casting this pays for {1}.",
+		"undying":             "When this dies without a +1/+1 counter, return it with one.",
+		"persist":             "When this dies without a -1/-1 counter, return it with one.",
+		"annihilator":         "Whenever this attacks, the defending player sacrifices that many permanents.",
+		"infect":              "Deals damage to creatures as -1/-1 counters and to players as poison counters.",
+		"myriad":              "Whenever this attacks, copy it attacking each other opponent; exile the copies after combat.",
+		"eminence":            "Works while this commander is in the command zone as well as on the battlefield.",
+		"prowess":             "Whenever you cast a noncreature spell, this gets +1/+1 until end of turn.",
+		"crew":                "Tap creatures with that much total power to make this Vehicle a creature this turn.",
+		"encore":              "Exile from your graveyard: make a hasty token copy attacking each opponent this turn.",
+		"mutate":              "Cast this onto a non-Human creature you own to merge with it.",
+		"companion":           "If your deck meets the condition, you may put this into your hand from outside for {3}.",
+		"changeling":          "This object is every creature type.",
+		"devoid":              "This card has no color.",
+		"suspend":             "Exile with time counters; remove one each upkeep and cast it free when the last goes.",
+		"miracle":             "You may cast this for its miracle cost if it's the first card you drew this turn.",
+		"phasing":             "Phases in or out as you untap; while phased out it's treated as though it doesn't exist.",
+		"split second":        "While this is on the stack, players can't cast spells or activate non-mana abilities.",
+		"casualty":            "As you cast this, you may sacrifice a creature with that much power to copy it.",
+		"blitz":               "Cast for the blitz cost: haste, draw a card when it dies, and sacrifice it at end of turn.",
+		"dethrone":            "Whenever this attacks the player with the most life, put a +1/+1 counter on it.",
+		"umbra armor":         "If the enchanted permanent would be destroyed, remove its damage and destroy this instead.",
+		"totem armor":         "If the enchanted permanent would be destroyed, remove its damage and destroy this instead.",
+		"embalm":              "Exile from your graveyard and pay the cost for a token copy that's a white Zombie.",
+		"eternalize":          "Exile from your graveyard and pay the cost for a 4/4 black Zombie token copy.",
+		"choose a background": "You can have a Background enchantment as your second commander.",
+	}
+}
+
+// formatKeywords lists a card's keywords, each followed by its glossary definition
+// when there is one. Cards without keywords get nothing.
+func formatKeywords(keywords []string) string {
+	if len(keywords) == 0 {
+		return ""
+	}
+
+	glossary := keywordGlossary()
+	var output strings.Builder
+	output.WriteString("\n**Keywords:**\n")
+	for _, keyword := range keywords {
+		if definition, ok := glossary[strings.ToLower(keyword)]; ok {
+			output.WriteString(fmt.Sprintf("- **%s:** %s\n", keyword, definition))
+		} else {
+			output.WriteString(fmt.Sprintf("- **%s**\n", keyword))
+		}
+	}
+	return output.String()
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestFormatKeywords(t *testing.T) {
+	card := scryfall.Card{Name: "Venerated Loxodon", Keywords: []string{"Convoke", "Deathtouch", "Gloomwalk"}}
+	got := FormatCardDetailsForDisplay(card, manaFormatSymbols)
+
+	for _, want := range []string{
+		"**Keywords:**\n",
+		"- **Convoke:** Each creature you tap while casting this pays for {1} or one mana of its color.\n",
+		"- **Deathtouch:** Any amount of damage",
+		"- **Gloomwalk**\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCardDetailsForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+
+	if got = formatKeywords(nil); got != "" {
+		t.Errorf("formatKeywords(nil) = %q, want empty", got)
+	}
+}
+
+func TestKeywordGlossaryKeys(t *testing.T) {
+	// Lookups lowercase the keyword, so every key must already be lowercase
+	for keyword, definition := range keywordGlossary() {
+		if keyword != strings.ToLower(keyword) {
+			t.Errorf("keywordGlossary() key %q is not lowercase", keyword)
+		}
+		if !strings.HasSuffix(definition, ".") && !strings.HasSuffix(definition, ".\"") {
+			t.Errorf("keywordGlossary()[%q] = %q, want a one-line sentence", keyword, definition)
+		}
+	}
+}
@@ -6,6 +6,10 @@ require (
 	github.com/BlueMonday/go-scryfall v0.9.1
 	github.com/mark3labs/mcp-go v0.43.0
 	github.com/rs/zerolog v1.34.0
+	golang.org/x/sync v0.17.0
+	golang.org/x/text v0.29.0
+	golang.org/x/time v0.15.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 )
 
 require (
@@ -1,26 +1,307 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
-const defaultHTTPTimeout = 10 * time.Second
+const (
+	// defaultHTTPTimeout bounds each outbound HTTP request, including reading the body.
+	defaultHTTPTimeout = 10 * time.Second
+	// httpTimeoutEnvVar overrides defaultHTTPTimeout.
+	httpTimeoutEnvVar = "HTTP_TIMEOUT"
+	// defaultHTTPMaxAttempts is how many times HTTPGet tries a request before giving up.
+	defaultHTTPMaxAttempts = 3
+	// defaultHTTPBackoffBase is the delay before the first retry; it doubles on each attempt.
+	defaultHTTPBackoffBase = 200 * time.Millisecond
+	// maxRetryAfterDelay caps how long we honor a server's Retry-After header.
+	maxRetryAfterDelay = 30 * time.Second
+	// defaultRetryAfterDelay is used when a 429 response has no usable Retry-After header.
+	defaultRetryAfterDelay = 2 * time.Second
+)
+
+// Sentinel errors for non-OK responses from Moxfield and EDHREC. Helpers wrap them in
+// a *StatusError, so callers can match the kind of failure with errors.Is.
+var (
+	// ErrNotFound means the requested deck, commander, or page doesn't exist (404).
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited means the service is rate limiting us (429), even after retrying.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUpstream means the service failed or answered with an unexpected status.
+	ErrUpstream = errors.New("upstream error")
+)
+
+// StatusError is a non-OK response from an upstream API. It unwraps to ErrNotFound,
+// ErrRateLimited, or ErrUpstream depending on the status code.
+type StatusError struct {
+	// Service names the API, e.g. "moxfield API" or "EDHREC combos API".
+	Service string
+	// Subject is what was requested (a deck ID, commander name), if worth reporting.
+	Subject    string
+	StatusCode int
+}
+
+// newStatusError returns the error for a non-OK response from service.
+func newStatusError(service, subject string, statusCode int) *StatusError {
+	return &StatusError{Service: service, Subject: subject, StatusCode: statusCode}
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s returned status %d", e.Service, e.StatusCode)
+	if e.Subject != "" {
+		msg += " for " + e.Subject
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error for the response's status code.
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return ErrUpstream
+	}
+}
+
+// upstreamErrorHint returns advice to append to a tool error caused by err, or an
+// empty string when err isn't an upstream status error.
+func upstreamErrorHint(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return ". Nothing exists under that name or ID: check the spelling, " +
+			"and for Moxfield or Archidekt that the deck is public"
+	case errors.Is(err, ErrRateLimited):
+		return ". The service is rate limiting requests: wait a minute and try again"
+	case errors.Is(err, ErrUpstream):
+		return ". The service is having problems: try again later"
+	default:
+		return ""
+	}
+}
 
-// HTTPGet performs an HTTP GET request with context and timeout.
+// sharedHTTPClient sends every outbound request (Scryfall, Moxfield, EDHREC, and
+// exchange rates) so connections are kept alive and reused across calls. Scryfall
+// API requests are throttled to scryfallRPS.
+var sharedHTTPClient = &http.Client{ //nolint:gochecknoglobals // shared client
+	Timeout: defaultHTTPTimeout,
+	Transport: &throttledTransport{
+		base:    http.DefaultTransport,
+		host:    scryfallAPIHost,
+		limiter: scryfallLimiter,
+		counter: scryfallRequests,
+	},
+}
+
+// ConfigureHTTPTimeout sets the shared client's timeout from the HTTP_TIMEOUT
+// environment variable, given as a duration ("15s", "1m") or whole seconds, falling
+// back to defaultHTTPTimeout when unset or invalid. Call it before making requests.
+func ConfigureHTTPTimeout() time.Duration {
+	timeout := defaultHTTPTimeout
+	if value := os.Getenv(httpTimeoutEnvVar); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			seconds, atoiErr := strconv.Atoi(value)
+			parsed, err = time.Duration(seconds)*time.Second, atoiErr
+		}
+		if err != nil || parsed <= 0 {
+			GetLogger().Warn().Str("value", value).Msgf("Invalid %s, using default", httpTimeoutEnvVar)
+		} else {
+			timeout = parsed
+		}
+	}
+
+	sharedHTTPClient.Timeout = timeout
+	return timeout
+}
+
+// retryPolicy controls how HTTP requests are retried.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// HTTPGet performs an HTTP GET request with context and timeout. Connection errors and
+// 5xx/429 responses are retried with exponential backoff; if every attempt fails the
+// last response or error is returned.
 func HTTPGet(ctx context.Context, url string) (*http.Response, error) {
-	client := &http.Client{
-		Timeout: defaultHTTPTimeout,
+	return httpGetWithRetry(ctx, url, retryPolicy{
+		maxAttempts: defaultHTTPMaxAttempts,
+		baseDelay:   defaultHTTPBackoffBase,
+	})
+}
+
+// httpGetWithRetry performs an HTTP GET request, retrying retryable failures according to policy.
+func httpGetWithRetry(ctx context.Context, url string, policy retryPolicy) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for attempt := range max(policy.maxAttempts, 1) {
+		if attempt > 0 {
+			delay := backoffDelay(policy.baseDelay, attempt)
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					delay = retryAfter
+				}
+			}
+			if waitErr := sleepWithContext(ctx, delay); waitErr != nil {
+				closeResponse(resp)
+				return nil, waitErr
+			}
+			closeResponse(resp)
+		}
+
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
+		req.Header.Set("Accept", "application/json")
+
+		resp, err = sharedHTTPClient.Do(req)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		if !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		GetLogger().Debug().Str("url", url).Int("attempt", attempt+1).Msg("Retryable HTTP failure")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return resp, err
+}
+
+// doWithRateLimitRetry sends req and, if the server answers 429 Too Many Requests,
+// waits for the Retry-After period (capped at maxRetryAfterDelay, or
+// defaultRetryAfterDelay when the header is missing) and retries once. The wait is
+// cut short if the request context is done.
+func doWithRateLimitRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	return doWithRateLimitRetryWait(client, req, nil)
+}
+
+// doWithRateLimitRetryWait is doWithRateLimitRetry with a wait hook, such as a rate
+// limiter's Wait, called before every attempt so the retry is throttled like the
+// first request. A nil wait sends without waiting.
+func doWithRateLimitRetryWait(
+	client *http.Client,
+	req *http.Request,
+	wait func(context.Context) error,
+) (*http.Response, error) {
+	send := func(req *http.Request) (*http.Response, error) {
+		if wait != nil {
+			if err := wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		return client.Do(req)
+	}
+
+	resp, err := send(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		delay = defaultRetryAfterDelay
+	}
+	closeResponse(resp)
+
+	GetLogger().Warn().Str("url", req.URL.String()).Dur("retry_after", delay).Msg("Rate limited, retrying")
+
+	if waitErr := sleepWithContext(req.Context(), delay); waitErr != nil {
+		return nil, waitErr
+	}
+
+	return send(req.Clone(req.Context()))
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds or as an
+// HTTP date, capping the result at maxRetryAfterDelay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		delay = time.Duration(seconds) * time.Second
+	} else {
+		date, dateErr := http.ParseTime(value)
+		if dateErr != nil {
+			return 0, false
+		}
+		delay = max(date.Sub(now), 0)
+	}
+
+	return min(delay, maxRetryAfterDelay), true
+}
+
+// isRetryable reports whether a request outcome is worth retrying: connection
+// errors, 5xx server errors, and 429 rate limiting.
+func isRetryable(resp *http.Response, err error) bool {
 	if err != nil {
-		return nil, err
+		return true
 	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoffDelay returns the exponential backoff delay before the given retry
+// attempt, with up to 50% random jitter added.
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+	return delay + rand.N(delay/2+1) //nolint:gosec // jitter doesn't need a secure source
+}
 
-	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
-	req.Header.Set("Accept", "application/json")
+// sleepWithContext waits for d or until ctx is done, whichever comes first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	return client.Do(req)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// closeResponse drains and closes a response body so its connection can be reused.
+func closeResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// responseBody returns the response body, decompressing it if the server sent it
+// gzipped. The transport only decompresses on its own when it added the
+// Accept-Encoding header itself, so requests that ask for gzip explicitly read
+// their bodies through here.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	return gzip.NewReader(resp.Body)
 }
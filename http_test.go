@@ -1,9 +1,15 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -127,3 +133,355 @@ func TestHTTPGet_InvalidURL(t *testing.T) {
 		t.Error("HTTPGet() expected error with invalid URL, got nil")
 	}
 }
+
+func TestHTTPGetWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{name: "recovers after 503", statuses: []int{503, 200}, wantStatus: 200, wantAttempts: 2},
+		{name: "retries 429", statuses: []int{429, 429, 200}, wantStatus: 200, wantAttempts: 3},
+		{name: "does not retry 404", statuses: []int{404, 200}, wantStatus: 404, wantAttempts: 1},
+		{name: "returns last response", statuses: []int{500, 502, 503, 200}, wantStatus: 503, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				n := attempts.Add(1)
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			policy := retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond}
+			resp, err := httpGetWithRetry(context.Background(), server.URL, policy)
+			if err != nil {
+				t.Fatalf("httpGetWithRetry() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("httpGetWithRetry() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("httpGetWithRetry() attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestHTTPGetWithRetry_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	_, err := httpGetWithRetry(context.Background(), url, retryPolicy{maxAttempts: 2, baseDelay: time.Millisecond})
+	if err == nil {
+		t.Error("httpGetWithRetry() expected connection error, got nil")
+	}
+}
+
+func TestHTTPGetWithRetry_CancelDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := httpGetWithRetry(ctx, server.URL, retryPolicy{maxAttempts: 3, baseDelay: time.Minute})
+	if err == nil {
+		t.Error("httpGetWithRetry() expected context error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("httpGetWithRetry() took %v, want it to stop when the context is done", elapsed)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	base := 200 * time.Millisecond
+	for attempt := 1; attempt <= 3; attempt++ {
+		want := base << (attempt - 1)
+		got := backoffDelay(base, attempt)
+		if got < want || got > want+want/2 {
+			t.Errorf("backoffDelay(%v, %d) = %v, want between %v and %v", base, attempt, got, want, want+want/2)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "5", want: 5 * time.Second, wantOK: true},
+		{name: "capped", value: "3600", want: maxRetryAfterDelay, wantOK: true},
+		{name: "negative", value: "-1", wantOK: false},
+		{name: "http date", value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second, wantOK: true},
+		{name: "date in past", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "garbage", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDoWithRateLimitRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{name: "success", statuses: []int{200}, wantStatus: 200, wantAttempts: 1},
+		{name: "retries once after 429", statuses: []int{429, 200}, wantStatus: 200, wantAttempts: 2},
+		{name: "gives up after one retry", statuses: []int{429, 429, 200}, wantStatus: 429, wantAttempts: 2},
+		{name: "does not retry 503", statuses: []int{503, 200}, wantStatus: 503, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				n := attempts.Add(1)
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := doWithRateLimitRetry(&http.Client{}, req)
+			if err != nil {
+				t.Fatalf("doWithRateLimitRetry() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("doWithRateLimitRetry() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("doWithRateLimitRetry() attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoWithRateLimitRetryWait(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waits := 0
+	wait := func(context.Context) error {
+		waits++
+		return nil
+	}
+	resp, err := doWithRateLimitRetryWait(&http.Client{}, req, wait)
+	if err != nil {
+		t.Fatalf("doWithRateLimitRetryWait() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || attempts.Load() != 2 {
+		t.Errorf("doWithRateLimitRetryWait() status = %d after %d attempts, want 200 after 2",
+			resp.StatusCode, attempts.Load())
+	}
+	if waits != 2 {
+		t.Errorf("doWithRateLimitRetryWait() waited %d times, want once per attempt", waits)
+	}
+
+	// A failed wait stops the request before it is sent
+	stop := errors.New("throttled")
+	if _, err := doWithRateLimitRetryWait(&http.Client{}, req, func(context.Context) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("doWithRateLimitRetryWait() error = %v, want %v", err, stop)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("doWithRateLimitRetryWait() sent a request after a failed wait, attempts = %d", got)
+	}
+}
+
+func TestDoWithRateLimitRetry_ContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := doWithRateLimitRetry(&http.Client{}, req); err == nil {
+		t.Error("doWithRateLimitRetry() expected context error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("doWithRateLimitRetry() took %v, want it to stop at the context deadline", elapsed)
+	}
+}
+
+func TestConfigureHTTPTimeout(t *testing.T) {
+	previous := sharedHTTPClient.Timeout
+	t.Cleanup(func() { sharedHTTPClient.Timeout = previous })
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: defaultHTTPTimeout},
+		{value: "30s", want: 30 * time.Second},
+		{value: "1m", want: time.Minute},
+		{value: "5", want: 5 * time.Second},
+		{value: "0", want: defaultHTTPTimeout},
+		{value: "-3s", want: defaultHTTPTimeout},
+		{value: "slow", want: defaultHTTPTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(httpTimeoutEnvVar, tt.value)
+
+			if got := ConfigureHTTPTimeout(); got != tt.want {
+				t.Errorf("ConfigureHTTPTimeout() = %v, want %v", got, tt.want)
+			}
+			if sharedHTTPClient.Timeout != tt.want {
+				t.Errorf("sharedHTTPClient.Timeout = %v, want %v", sharedHTTPClient.Timeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpstreamStatusErrors(t *testing.T) {
+	fetchers := map[string]func(ctx context.Context, baseURL string) error{
+		"moxfield deck": func(ctx context.Context, baseURL string) error {
+			_, err := getMoxfieldDeckWithURL(ctx, "abc123", baseURL)
+			return err
+		},
+		"EDHREC recommendations": func(ctx context.Context, baseURL string) error {
+			_, err := getCommanderRecommendationsWithURL(ctx, "Atraxa, Praetors' Voice", baseURL)
+			return err
+		},
+		"EDHREC combos": func(ctx context.Context, baseURL string) error {
+			_, err := getCombosForColorsWithURL(ctx, "ub", baseURL)
+			return err
+		},
+		"EDHREC average deck": func(ctx context.Context, baseURL string) error {
+			_, err := getAverageDeckWithURL(ctx, "Krenko, Mob Boss", baseURL)
+			return err
+		},
+	}
+
+	statuses := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{status: http.StatusForbidden, want: ErrUpstream},
+		{status: http.StatusBadGateway, want: ErrUpstream},
+	}
+
+	for name, fetch := range fetchers {
+		for _, tt := range statuses {
+			t.Run(fmt.Sprintf("%s %d", name, tt.status), func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+				}))
+				defer server.Close()
+
+				err := fetch(t.Context(), server.URL)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("error = %v, want %v", err, tt.want)
+				}
+
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+					t.Errorf("error = %#v, want a *StatusError with status %d", err, tt.status)
+				}
+				if upstreamErrorHint(err) == "" {
+					t.Errorf("upstreamErrorHint(%v) is empty", err)
+				}
+			})
+		}
+	}
+
+	if hint := upstreamErrorHint(errors.New("connection refused")); hint != "" {
+		t.Errorf("upstreamErrorHint() for a non-status error = %q, want empty", hint)
+	}
+}
+
+func TestResponseBody(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte(`{"ok": true}`))
+	_ = gz.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		want     string
+		wantErr  bool
+	}{
+		{name: "plain", body: []byte(`{"ok": true}`), want: `{"ok": true}`},
+		{name: "gzip", encoding: "gzip", body: compressed.Bytes(), want: `{"ok": true}`},
+		{name: "gzip uppercase", encoding: "GZIP", body: compressed.Bytes(), want: `{"ok": true}`},
+		{name: "corrupt gzip", encoding: "gzip", body: []byte("not gzip"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Encoding": []string{tt.encoding}},
+				Body:   io.NopCloser(bytes.NewReader(tt.body)),
+			}
+
+			body, err := responseBody(resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("responseBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := io.ReadAll(body)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("responseBody() read %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
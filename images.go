@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultCardImageSize is the Scryfall image size used when none is requested.
+	defaultCardImageSize = "normal"
+	// maxCardImageBytes caps how much image data is inlined into a tool result.
+	maxCardImageBytes = 5 << 20
+	// defaultProxyImageSize is the image size get_proxy_images uses when none is requested.
+	defaultProxyImageSize = "png"
+)
+
+// CardImage is the image URL for one face of a card.
+type CardImage struct {
+	Face string
+	URL  string
+}
+
+// cardImageSizes returns the image sizes get_card_image accepts.
+func cardImageSizes() []string {
+	return []string{"small", "normal", "large", "png", "art_crop"}
+}
+
+// proxyImageSizes returns the image sizes get_proxy_images accepts: full cards as
+// PNG for printing, or art crops.
+func proxyImageSizes() []string {
+	return []string{"png", "art_crop"}
+}
+
+// imageURIForSize returns the URI for the requested size, or "" if the size is unknown or missing.
+func imageURIForSize(uris scryfall.ImageURIs, size string) string {
+	switch size {
+	case "small":
+		return uris.Small
+	case "normal":
+		return uris.Normal
+	case "large":
+		return uris.Large
+	case "png":
+		return uris.PNG
+	case "art_crop":
+		return uris.ArtCrop
+	default:
+		return ""
+	}
+}
+
+// cardImageURLs returns the image URL for each face of a card. Single-faced cards
+// carry their images at the top level; double-faced cards carry them per face.
+func cardImageURLs(card scryfall.Card, size string) []CardImage {
+	if card.ImageURIs != nil {
+		if uri := imageURIForSize(*card.ImageURIs, size); uri != "" {
+			return []CardImage{{Face: card.Name, URL: uri}}
+		}
+		return nil
+	}
+
+	var images []CardImage
+	for _, face := range card.CardFaces {
+		if uri := imageURIForSize(face.ImageURIs, size); uri != "" {
+			images = append(images, CardImage{Face: face.Name, URL: uri})
+		}
+	}
+	return images
+}
+
+// fetchImageContent downloads an image and wraps it as MCP image content.
+func fetchImageContent(ctx context.Context, url string) (mcp.ImageContent, error) {
+	resp, err := HTTPGet(ctx, url)
+	if err != nil {
+		return mcp.ImageContent{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return mcp.ImageContent{}, fmt.Errorf("image request returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCardImageBytes+1))
+	if err != nil {
+		return mcp.ImageContent{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxCardImageBytes {
+		return mcp.ImageContent{}, fmt.Errorf("image exceeds %d bytes", maxCardImageBytes)
+	}
+
+	mimeType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType = http.DetectContentType(data)
+	}
+
+	return mcp.NewImageContent(base64.StdEncoding.EncodeToString(data), mimeType), nil
+}
+
+// FormatCardImagesForDisplay lists the image URL of each card face.
+func FormatCardImagesForDisplay(cardName, size string, images []CardImage) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# %s (%s)\n\n", cardName, size))
+
+	if len(images) == 1 {
+		output.WriteString(fmt.Sprintf("**Image:** %s\n", images[0].URL))
+		return output.String()
+	}
+
+	for _, image := range images {
+		output.WriteString(fmt.Sprintf("**%s:** %s\n", image.Face, image.URL))
+	}
+	return output.String()
+}
+
+// proxyImageJSON is one printable image in a proxy sheet: a copy of a card, or one
+// face of a double-faced card.
+type proxyImageJSON struct {
+	Name string `json:"name"`
+	Face string `json:"face"`
+	URL  string `json:"url"`
+}
+
+// proxySheetJSON lists an image for every copy of every card in a decklist, in
+// decklist order, for a downstream tool to lay out on pages.
+type proxySheetJSON struct {
+	Size       string           `json:"size"`
+	TotalCards int              `json:"total_cards"`
+	Images     []proxyImageJSON `json:"images"`
+	// NotFound lists the decklist names Scryfall couldn't resolve.
+	NotFound []string `json:"not_found"`
+	// NoImage lists resolved cards Scryfall has no image of in this size.
+	NoImage []string `json:"no_image,omitempty"`
+}
+
+// newProxySheetJSON builds the proxy sheet for a decklist, repeating each card's
+// images once per copy. Double-faced cards get one image per face.
+func newProxySheetJSON(entries []DecklistEntry, lookup CardLookupResult, size string) proxySheetJSON {
+	sheet := proxySheetJSON{
+		Size:     size,
+		Images:   []proxyImageJSON{},
+		NotFound: []string{},
+	}
+	if lookup.NotFound != nil {
+		sheet.NotFound = lookup.NotFound
+	}
+
+	for _, entry := range entries {
+		card, ok := lookup.Get(entry.Name)
+		if !ok {
+			continue
+		}
+
+		images := cardImageURLs(card, size)
+		if len(images) == 0 {
+			if !slices.Contains(sheet.NoImage, card.Name) {
+				sheet.NoImage = append(sheet.NoImage, card.Name)
+			}
+			continue
+		}
+
+		sheet.TotalCards += entry.Quantity
+		for range entry.Quantity {
+			for _, image := range images {
+				sheet.Images = append(sheet.Images, proxyImageJSON{Name: card.Name, Face: image.Face, URL: image.URL})
+			}
+		}
+	}
+
+	return sheet
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCardImageURLs(t *testing.T) {
+	single := scryfall.Card{
+		Name:      "Sol Ring",
+		ImageURIs: &scryfall.ImageURIs{Normal: "https://img/sol-normal.jpg", ArtCrop: "https://img/sol-art.jpg"},
+	}
+	doubleFaced := scryfall.Card{
+		Name: "Delver of Secrets // Insectile Aberration",
+		CardFaces: []scryfall.CardFace{
+			{Name: "Delver of Secrets", ImageURIs: scryfall.ImageURIs{Normal: "https://img/delver-front.jpg"}},
+			{Name: "Insectile Aberration", ImageURIs: scryfall.ImageURIs{Normal: "https://img/delver-back.jpg"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		card scryfall.Card
+		size string
+		want []CardImage
+	}{
+		{
+			name: "single face",
+			card: single,
+			size: "art_crop",
+			want: []CardImage{{Face: "Sol Ring", URL: "https://img/sol-art.jpg"}},
+		},
+		{
+			name: "double faced",
+			card: doubleFaced,
+			size: "normal",
+			want: []CardImage{
+				{Face: "Delver of Secrets", URL: "https://img/delver-front.jpg"},
+				{Face: "Insectile Aberration", URL: "https://img/delver-back.jpg"},
+			},
+		},
+		{name: "missing size", card: single, size: "png", want: nil},
+		{name: "no image data", card: scryfall.Card{Name: "Unknown"}, size: "normal", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardImageURLs(tt.card, tt.size)
+			if len(got) != len(tt.want) {
+				t.Fatalf("cardImageURLs() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("cardImageURLs()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHandleGetCardImage(t *testing.T) {
+	pngBytes := []byte("\x89PNG\r\n\x1a\n fake image data")
+
+	var s *MTGCommanderServer
+	s = newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/cards/named" && r.URL.Query().Get("fuzzy") == "Sol Ring":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{
+				Name: "Sol Ring",
+				ImageURIs: &scryfall.ImageURIs{
+					Normal: s.scryfallBaseURL + "/images/sol-ring.png",
+					Large:  s.scryfallBaseURL + "/images/missing.jpg",
+				},
+			})
+		case r.URL.Path == "/cards/named" && r.URL.Query().Get("fuzzy") == "Blank Card":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{Name: "Blank Card"})
+		case r.URL.Path == "/images/sol-ring.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(pngBytes)
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	t.Run("inline image", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"name": "Sol Ring"}
+
+		result, err := s.handleGetCardImage(t.Context(), request)
+		if err != nil || result.IsError {
+			t.Fatalf("handleGetCardImage() = %+v, %v", result, err)
+		}
+
+		if len(result.Content) != 2 {
+			t.Fatalf("handleGetCardImage() returned %d content blocks, want text and image", len(result.Content))
+		}
+
+		text, _ := result.Content[0].(mcp.TextContent)
+		if !strings.Contains(text.Text, "/images/sol-ring.png") {
+			t.Errorf("handleGetCardImage() text missing image URL: %s", text.Text)
+		}
+
+		image, ok := result.Content[1].(mcp.ImageContent)
+		if !ok || image.MIMEType != "image/png" || image.Data == "" {
+			t.Errorf("handleGetCardImage() image = %+v, want base64 PNG", result.Content[1])
+		}
+	})
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains string
+	}{
+		{
+			name:         "download fails falls back to URL",
+			args:         map[string]any{"name": "Sol Ring", "size": "large"},
+			wantContains: "/images/missing.jpg",
+		},
+		{
+			name:         "no image data",
+			args:         map[string]any{"name": "Blank Card"},
+			wantContains: "No normal image is available for Blank Card",
+		},
+		{
+			name:         "invalid size",
+			args:         map[string]any{"name": "Sol Ring", "size": "huge"},
+			wantErr:      true,
+			wantContains: "Invalid size",
+		},
+		{
+			name:    "unknown card",
+			args:    map[string]any{"name": "Not A Card"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetCardImage, tt.args)
+
+			if isErr != tt.wantErr {
+				t.Errorf("handleGetCardImage() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			if !strings.Contains(got, tt.wantContains) {
+				t.Errorf("handleGetCardImage() missing %q in output: %s", tt.wantContains, got)
+			}
+		})
+	}
+}
+
+func TestFormatCardImagesForDisplay(t *testing.T) {
+	got := FormatCardImagesForDisplay("Delver of Secrets // Insectile Aberration", "normal", []CardImage{
+		{Face: "Delver of Secrets", URL: "https://img/front.jpg"},
+		{Face: "Insectile Aberration", URL: "https://img/back.jpg"},
+	})
+
+	for _, want := range []string{
+		"**Delver of Secrets:** https://img/front.jpg",
+		"**Insectile Aberration:** https://img/back.jpg",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatCardImagesForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+
+	single := FormatCardImagesForDisplay("Sol Ring", "png", []CardImage{{Face: "Sol Ring", URL: "https://img/sol.png"}})
+	if want := "# Sol Ring (png)\n\n**Image:** https://img/sol.png\n"; single != want {
+		t.Errorf("FormatCardImagesForDisplay() single face = %q, want %q", single, want)
+	}
+}
+
+func TestHandleGetProxyImages(t *testing.T) {
+	known := map[string]scryfall.Card{
+		"sol ring": {
+			Name:      "Sol Ring",
+			ImageURIs: &scryfall.ImageURIs{PNG: "https://img/sol.png", ArtCrop: "https://img/sol-art.jpg"},
+		},
+		"delver of secrets": {
+			Name: "Delver of Secrets // Insectile Aberration",
+			CardFaces: []scryfall.CardFace{
+				{Name: "Delver of Secrets", ImageURIs: scryfall.ImageURIs{PNG: "https://img/delver-front.png"}},
+				{Name: "Insectile Aberration", ImageURIs: scryfall.ImageURIs{PNG: "https://img/delver-back.png"}},
+			},
+		},
+		"blank card": {Name: "Blank Card"},
+	}
+	s := newTestMTGServer(t, collectionHandler(t, known, nil))
+
+	t.Run("decklist", func(t *testing.T) {
+		got, isErr := callTool(t, s.handleGetProxyImages, map[string]any{
+			"decklist": "2 Sol Ring\n1 Delver of Secrets\n1 Blank Card\n1 Not A Card",
+		})
+		if isErr {
+			t.Fatalf("handleGetProxyImages() returned error: %s", got)
+		}
+
+		var sheet proxySheetJSON
+		if err := json.Unmarshal([]byte(got), &sheet); err != nil {
+			t.Fatalf("handleGetProxyImages() returned invalid JSON: %v\n%s", err, got)
+		}
+
+		want := []proxyImageJSON{
+			{Name: "Sol Ring", Face: "Sol Ring", URL: "https://img/sol.png"},
+			{Name: "Sol Ring", Face: "Sol Ring", URL: "https://img/sol.png"},
+			{
+				Name: "Delver of Secrets // Insectile Aberration",
+				Face: "Delver of Secrets",
+				URL:  "https://img/delver-front.png",
+			},
+			{
+				Name: "Delver of Secrets // Insectile Aberration",
+				Face: "Insectile Aberration",
+				URL:  "https://img/delver-back.png",
+			},
+		}
+		if !slices.Equal(sheet.Images, want) {
+			t.Errorf("images = %+v, want %+v", sheet.Images, want)
+		}
+		if sheet.Size != "png" || sheet.TotalCards != 3 {
+			t.Errorf("size, total_cards = %q, %d, want png, 3", sheet.Size, sheet.TotalCards)
+		}
+		if !slices.Equal(sheet.NotFound, []string{"Not A Card"}) {
+			t.Errorf("not_found = %v, want [Not A Card]", sheet.NotFound)
+		}
+		if !slices.Equal(sheet.NoImage, []string{"Blank Card"}) {
+			t.Errorf("no_image = %v, want [Blank Card]", sheet.NoImage)
+		}
+	})
+
+	t.Run("art crop", func(t *testing.T) {
+		got, isErr := callTool(t, s.handleGetProxyImages, map[string]any{
+			"decklist": "Sol Ring",
+			"size":     "art_crop",
+		})
+		if isErr || !strings.Contains(got, "https://img/sol-art.jpg") {
+			t.Errorf("handleGetProxyImages() = %s, want the art crop URL", got)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantContains string
+	}{
+		{name: "no source", args: map[string]any{}, wantContains: "either decklist or deck_id"},
+		{
+			name:         "both sources",
+			args:         map[string]any{"decklist": "Sol Ring", "deck_id": "abc123"},
+			wantContains: "either decklist or deck_id",
+		},
+		{
+			name:         "invalid size",
+			args:         map[string]any{"decklist": "Sol Ring", "size": "large"},
+			wantContains: "Invalid size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetProxyImages, tt.args)
+			if !isErr || !strings.Contains(got, tt.wantContains) {
+				t.Errorf("handleGetProxyImages() = %s (isError %v), want error containing %q", got, isErr, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// legalityExplanation describes what a legality status means for deckbuilding.
+// "not_legal" is the one most often misread: it means the card was never part of
+// the format's card pool, not that it was banned.
+func legalityExplanation(legality scryfall.Legality) string {
+	switch legality {
+	case scryfall.LegalityLegal:
+		return "can be played"
+	case scryfall.LegalityBanned:
+		return "part of the card pool, but banned from play"
+	case scryfall.LegalityRestricted:
+		return "limited to one copy per deck"
+	case scryfall.LegalityNotLegal:
+		return "never printed in a set legal for this format; not a ban"
+	default:
+		return "unknown status"
+	}
+}
+
+// formatLegalityLine renders one format's legality with its explanation. Restricted
+// cards get a warning marker, since the one-copy limit is easy to overlook.
+func formatLegalityLine(format string, legality scryfall.Legality) string {
+	status := string(legality)
+	if legality == scryfall.LegalityRestricted {
+		status = "⚠️ " + status
+	}
+	return fmt.Sprintf("- %s: %s (%s)\n", format, status, legalityExplanation(legality))
+}
+
+// isGameChanger reports whether a card is on the Commander Game Changers list, using
+// the offline index in offline mode.
+func (s *MTGCommanderServer) isGameChanger(ctx context.Context, card scryfall.Card) (bool, error) {
+	if s.offlineIndex != nil {
+		indexed, ok := s.offlineIndex.get(card.Name)
+		if !ok {
+			return false, fmt.Errorf("%q is not in the offline card index", card.Name)
+		}
+		return indexed.GameChanger, nil
+	}
+	return fetchGameChanger(ctx, s.scryfallBaseURL, card.ID)
+}
+
+// fetchGameChanger reports whether a card is on the Commander Game Changers list.
+// go-scryfall doesn't decode Scryfall's game_changer field, so the card is
+// fetched directly.
+func fetchGameChanger(ctx context.Context, baseURL, cardID string) (bool, error) {
+	resp, err := HTTPGet(ctx, fmt.Sprintf("%s/cards/%s", baseURL, url.PathEscape(cardID)))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("scryfall API returned status %d", resp.StatusCode)
+	}
+
+	var card struct {
+		GameChanger bool `json:"game_changer"`
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&card); decodeErr != nil {
+		return false, fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+
+	return card.GameChanger, nil
+}
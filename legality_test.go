@@ -0,0 +1,120 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleCheckLegality(t *testing.T) {
+	// Trimmed Scryfall payloads keyed by card name, each with its game_changer flag
+	cards := map[string]string{
+		"Rhystic Study": `{"object": "card", "id": "d6914dba-0d27-4055-ac34-b3ebf5802221",
+			"name": "Rhystic Study", "game_changer": true,
+			"legalities": {"standard": "not_legal", "pioneer": "not_legal", "modern": "legal",
+				"legacy": "legal", "vintage": "legal", "pauper": "not_legal", "commander": "legal"}}`,
+		"Llanowar Elves": `{"object": "card", "id": "8bbcfb77-daa1-4ce5-b5f9-48d0a8edbba9",
+			"name": "Llanowar Elves", "game_changer": false,
+			"legalities": {"standard": "not_legal", "pioneer": "banned", "modern": "legal",
+				"legacy": "legal", "vintage": "legal", "pauper": "legal", "commander": "legal"}}`,
+		"Black Lotus": `{"object": "card", "id": "bd8fa327-dd41-4737-8f19-2cf5eb1f7cdd",
+			"name": "Black Lotus", "game_changer": false,
+			"legalities": {"standard": "not_legal", "pioneer": "not_legal", "modern": "not_legal",
+				"legacy": "banned", "vintage": "restricted", "pauper": "not_legal", "commander": "banned"}}`,
+		"Sol Ring": `{"object": "card", "id": "6ad8011d-3471-4369-9d68-b264cc027487",
+			"name": "Sol Ring", "game_changer": false,
+			"legalities": {"standard": "not_legal", "pioneer": "not_legal", "modern": "not_legal",
+				"legacy": "banned", "vintage": "restricted", "pauper": "not_legal", "commander": "legal"}}`,
+	}
+
+	byID := map[string]string{
+		"/cards/d6914dba-0d27-4055-ac34-b3ebf5802221": cards["Rhystic Study"],
+		"/cards/8bbcfb77-daa1-4ce5-b5f9-48d0a8edbba9": cards["Llanowar Elves"],
+		"/cards/bd8fa327-dd41-4737-8f19-2cf5eb1f7cdd": cards["Black Lotus"],
+		"/cards/6ad8011d-3471-4369-9d68-b264cc027487": cards["Sol Ring"],
+	}
+
+	var gameChangerLookups int
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		card, ok := cards[r.URL.Query().Get("fuzzy")]
+		if r.URL.Path != "/cards/named" {
+			card, ok = byID[r.URL.Path]
+			gameChangerLookups++
+		}
+		if !ok {
+			writeScryfallNotFound(w)
+			return
+		}
+		_, _ = w.Write([]byte(card))
+	}))
+
+	tests := []struct {
+		name         string
+		card         string
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name: "game changer",
+			card: "Rhystic Study",
+			wantContains: []string{
+				"**Game Changer:** This card is on the Commander Game Changers list",
+				"- Standard: not_legal (never printed in a set legal for this format; not a ban)",
+				"- Modern: legal (can be played)",
+			},
+		},
+		{
+			name: "banned is distinguished from not legal",
+			card: "Llanowar Elves",
+			wantContains: []string{
+				"**Game Changer:** No",
+				"- Pioneer: banned (part of the card pool, but banned from play)",
+				"- Standard: not_legal (never printed in a set legal for this format; not a ban)",
+			},
+		},
+		{
+			name: "restricted and banned in Commander",
+			card: "Black Lotus",
+			wantContains: []string{
+				"- Vintage: ⚠️ restricted (limited to one copy per deck)",
+				"- Commander: banned (part of the card pool, but banned from play)",
+			},
+			wantMissing: []string{"Game Changer"},
+		},
+		{
+			name: "restricted in Vintage only",
+			card: "Sol Ring",
+			wantContains: []string{
+				"✅ This card is **LEGAL** in Commander format.",
+				"- Vintage: ⚠️ restricted (limited to one copy per deck)",
+				"- Commander: legal (can be played)",
+			},
+			wantMissing: []string{"- Legacy: ⚠️", "- Commander: ⚠️"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleCheckLegality, map[string]any{"name": tt.card})
+			if isErr {
+				t.Fatalf("handleCheckLegality() returned error: %s", got)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleCheckLegality() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleCheckLegality() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	// Cards banned in Commander skip the Game Changer lookup
+	if gameChangerLookups != 3 {
+		t.Errorf("handleCheckLegality() made %d Game Changer lookups, want 3", gameChangerLookups)
+	}
+}
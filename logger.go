@@ -1,15 +1,132 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const (
+	// logFileEnvVar overrides the default log file path.
+	logFileEnvVar = "MTG_LOG_FILE"
+	// logFormatEnvVar overrides the default log format.
+	logFormatEnvVar = "MTG_LOG_FORMAT"
+	// logMaxSizeEnvVar overrides the size in megabytes at which the log file is rotated.
+	logMaxSizeEnvVar = "MTG_LOG_MAX_SIZE"
+	// logMaxBackupsEnvVar overrides how many rotated log files are kept.
+	logMaxBackupsEnvVar = "MTG_LOG_MAX_BACKUPS"
+	// logMaxAgeEnvVar overrides how many days rotated log files are kept.
+	logMaxAgeEnvVar = "MTG_LOG_MAX_AGE"
+	// defaultLogFileName is the log file name, placed in the user config directory by default.
+	defaultLogFileName = "mtg-commander-server.log"
+	// logDirName is the directory created under the user config directory for logs.
+	logDirName = "mtg-commander"
+
+	// defaultLogMaxSizeMB is the log file size that triggers a rotation.
+	defaultLogMaxSizeMB = 100
+	// defaultLogMaxBackups is how many rotated log files are kept.
+	defaultLogMaxBackups = 5
+	// defaultLogMaxAgeDays is how long rotated log files are kept.
+	defaultLogMaxAgeDays = 30
+
+	logFormatConsole = "console"
+	logFormatJSON    = "json"
+)
+
+// LoggerConfig configures where and how InitLogger writes logs.
+type LoggerConfig struct {
+	// FilePath is the log file to append to.
+	FilePath string
+	// Format is "console" for colored human-readable output or "json" for
+	// structured output. Empty means console.
+	Format string
+	// MaxSizeMB is the size in megabytes at which the log file is rotated.
+	MaxSizeMB int
+	// MaxBackups is how many rotated log files are kept. Zero keeps them all.
+	MaxBackups int
+	// MaxAgeDays is how many days rotated log files are kept. Zero keeps them regardless of age.
+	MaxAgeDays int
+}
+
+// logFormats returns the supported log formats.
+func logFormats() []string {
+	return []string{logFormatConsole, logFormatJSON}
+}
+
+// ResolveLoggerConfig builds the logger configuration from command-line flag
+// values, falling back to the MTG_LOG_* environment variables, then to defaults.
+// Empty or zero flag values count as unset.
+func ResolveLoggerConfig(flags LoggerConfig) (LoggerConfig, error) {
+	cfg := flags
+	if cfg.FilePath == "" {
+		cfg.FilePath = os.Getenv(logFileEnvVar)
+	}
+	if cfg.FilePath == "" {
+		cfg.FilePath = defaultLogFilePath()
+	}
+	if cfg.Format == "" {
+		cfg.Format = os.Getenv(logFormatEnvVar)
+	}
+	if cfg.Format == "" {
+		cfg.Format = logFormatConsole
+	}
+
+	rotation := []struct {
+		value    *int
+		envVar   string
+		fallback int
+	}{
+		{&cfg.MaxSizeMB, logMaxSizeEnvVar, defaultLogMaxSizeMB},
+		{&cfg.MaxBackups, logMaxBackupsEnvVar, defaultLogMaxBackups},
+		{&cfg.MaxAgeDays, logMaxAgeEnvVar, defaultLogMaxAgeDays},
+	}
+	for _, setting := range rotation {
+		if *setting.value != 0 {
+			continue
+		}
+		*setting.value = setting.fallback
+		if env := os.Getenv(setting.envVar); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil || n < 0 {
+				return LoggerConfig{}, fmt.Errorf("invalid %s %q (must be a non-negative integer)", setting.envVar, env)
+			}
+			*setting.value = n
+		}
+	}
+
+	return cfg, nil
+}
+
+// defaultLogFilePath returns the log file path under the user config directory
+// (e.g., ~/.config/mtg-commander on Linux), so logs don't depend on the working
+// directory the server was launched from. It falls back to the working directory
+// when the config directory is unavailable.
+func defaultLogFilePath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return defaultLogFileName
+	}
+
+	logDir := filepath.Join(configDir, logDirName)
+	if mkdirErr := os.MkdirAll(logDir, 0o700); mkdirErr != nil {
+		return defaultLogFileName
+	}
+	return filepath.Join(logDir, defaultLogFileName)
+}
+
 // loggerInstance wraps the zerolog logger for thread-safe access.
 type loggerInstance struct {
 	logger zerolog.Logger
+	// file is the rotating log file opened by InitLogger, closed by CloseLogger.
+	file *lumberjack.Logger
 }
 
 func (l *loggerInstance) get() *zerolog.Logger {
@@ -26,18 +143,48 @@ func newLoggerInstance() *loggerInstance {
 var loggerHolder = newLoggerInstance() //nolint:gochecknoglobals // logger needs to be accessible throughout the application
 
 // InitLogger initializes the global logger with both console and file output.
-func InitLogger(logFilePath string) error {
-	// Create log file
-	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+// Call CloseLogger before exiting to flush and close the log file.
+func InitLogger(cfg LoggerConfig) error {
+	format := cfg.Format
+	if format == "" {
+		format = logFormatConsole
+	}
+	if !slices.Contains(logFormats(), format) {
+		return fmt.Errorf("invalid log format %q (use one of: %s)", format, strings.Join(logFormats(), ", "))
+	}
+
+	// Create the log file up front so a bad path fails here rather than on the
+	// first write, which lumberjack would otherwise report only to the caller of Write
+	checkFile, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 	if err != nil {
 		return err
 	}
+	if closeErr := checkFile.Close(); closeErr != nil {
+		return closeErr
+	}
 
-	// Console writer with colors
-	consoleWriter := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: time.RFC3339,
-		NoColor:    false,
+	// Rotating file writer: rotates at MaxSizeMB and prunes old backups by count and age
+	logFile := &lumberjack.Logger{
+		Filename:   cfg.FilePath,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+	}
+
+	// Re-initializing replaces the previous log file
+	if closeErr := CloseLogger(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to close previous log file: %v\n", closeErr)
+	}
+	loggerHolder.file = logFile
+
+	// Console writer with colors, or raw JSON lines for structured log collection
+	var consoleWriter io.Writer = os.Stdout
+	if format == logFormatConsole {
+		consoleWriter = zerolog.ConsoleWriter{
+			Out:        os.Stdout,
+			TimeFormat: time.RFC3339,
+			NoColor:    false,
+		}
 	}
 
 	// Multi-writer: write to both console and file
@@ -56,6 +203,20 @@ func InitLogger(logFilePath string) error {
 	return nil
 }
 
+// CloseLogger closes the log file opened by InitLogger. Later log messages go
+// to stderr only. It is safe to call when no log file is open.
+func CloseLogger() error {
+	logFile := loggerHolder.file
+	if logFile == nil {
+		return nil
+	}
+
+	loggerHolder.logger = newLoggerInstance().logger
+	loggerHolder.file = nil
+
+	return logFile.Close()
+}
+
 // SetLogLevel sets the global log level.
 func SetLogLevel(level string) {
 	switch level {
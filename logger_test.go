@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -31,7 +32,7 @@ func TestInitLogger(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := InitLogger(tt.logFilePath)
+			err := InitLogger(LoggerConfig{FilePath: tt.logFilePath})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InitLogger() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -58,7 +59,7 @@ func TestInitLogger(t *testing.T) {
 func TestInitLogger_InvalidPath(t *testing.T) {
 	// Try to create a log file in a non-existent directory without creating it
 	invalidPath := "/nonexistent/directory/that/does/not/exist/test.log"
-	err := InitLogger(invalidPath)
+	err := InitLogger(LoggerConfig{FilePath: invalidPath})
 	if err == nil {
 		t.Error("InitLogger() expected error with invalid path, got nil")
 	}
@@ -68,7 +69,7 @@ func TestSetLogLevel(t *testing.T) {
 	// Initialize logger first with a temp file
 	tmpDir := t.TempDir()
 	logPath := filepath.Join(tmpDir, "test.log")
-	if err := InitLogger(logPath); err != nil {
+	if err := InitLogger(LoggerConfig{FilePath: logPath}); err != nil {
 		t.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -124,7 +125,7 @@ func TestGetLogger(t *testing.T) {
 	// Initialize logger first
 	tmpDir := t.TempDir()
 	logPath := filepath.Join(tmpDir, "test.log")
-	if err := InitLogger(logPath); err != nil {
+	if err := InitLogger(LoggerConfig{FilePath: logPath}); err != nil {
 		t.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -140,7 +141,7 @@ func TestGetLogger(t *testing.T) {
 func TestLogger_MultipleWrites(t *testing.T) {
 	tmpDir := t.TempDir()
 	logPath := filepath.Join(tmpDir, "test.log")
-	if err := InitLogger(logPath); err != nil {
+	if err := InitLogger(LoggerConfig{FilePath: logPath}); err != nil {
 		t.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -165,7 +166,7 @@ func TestLogger_MultipleWrites(t *testing.T) {
 func TestLogger_ConcurrentWrites(t *testing.T) {
 	tmpDir := t.TempDir()
 	logPath := filepath.Join(tmpDir, "concurrent.log")
-	if err := InitLogger(logPath); err != nil {
+	if err := InitLogger(LoggerConfig{FilePath: logPath}); err != nil {
 		t.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -193,3 +194,117 @@ func TestLogger_ConcurrentWrites(t *testing.T) {
 		t.Error("Log file is empty after concurrent writes")
 	}
 }
+
+func TestCloseLogger(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "close.log")
+	if err := InitLogger(LoggerConfig{FilePath: logPath}); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	GetLogger().Info().Msg("before close")
+
+	if err := CloseLogger(); err != nil {
+		t.Fatalf("CloseLogger() error = %v", err)
+	}
+
+	fileInfo, err := os.Stat(logPath)
+	if err != nil {
+		t.Fatalf("Failed to stat log file: %v", err)
+	}
+
+	// Logging after close must not write to (or fail on) the closed file
+	GetLogger().Info().Msg("after close")
+	afterInfo, err := os.Stat(logPath)
+	if err != nil {
+		t.Fatalf("Failed to stat log file: %v", err)
+	}
+	if afterInfo.Size() != fileInfo.Size() {
+		t.Errorf("Log file grew from %d to %d bytes after CloseLogger()", fileInfo.Size(), afterInfo.Size())
+	}
+
+	if err := CloseLogger(); err != nil {
+		t.Errorf("CloseLogger() twice error = %v, want nil", err)
+	}
+}
+
+func TestInitLogger_Formats(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := InitLogger(LoggerConfig{FilePath: filepath.Join(tmpDir, "json.log"), Format: "json"}); err != nil {
+		t.Errorf("InitLogger() json format error = %v", err)
+	}
+	_ = CloseLogger()
+
+	if err := InitLogger(LoggerConfig{FilePath: filepath.Join(tmpDir, "xml.log"), Format: "xml"}); err == nil {
+		t.Error("InitLogger() expected error with unknown format, got nil")
+	}
+}
+
+func TestResolveLoggerConfig(t *testing.T) {
+	t.Setenv(logFileEnvVar, "/tmp/from-env.log")
+	t.Setenv(logFormatEnvVar, "json")
+
+	t.Setenv(logMaxSizeEnvVar, "10")
+	t.Setenv(logMaxBackupsEnvVar, "2")
+	t.Setenv(logMaxAgeEnvVar, "7")
+
+	cfg, err := ResolveLoggerConfig(LoggerConfig{})
+	if err != nil {
+		t.Fatalf("ResolveLoggerConfig() error = %v", err)
+	}
+	want := LoggerConfig{FilePath: "/tmp/from-env.log", Format: "json", MaxSizeMB: 10, MaxBackups: 2, MaxAgeDays: 7}
+	if cfg != want {
+		t.Errorf("ResolveLoggerConfig() from env = %+v, want %+v", cfg, want)
+	}
+
+	flags := LoggerConfig{FilePath: "/tmp/from-flag.log", Format: "console", MaxSizeMB: 1, MaxBackups: 3, MaxAgeDays: 4}
+	cfg, err = ResolveLoggerConfig(flags)
+	if err != nil {
+		t.Fatalf("ResolveLoggerConfig() error = %v", err)
+	}
+	if cfg != flags {
+		t.Errorf("ResolveLoggerConfig() flags should override env, got %+v", cfg)
+	}
+
+	t.Setenv(logFileEnvVar, "")
+	t.Setenv(logFormatEnvVar, "")
+	t.Setenv(logMaxSizeEnvVar, "")
+	t.Setenv(logMaxBackupsEnvVar, "")
+	t.Setenv(logMaxAgeEnvVar, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err = ResolveLoggerConfig(LoggerConfig{})
+	if err != nil {
+		t.Fatalf("ResolveLoggerConfig() error = %v", err)
+	}
+	if filepath.Base(cfg.FilePath) != defaultLogFileName || cfg.Format != logFormatConsole ||
+		cfg.MaxSizeMB != defaultLogMaxSizeMB || cfg.MaxBackups != defaultLogMaxBackups ||
+		cfg.MaxAgeDays != defaultLogMaxAgeDays {
+		t.Errorf("ResolveLoggerConfig() defaults = %+v", cfg)
+	}
+
+	t.Setenv(logMaxBackupsEnvVar, "lots")
+	if _, err := ResolveLoggerConfig(LoggerConfig{}); err == nil {
+		t.Error("ResolveLoggerConfig() expected error for non-numeric rotation setting, got nil")
+	}
+}
+
+func TestInitLogger_Rotation(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "rotate.log")
+	if err := InitLogger(LoggerConfig{FilePath: logPath, MaxSizeMB: 1, MaxBackups: 1}); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer func() { _ = CloseLogger() }()
+
+	// Write a little over 1 MB so lumberjack rotates once
+	line := strings.Repeat("x", 1024)
+	for range 1100 {
+		GetLogger().Info().Msg(line)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(logPath), "rotate-*.log"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected a rotated backup log file, found none")
+	}
+}
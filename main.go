@@ -1,11 +1,20 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	scryfall "github.com/BlueMonday/go-scryfall"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,36 +22,92 @@ import (
 )
 
 const (
-	totalToolCount               = 11
-	totalResourceCount           = 2
+	totalToolCount               = 44
+	totalResourceCount           = 3
+	totalPromptCount             = 2
 	maxSearchLimit               = 50
-	defaultSplitLimit            = 2
 	maxPageSize                  = 100
 	deckValidationBasicCardCount = 99
 	deckValidationCommanderCount = 100
+	maxCommanders                = 2
+	fallbackUSDToBRLRate         = 5.40
 )
 
+// defaultScryfallBaseURL is the Scryfall API root used for endpoints go-scryfall doesn't cover.
+const defaultScryfallBaseURL = "https://api.scryfall.com"
+
 // MTGCommanderServer wraps the MCP server with MTG-specific functionality.
 type MTGCommanderServer struct {
-	scryfallClient *scryfall.Client
+	scryfallClient   *scryfall.Client
+	scryfallBaseURL  string
+	edhrecBaseURL    string
+	archidektBaseURL string
+	cardCache        *cardCache
+	// edhrecCache holds EDHREC commander recommendations between tool calls.
+	edhrecCache *edhrecCache
+	// offlineIndex serves card lookups from Scryfall bulk data in offline mode; nil when online.
+	offlineIndex *offlineIndex
+	// bannedList caches the Commander banned list for the tool and resource.
+	bannedList *bannedListCache
 }
 
 // NewMTGCommanderServer creates a new MTG Commander MCP server.
 func NewMTGCommanderServer() (*MTGCommanderServer, error) {
-	client, err := scryfall.NewClient()
+	// sharedHTTPClient throttles Scryfall for every caller, so go-scryfall's own
+	// per-client limiter would only double the wait
+	client, err := scryfall.NewClient(scryfall.WithHTTPClient(sharedHTTPClient), scryfall.WithLimiter(nil))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Scryfall client: %w", err)
 	}
 
-	return &MTGCommanderServer{
-		scryfallClient: client,
-	}, nil
+	s := &MTGCommanderServer{
+		scryfallClient:   client,
+		scryfallBaseURL:  defaultScryfallBaseURL,
+		edhrecBaseURL:    edhrecPagesURL,
+		archidektBaseURL: archidektAPIURL,
+		cardCache:        newCardCache(cardCacheTTLFromEnv(), defaultCardCacheMaxEntries),
+		edhrecCache:      newEDHRECCache(edhrecCacheTTLFromEnv(), defaultEDHRECCacheMaxEntries),
+	}
+	s.bannedList = newBannedListCache(bannedListRefreshFromEnv(), s.fetchBannedList)
+	return s, nil
 }
 
 func main() {
+	debug := flag.Bool("debug", false, "Enable debug logging")
+	logFileFlag := flag.String("log-file", "",
+		"Log file path (default: $"+logFileEnvVar+", or "+defaultLogFileName+" in the user config directory)")
+	logFormatFlag := flag.String("log-format", "",
+		"Log format: console or json (default: $"+logFormatEnvVar+", or console)")
+	logMaxSizeFlag := flag.Int("log-max-size", 0,
+		fmt.Sprintf("Log file size in MB that triggers rotation (default: $%s, or %d)",
+			logMaxSizeEnvVar, defaultLogMaxSizeMB))
+	logMaxBackupsFlag := flag.Int("log-max-backups", 0,
+		fmt.Sprintf("Rotated log files to keep (default: $%s, or %d)", logMaxBackupsEnvVar, defaultLogMaxBackups))
+	logMaxAgeFlag := flag.Int("log-max-age", 0,
+		fmt.Sprintf("Days to keep rotated log files (default: $%s, or %d)", logMaxAgeEnvVar, defaultLogMaxAgeDays))
+	offlineFlag := flag.Bool("offline", false,
+		"Serve card lookups from a local Scryfall bulk data index (default: $"+offlineEnvVar+")")
+	bulkDataFileFlag := flag.String("bulk-data-file", "",
+		"Scryfall bulk data file for offline mode (default: $"+bulkDataFileEnvVar+
+			", or "+defaultBulkDataFileName+" in the user cache directory)")
+	refreshBulkData := flag.Bool("refresh-bulk-data", false,
+		"Download fresh Scryfall bulk data for offline mode and exit")
+	flag.Parse()
+
 	// Initialize logger
-	logFilePath := "mtg-commander-server.log"
-	if err := InitLogger(logFilePath); err != nil {
+	logConfig, err := ResolveLoggerConfig(LoggerConfig{
+		FilePath:   *logFileFlag,
+		Format:     *logFormatFlag,
+		MaxSizeMB:  *logMaxSizeFlag,
+		MaxBackups: *logMaxBackupsFlag,
+		MaxAgeDays: *logMaxAgeFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid logging configuration: %v\n", err)
+		os.Exit(1)
+	}
+	logFilePath := logConfig.FilePath
+	if err := InitLogger(logConfig); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
@@ -51,11 +116,17 @@ func main() {
 	log.Info().Msg("Initializing MTG Commander MCP Server")
 
 	// Check for log level flag
-	if len(os.Args) > 1 && os.Args[1] == "--debug" {
+	if *debug {
 		SetLogLevel("debug")
 		log.Debug().Msg("Debug logging enabled")
 	}
 
+	httpTimeout := ConfigureHTTPTimeout()
+	log.Info().Dur("http_timeout", httpTimeout).Msg("HTTP timeout configured")
+
+	moxfieldRPS := ConfigureMoxfieldRateLimit()
+	log.Info().Float64("moxfield_rps", moxfieldRPS).Msg("Moxfield rate limit configured")
+
 	// Create MTG Commander server instance
 	log.Info().Msg("Creating MTG Commander server instance")
 	mtgServer, err := NewMTGCommanderServer()
@@ -64,11 +135,39 @@ func main() {
 	}
 	log.Info().Msg("MTG Commander server instance created successfully")
 
+	bulkDataPath := *bulkDataFileFlag
+	if bulkDataPath == "" {
+		bulkDataPath = defaultBulkDataPath()
+	}
+
+	if *refreshBulkData {
+		mtgServer.offlineIndex = &offlineIndex{}
+		if err := mtgServer.refreshOfflineIndex(context.Background(), bulkDataPath); err != nil {
+			log.Fatal().Err(err).Msg("Failed to refresh bulk data")
+		}
+		log.Info().Str("path", bulkDataPath).Int("indexed_names", mtgServer.offlineIndex.len()).
+			Msg("Bulk data refreshed")
+		fmt.Fprintf(os.Stderr, "Refreshed offline index at %s (%d card names)\n",
+			bulkDataPath, mtgServer.offlineIndex.len())
+		if closeErr := CloseLogger(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close log file: %v\n", closeErr)
+		}
+		return
+	}
+
+	if *offlineFlag || offlineModeFromEnv() {
+		if err := mtgServer.enableOfflineMode(context.Background(), bulkDataPath); err != nil {
+			log.Fatal().Err(err).Str("path", bulkDataPath).Msg("Failed to load offline index")
+		}
+		log.Info().Str("path", bulkDataPath).Int("indexed_names", mtgServer.offlineIndex.len()).
+			Msg("Offline mode enabled")
+	}
+
 	// Create MCP server
 	log.Info().Msg("Creating MCP server")
 	mcpServer := server.NewMCPServer(
 		"MTG Commander Assistant",
-		"1.0.0",
+		version,
 		server.WithRecovery(), // Add panic recovery middleware
 	)
 
@@ -82,14 +181,40 @@ func main() {
 	mtgServer.registerResources(mcpServer)
 	log.Info().Int("resource_count", totalResourceCount).Msg("All resources registered successfully")
 
+	// Register prompts
+	log.Info().Msg("Registering MCP prompts")
+	mtgServer.registerPrompts(mcpServer)
+	log.Info().Int("prompt_count", totalPromptCount).Msg("All prompts registered successfully")
+
+	// Cancel the root context on SIGINT/SIGTERM so in-flight requests stop and logs are flushed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	// Keep the cached banned list fresh and log ban announcements as they land
+	log.Info().Dur("interval", mtgServer.bannedList.interval).Msg("Starting banned list refresh")
+	go mtgServer.bannedList.run(ctx)
+
 	// Start server with stdio transport
 	log.Info().
-		Str("transport", "stdio").
+		Str("transport", serverTransport).
 		Str("log_file", logFilePath).
+		Str("log_format", logConfig.Format).
+		Int("log_max_size_mb", logConfig.MaxSizeMB).
 		Msg("Starting MTG Commander MCP Server")
 
-	if serveErr := server.ServeStdio(mcpServer); serveErr != nil {
-		log.Fatal().Err(serveErr).Msg("Server error")
+	serveErr := server.NewStdioServer(mcpServer).Listen(ctx, os.Stdin, os.Stdout)
+	stop()
+
+	failed := serveErr != nil && !errors.Is(serveErr, context.Canceled)
+	if failed {
+		log.Error().Err(serveErr).Msg("Server error")
+	}
+	log.Info().Msg("MTG Commander MCP Server stopped")
+
+	if closeErr := CloseLogger(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to close log file: %v\n", closeErr)
+	}
+	if failed {
+		os.Exit(1)
 	}
 }
 
@@ -106,7 +231,45 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 			mcp.Description("Search query (e.g., 'sol ring', 'c:blue type:creature', 'commander')"),
 		),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of results to return (default: 10, max: 50)"),
+			mcp.Description(fmt.Sprintf(
+				"Maximum number of results to return per page (default: 10, max: %d); larger limits fetch "+
+					"extra Scryfall pages as needed", maxSearchCardsLimit,
+			)),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("Page of results to return, 'limit' cards per page (default: 1)"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Sort order (default: name). Use 'edhrec' to sort by EDHREC popularity, "+
+				"'usd' by price, 'cmc' by mana value, or 'released' by release date"),
+			mcp.Enum(searchCardOrders()...),
+		),
+		mcp.WithString("dir",
+			mcp.Description("Sort direction: auto, asc, or desc (default: auto, Scryfall's natural direction for the order)"),
+			mcp.Enum(searchCardDirs()...),
+		),
+		mcp.WithString("mana_format",
+			mcp.Description("How to show mana costs: 'symbols' ({2}{W}{U}, default) or 'compact' (2WU)"),
+			mcp.Enum(manaFormats()...),
+		),
+		mcp.WithString("format",
+			mcp.Description(fmt.Sprintf(
+				"Output format: 'text' (markdown, default) or 'json' (typed JSON for programs); "+
+					"the default can be changed with %s", outputFormatEnvVar,
+			)),
+			mcp.Enum(outputFormats()...),
+		),
+		mcp.WithBoolean("identity",
+			mcp.Description(
+				"Treat color clauses as color identity, rewriting c: to id: so 'c:ub' finds cards playable "+
+					"in a Dimir Commander deck (default: false)",
+			),
+		),
+		mcp.WithBoolean("verbose",
+			mcp.Description(
+				"Also show each result's rarity (C/U/R/M), set release year, CMC, and color identity, "+
+					"to tell reprints from new cards at a glance (default: false)",
+			),
 		),
 	)
 	mcpServer.AddTool(searchCardsTool, s.handleSearchCards)
@@ -121,6 +284,17 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Exact or fuzzy card name (e.g., 'Lightning Bolt', 'Mana Crypt')"),
 		),
+		mcp.WithString("mana_format",
+			mcp.Description("How to show mana costs: 'symbols' ({2}{W}{U}, default) or 'compact' (2WU)"),
+			mcp.Enum(manaFormats()...),
+		),
+		mcp.WithString("format",
+			mcp.Description(fmt.Sprintf(
+				"Output format: 'text' (markdown, default) or 'json' (typed JSON for programs); "+
+					"the default can be changed with %s", outputFormatEnvVar,
+			)),
+			mcp.Enum(outputFormats()...),
+		),
 	)
 	mcpServer.AddTool(cardDetailsTool, s.handleGetCardDetails)
 
@@ -141,8 +315,10 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 	rulingsTool := mcp.NewTool("get_card_rulings",
 		mcp.WithDescription("Get official rulings and clarifications for a Magic: The Gathering card"),
 		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("Card name to get rulings for"),
+			mcp.Description("Card name to get rulings for (required unless scryfall_id is given)"),
+		),
+		mcp.WithString("scryfall_id",
+			mcp.Description("Scryfall card ID from a previous search result; skips the name lookup"),
 		),
 	)
 	mcpServer.AddTool(rulingsTool, s.handleGetRulings)
@@ -151,7 +327,7 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 	priceTool := mcp.NewTool(
 		"get_card_price",
 		mcp.WithDescription(
-			"Get current pricing for a Magic: The Gathering card in USD, EUR, and BRL (Brazilian Real via conversion)",
+			"Get current pricing for a Magic: The Gathering card in USD, EUR, and BRL, or other currencies via conversion",
 		),
 		mcp.WithString("name",
 			mcp.Required(),
@@ -160,6 +336,12 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 		mcp.WithString("set",
 			mcp.Description("Specific set code (optional, e.g., 'MH2', 'CMR')"),
 		),
+		mcp.WithString("currency",
+			mcp.Description(
+				"Comma-separated currency codes to show alongside USD (e.g., 'BRL,CAD,GBP'; default: 'EUR,BRL'). "+
+					"EUR uses Scryfall's native price; others are converted from USD",
+			),
+		),
 	)
 	mcpServer.AddTool(priceTool, s.handleGetPrice)
 
@@ -186,6 +368,15 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 				"Decklist as JSON array of card names or newline-separated card names with quantities (e.g., '1 Sol Ring')",
 			),
 		),
+		mcp.WithString("partner",
+			mcp.Description(
+				"Second commander for Partner, Friends forever, or 'Choose a Background' pairings (optional); "+
+					"the decklist should then have 98 cards",
+			),
+		),
+		mcp.WithString("companion",
+			mcp.Description("Companion card name (optional); it is not counted in the decklist"),
+		),
 	)
 	mcpServer.AddTool(validateDeckTool, s.handleValidateDeck)
 
@@ -199,6 +390,25 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Moxfield deck ID or full URL (e.g., 'abc123' or 'https://www.moxfield.com/decks/abc123')"),
 		),
+		mcp.WithString("version",
+			mcp.Description(
+				"Version ID from the deck's Moxfield change history, to fetch the deck as it was then "+
+					"(default: the latest version)",
+			),
+		),
+		mcp.WithBoolean("with_prices",
+			mcp.Description(
+				"Add an estimated USD deck price, price tier, and the 10 most expensive cards, "+
+					"looked up on Scryfall (default: false; slower)",
+			),
+		),
+		mcp.WithString("format",
+			mcp.Description(fmt.Sprintf(
+				"Output format: 'text' (markdown, default) or 'json' (typed JSON for programs); "+
+					"the default can be changed with %s", outputFormatEnvVar,
+			)),
+			mcp.Enum(outputFormats()...),
+		),
 	)
 	mcpServer.AddTool(moxfieldDeckTool, s.handleGetMoxfieldDeck)
 
@@ -219,23 +429,33 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 	searchMoxfieldDecksTool := mcp.NewTool(
 		"search_moxfield_decks",
 		mcp.WithDescription(
-			"Search for decks on Moxfield by commander name or other criteria, returns popular decks sorted by views/likes",
+			"Search for decks on Moxfield by text, commander, or hubs (tags), returns popular decks sorted by views/likes",
+		),
+		mcp.WithString("query",
+			mcp.Description("Text to search for, usually a commander name (e.g., 'Atraxa, Praetors Voice')"),
 		),
 		mcp.WithString("commander",
-			mcp.Required(),
-			mcp.Description("Commander card name to search for (e.g., 'Atraxa, Praetors Voice')"),
+			mcp.Description("Only return decks led by this commander (e.g., 'Kinnan, Bonder Prodigy')"),
+		),
+		mcp.WithString("hubs",
+			mcp.Description("Comma-separated Moxfield hubs (tags) decks must have (e.g., 'cEDH, Budget')"),
 		),
 		mcp.WithString("format",
 			mcp.Description("MTG format to filter by (default: 'commander')"),
 		),
 		mcp.WithString("sort_type",
-			mcp.Description("Sort type: 'updated', 'views', 'likes' (default: 'updated')"),
+			mcp.Description("Sort type: 'updated', 'created', 'views', 'likes', or 'name' (default: 'updated')"),
+			mcp.Enum(moxfieldSortTypes()...),
 		),
 		mcp.WithString("sort_direction",
 			mcp.Description("Sort direction: 'Ascending' or 'Descending' (default: 'Descending')"),
+			mcp.Enum(moxfieldSortDirections()...),
 		),
 		mcp.WithNumber("page_size",
-			mcp.Description("Number of decks to return (default: 20, max: 100)"),
+			mcp.Description("Number of decks to return per page (default: 20, max: 100)"),
+		),
+		mcp.WithNumber("page_number",
+			mcp.Description("Page of results to return, 'page_size' decks per page (default: 1)"),
 		),
 	)
 	mcpServer.AddTool(searchMoxfieldDecksTool, s.handleSearchMoxfieldDecks)
@@ -253,6 +473,22 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum cards to show per category (default: 10)"),
 		),
+		mcp.WithString("set",
+			mcp.Description("Only show cards printed in this set code (optional, e.g., 'MH3' for a new release)"),
+		),
+		mcp.WithBoolean("budget",
+			mcp.Description("Use EDHREC's budget page, favoring cheaper alternatives to expensive staples (default: false)"),
+		),
+		mcp.WithString("sort",
+			mcp.Description(
+				"Card order within each category: 'synergy_desc' (default), 'synergy_asc' to surface "+
+					"commonly cut / low synergy cards, or 'inclusion_desc' for the most played cards",
+			),
+			mcp.Enum(recsSortOrders()...),
+		),
+		mcp.WithBoolean("fresh",
+			mcp.Description("Refetch from EDHREC instead of using the cached page (cached for 12 hours; default: false)"),
+		),
 	)
 	mcpServer.AddTool(edhrecRecommendationsTool, s.handleGetEDHRECRecommendations)
 
@@ -271,781 +507,3470 @@ func (s *MTGCommanderServer) registerTools(mcpServer *server.MCPServer) {
 		),
 	)
 	mcpServer.AddTool(edhrecCombosTool, s.handleGetEDHRECCombos)
-}
 
-// registerResources registers MCP resources.
-func (s *MTGCommanderServer) registerResources(mcpServer *server.MCPServer) {
-	// Resource 1: Commander Rules
-	rulesResource := mcp.NewResource(
-		"commander://rules",
-		"Commander Format Rules",
-		mcp.WithResourceDescription("Official Commander format rules and deck construction guidelines"),
-		mcp.WithMIMEType("text/plain"),
+	// Tool 13: Similar Cards
+	similarCardsTool := mcp.NewTool(
+		"similar_cards",
+		mcp.WithDescription(
+			"Find functionally similar cards (e.g., alternatives to a board wipe) using EDHREC and Scryfall rules-text matching",
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Card name to find alternatives for (e.g., 'Wrath of God')"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum candidates to return (default: 10)"),
+		),
 	)
-	mcpServer.AddResource(rulesResource, s.handleCommanderRules)
+	mcpServer.AddTool(similarCardsTool, s.handleSimilarCards)
 
-	// Resource 2: Banned List Resource
-	bannedResource := mcp.NewResource(
-		"commander://banned-list",
-		"Commander Banned List",
-		mcp.WithResourceDescription("Current list of cards banned in Commander format"),
-		mcp.WithMIMEType("application/json"),
+	// Tool 14: Parse Decklist
+	parseDecklistTool := mcp.NewTool(
+		"parse_decklist",
+		mcp.WithDescription(
+			"Dry-run a decklist through the parser used by validate_deck, returning parsed entries and warnings",
+		),
+		mcp.WithString(
+			"decklist",
+			mcp.Required(),
+			mcp.Description(
+				"Decklist as JSON array of card names or newline-separated card names with quantities (e.g., '1 Sol Ring')",
+			),
+		),
 	)
-	mcpServer.AddResource(bannedResource, s.handleBannedListResource)
-}
-
-// Tool Handlers
-
-func (s *MTGCommanderServer) handleSearchCards(
-	ctx context.Context,
-	request mcp.CallToolRequest,
-) (*mcp.CallToolResult, error) {
-	query, err := request.RequireString("query")
-	if err != nil {
-		GetLogger().Error().Err(err).Str("tool", "search_cards").Msg("Missing required query parameter")
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	const defaultLimit = 10
-	limit := defaultLimit
-	args := request.GetArguments()
-	if limitVal, hasLimit := args["limit"]; hasLimit {
-		if limitFloat, ok := limitVal.(float64); ok {
-			limit = int(limitFloat)
-			if limit > maxSearchLimit {
-				limit = maxSearchLimit
-			}
-		}
-	}
+	mcpServer.AddTool(parseDecklistTool, s.handleParseDecklist)
 
-	GetLogger().Info().
-		Str("tool", "search_cards").
-		Str("query", query).
-		Int("limit", limit).
-		Msg("Searching for cards")
-
-	// Search cards using Scryfall
-	searchOpts := scryfall.SearchCardsOptions{
-		Unique: "cards",
-		Order:  "name",
-	}
+	// Tool 15: Average Deck Price
+	averageDeckPriceTool := mcp.NewTool(
+		"average_deck_price",
+		mcp.WithDescription(
+			"Estimate the typical cost to build a commander by pricing EDHREC's average deck in USD and BRL",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander card name (e.g., 'Atraxa, Praetors Voice')"),
+		),
+	)
+	mcpServer.AddTool(averageDeckPriceTool, s.handleAverageDeckPrice)
 
-	result, err := s.scryfallClient.SearchCards(ctx, query, searchOpts)
-	if err != nil {
-		GetLogger().Error().
-			Err(err).
-			Str("tool", "search_cards").
-			Str("query", query).
-			Msg("Scryfall search failed")
-		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
-	}
+	// Tool 16: Get Card By Set
+	cardBySetTool := mcp.NewTool(
+		"get_card_by_set",
+		mcp.WithDescription(
+			"Get the exact printing of a card by set code and collector number, including set icon and available finishes",
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Card name, used to confirm the printing (e.g., 'Sol Ring')"),
+		),
+		mcp.WithString("set",
+			mcp.Required(),
+			mcp.Description("Set code (e.g., 'C21', 'LEA')"),
+		),
+		mcp.WithString("collector_number",
+			mcp.Required(),
+			mcp.Description("Collector number within the set (e.g., '263')"),
+		),
+	)
+	mcpServer.AddTool(cardBySetTool, s.handleGetCardBySet)
 
-	if len(result.Cards) == 0 {
-		GetLogger().Info().
-			Str("tool", "search_cards").
-			Str("query", query).
-			Msg("No cards found")
-		return mcp.NewToolResultText("No cards found matching your query."), nil
-	}
+	// Tool 17: Get Random Card
+	randomCardTool := mcp.NewTool(
+		"get_random_card",
+		mcp.WithDescription(
+			"Get a random Magic: The Gathering card for inspiration, optionally restricted by a Scryfall search query",
+		),
+		mcp.WithString("query",
+			mcp.Description("Optional Scryfall query to draw from (e.g., 'is:commander', 'c:green type:creature')"),
+		),
+	)
+	mcpServer.AddTool(randomCardTool, s.handleGetRandomCard)
 
-	GetLogger().Info().
-		Str("tool", "search_cards").
-		Str("query", query).
-		Int("results_found", result.TotalCards).
-		Int("results_returned", len(result.Cards)).
-		Msg("Search completed successfully")
+	// Tool 18: Get Card Image
+	cardImageTool := mcp.NewTool(
+		"get_card_image",
+		mcp.WithDescription("Get the image of a Magic: The Gathering card, with one image per face for double-faced cards"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Card name (e.g., 'Delver of Secrets')"),
+		),
+		mcp.WithString("size",
+			mcp.Description("Image size: small, normal, large, png, or art_crop (default: normal)"),
+			mcp.Enum(cardImageSizes()...),
+		),
+	)
+	mcpServer.AddTool(cardImageTool, s.handleGetCardImage)
 
-	// Limit results
-	if len(result.Cards) > limit {
-		result.Cards = result.Cards[:limit]
-	}
+	// Tool 19: Get Card Printings
+	cardPrintingsTool := mcp.NewTool(
+		"get_card_printings",
+		mcp.WithDescription(
+			"List every printing of a card with set, collector number, rarity, USD price, and foil availability",
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Exact card name (e.g., 'Sol Ring')"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of printings to list, newest first (default: 25, max: 175)"),
+		),
+	)
+	mcpServer.AddTool(cardPrintingsTool, s.handleGetCardPrintings)
 
-	// Format results
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("Found %d cards (showing first %d):\n\n", result.TotalCards, len(result.Cards)))
+	// Tool 20: Compare Moxfield Decks
+	compareDecksTool := mcp.NewTool(
+		"compare_moxfield_decks",
+		mcp.WithDescription("Compare the mainboards of two Moxfield decks, listing cards unique to each and cards in common"),
+		mcp.WithString("deck_a",
+			mcp.Required(),
+			mcp.Description("First Moxfield deck ID or full URL"),
+		),
+		mcp.WithString("deck_b",
+			mcp.Required(),
+			mcp.Description("Second Moxfield deck ID or full URL"),
+		),
+	)
+	mcpServer.AddTool(compareDecksTool, s.handleCompareMoxfieldDecks)
 
-	for i, card := range result.Cards {
-		output.WriteString(fmt.Sprintf("%d. **%s** %s\n", i+1, card.Name, card.ManaCost))
-		output.WriteString(fmt.Sprintf("   Type: %s\n", card.TypeLine))
-		if card.OracleText != "" {
-			output.WriteString(fmt.Sprintf("   Text: %s\n", card.OracleText))
-		}
-		output.WriteString(fmt.Sprintf("   Set: %s (%s)\n", card.SetName, strings.ToUpper(card.Set)))
-		output.WriteString(fmt.Sprintf("   Commander Legal: %s\n\n", card.Legalities.Commander))
-	}
+	// Tool 21: Export Deck
+	exportDeckTool := mcp.NewTool(
+		"export_deck",
+		mcp.WithDescription(
+			"Export a Moxfield deck as a plain '<qty> <name>' decklist for importing into Archidekt, MTGO, or Arena",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Export format: 'text' for '<qty> <name>' lines or 'mtgo' for MTGO .dek XML (default: text)"),
+			mcp.Enum(deckExportFormats()...),
+		),
+	)
+	mcpServer.AddTool(exportDeckTool, s.handleExportDeck)
 
-	return mcp.NewToolResultText(output.String()), nil
-}
+	// Tool 22: Autocomplete Card Name
+	autocompleteTool := mcp.NewTool(
+		"autocomplete_card",
+		mcp.WithDescription(
+			"Suggest up to 20 full card names for a partial or misspelled name, "+
+				"useful for recovering from typos before looking a card up",
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Partial card name (e.g., 'tefer', 'sol ri')"),
+		),
+	)
+	mcpServer.AddTool(autocompleteTool, s.handleAutocompleteCard)
 
-func (s *MTGCommanderServer) handleGetCardDetails(
-	ctx context.Context,
-	request mcp.CallToolRequest,
-) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+	// Tool 23: Get Commander Themes
+	commanderThemesTool := mcp.NewTool(
+		"get_commander_themes",
+		mcp.WithDescription(
+			"Get the popular build themes and tribes for a commander from EDHREC (e.g., '+1/+1 Counters' for Atraxa) "+
+				"with how many decks play each",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander card name (e.g., 'Atraxa, Praetors Voice')"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum themes to show (default: 15)"),
+		),
+	)
+	mcpServer.AddTool(commanderThemesTool, s.handleGetCommanderThemes)
 
-	// Get card by name (fuzzy match)
-	card, err := s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
-	}
+	// Tool 24: Get Salt List
+	saltListTool := mcp.NewTool(
+		"get_salt_list",
+		mcp.WithDescription(
+			"Get EDHREC's salt list, the cards Commander players most dislike playing against, with salt scores. "+
+				"Useful for building a friendly deck for casual pods",
+		),
+		mcp.WithNumber("page",
+			mcp.Description("Page of the salt list to fetch (default: 1)"),
+		),
+	)
+	mcpServer.AddTool(saltListTool, s.handleGetSaltList)
 
-	// Format card details
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# %s %s\n\n", card.Name, card.ManaCost))
-	output.WriteString(fmt.Sprintf("**Type:** %s\n", card.TypeLine))
-	output.WriteString(
-		fmt.Sprintf("**Set:** %s (%s) #%s\n", card.SetName, strings.ToUpper(card.Set), card.CollectorNumber),
+	// Tool 25: Get Commander Combos
+	commanderCombosTool := mcp.NewTool(
+		"get_commander_combos",
+		mcp.WithDescription(
+			"Get the combos played in a specific commander's decks from the commander's EDHREC page, "+
+				"rather than every combo in its colors",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander card name (e.g., 'Atraxa, Praetors Voice')"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum combos to show (default: 10)"),
+		),
 	)
-	output.WriteString(fmt.Sprintf("**Rarity:** %s\n\n", card.Rarity))
+	mcpServer.AddTool(commanderCombosTool, s.handleGetCommanderCombos)
 
-	if card.OracleText != "" {
-		output.WriteString(fmt.Sprintf("**Oracle Text:**\n%s\n\n", card.OracleText))
+	// Tool 26: Get Deck Stats
+	deckStatsTool := mcp.NewTool(
+		"get_deck_stats",
+		mcp.WithDescription(
+			"Summarize a Moxfield deck without the full list: total cards, lands, average nonland CMC, "+
+				"card type counts, and color identity",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+	)
+	mcpServer.AddTool(deckStatsTool, s.handleGetDeckStats)
+
+	// Tool 27: Validate Moxfield Deck
+	validateMoxfieldDeckTool := mcp.NewTool(
+		"validate_moxfield_deck",
+		mcp.WithDescription(
+			"Validate a Moxfield deck for Commander legality in one step, detecting its commander(s) and companion "+
+				"from the deck (deck size, singleton including quantities, color identity, banned cards)",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+		mcp.WithBoolean("allow_noncommander",
+			mcp.Description("Validate with Commander rules even if the deck's Moxfield format isn't Commander "+
+				"(e.g., brawl or oathbreaker); a warning is included in the report (default: false)"),
+		),
+	)
+	mcpServer.AddTool(validateMoxfieldDeckTool, s.handleValidateMoxfieldDeck)
+
+	// Tool 28: Get Set
+	getSetTool := mcp.NewTool(
+		"get_set",
+		mcp.WithDescription(
+			"Get MTG set metadata (full name, release date, card count, set type, icon) by set code, "+
+				"or search sets by name",
+		),
+		mcp.WithString("code",
+			mcp.Description("Set code (e.g., 'MH3', 'cmm')"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Full or partial set name to search for (e.g., 'Modern Horizons'); used when code is empty"),
+		),
+	)
+	mcpServer.AddTool(getSetTool, s.handleGetSet)
+
+	// Tool 29: Get Cards Batch
+	cardsBatchTool := mcp.NewTool(
+		"get_cards_batch",
+		mcp.WithDescription(
+			"Look up many cards at once and get a one-line summary of each (mana cost, type, Commander legality, "+
+				"price), plus the names that weren't found. Much faster than calling get_card_details per card",
+		),
+		mcp.WithString("cards",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf(
+				"Card names as a JSON array or one per line; quantities like '1x' are ignored (max %d distinct cards)",
+				maxBatchCards,
+			)),
+		),
+	)
+	mcpServer.AddTool(cardsBatchTool, s.handleGetCardsBatch)
+
+	// Tool 30: Find Commanders
+	findCommandersTool := mcp.NewTool(
+		"find_commanders",
+		mcp.WithDescription(
+			"Find Commander-legal commanders for a color identity (e.g., 'what commanders can I build in Jeskai?'), "+
+				"most popular on EDHREC first",
+		),
+		mcp.WithString("colors",
+			mcp.Required(),
+			mcp.Description("Color identity as WUBRG letters (e.g., 'wur') or a name (e.g., 'Jeskai', 'Golgari')"),
+		),
+		mcp.WithBoolean("exact",
+			mcp.Description(
+				"Only commanders with exactly these colors; otherwise any commander within them (default: false)",
+			),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum commanders to show (default: %d, max: %d)",
+				defaultCommanderLimit, maxSearchLimit)),
+		),
+	)
+	mcpServer.AddTool(findCommandersTool, s.handleFindCommanders)
+
+	// Tool 31: Estimate Power Level
+	powerLevelTool := mcp.NewTool(
+		"estimate_power_level",
+		mcp.WithDescription(
+			"Estimate a Moxfield Commander deck's power level (1-10) from fast mana, tutors, free counterspells, "+
+				"complete EDHREC combos, and average CMC, listing every contributing factor",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+	)
+	mcpServer.AddTool(powerLevelTool, s.handleEstimatePowerLevel)
+
+	// Tool 32: Get Token
+	tokenTool := mcp.NewTool(
+		"get_token",
+		mcp.WithDescription(
+			"Look up token cards (e.g., 'Treasure', '1/1 white Soldier') with their colors, power/toughness, "+
+				"rules text, and the cards that create them",
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description(
+				"Token name or description; P/T like '1/1' and color words like 'white' become filters, "+
+					"and Scryfall syntax is passed through",
+			),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum tokens to show (default: %d, max: %d)", defaultTokenLimit, maxSearchLimit)),
+		),
+	)
+	mcpServer.AddTool(tokenTool, s.handleGetToken)
+
+	// Tool 33: Get Deck Missing Staples
+	missingStaplesTool := mcp.NewTool(
+		"get_deck_missing_staples",
+		mcp.WithDescription(
+			"Compare a Moxfield Commander deck against EDHREC's high synergy and top cards for its commander, "+
+				"listing the most played recommendations the deck isn't running with their inclusion percentages",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum missing cards to show (default: %d, max: %d)",
+				defaultMissingStaplesLimit, maxSearchLimit)),
+		),
+	)
+	mcpServer.AddTool(missingStaplesTool, s.handleGetDeckMissingStaples)
+
+	// Tool 34: Get Average Deck
+	averageDeckTool := mcp.NewTool(
+		"get_average_deck",
+		mcp.WithDescription(
+			"Get EDHREC's average deck for a commander as an importable '<qty> <name>' decklist, "+
+				"a quick starting point for a new Commander deck",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander name (e.g., 'Atraxa, Praetors' Voice')"),
+		),
+	)
+	mcpServer.AddTool(averageDeckTool, s.handleGetAverageDeck)
+
+	// Tool 35: Server Info
+	serverInfoTool := mcp.NewTool(
+		"server_info",
+		mcp.WithDescription(
+			"Diagnostics: the server's version, build commit, and transport, and whether Scryfall, Moxfield, "+
+				"and EDHREC are reachable, with the latency of each",
+		),
+	)
+	mcpServer.AddTool(serverInfoTool, s.handleServerInfo)
+
+	// Tool 36: Get Archidekt Deck
+	archidektDeckTool := mcp.NewTool(
+		"get_archidekt_deck",
+		mcp.WithDescription(
+			"Fetch a deck from Archidekt by URL or deck ID, with the same card type breakdown, mana curve, "+
+				"and color requirements as get_moxfield_deck",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Archidekt deck ID or URL (e.g., 'https://archidekt.com/decks/123456/my-deck' or '123456')"),
+		),
+	)
+	mcpServer.AddTool(archidektDeckTool, s.handleGetArchidektDeck)
+
+	// Tool 37: Get Deck Curve Chart
+	deckCurveChartTool := mcp.NewTool(
+		"get_deck_curve_chart",
+		mcp.WithDescription(
+			"Draw a Moxfield deck's mana curve as a Unicode bar chart for monospace display, splitting each "+
+				"mana value into creatures and noncreatures, to see at a glance whether the deck is top-heavy",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+	)
+	mcpServer.AddTool(deckCurveChartTool, s.handleGetDeckCurveChart)
+
+	// Tool 38: Suggest Cuts
+	suggestCutsTool := mcp.NewTool(
+		"suggest_cuts",
+		mcp.WithDescription(
+			"Suggest cards to cut from a Moxfield Commander deck to get it down to 100 cards (advisory only): "+
+				"expensive cards EDHREC doesn't recommend for the commander and the lowest synergy ones, "+
+				"falling back to mana value and card type when EDHREC has no data",
+		),
+		mcp.WithString("deck_id",
+			mcp.Required(),
+			mcp.Description("Moxfield deck ID or full URL"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf(
+				"Number of cuts to suggest (default: the number of cards over 100, or %d; max: %d)",
+				defaultSuggestedCuts, maxSearchLimit,
+			)),
+		),
+	)
+	mcpServer.AddTool(suggestCutsTool, s.handleSuggestCuts)
+
+	// Tool 39: Validate Deck Format
+	validateDeckFormatTool := mcp.NewTool(
+		"validate_deck_format",
+		mcp.WithDescription(
+			"Validate a decklist for a format: Commander (same checks as validate_deck) or a 60-card format "+
+				"(at least 60 cards, up to 15 sideboard cards, at most 4 copies of each card, every card legal)",
+		),
+		mcp.WithString("format",
+			mcp.Required(),
+			mcp.Description("Format to validate for"),
+			mcp.Enum(validationFormats()...),
+		),
+		mcp.WithString("decklist",
+			mcp.Required(),
+			mcp.Description(
+				"Main deck as JSON array of card names or newline-separated card names with quantities "+
+					"(e.g., '4 Lightning Bolt')",
+			),
+		),
+		mcp.WithString("sideboard",
+			mcp.Description("Sideboard in the same form as decklist (optional; not allowed for Commander)"),
+		),
+		mcp.WithString("commander",
+			mcp.Description("Commander card name (required for Commander)"),
+		),
+		mcp.WithString("partner",
+			mcp.Description("Second commander for Commander pairings (optional)"),
+		),
+		mcp.WithString("companion",
+			mcp.Description("Companion card name for Commander (optional)"),
+		),
+	)
+	mcpServer.AddTool(validateDeckFormatTool, s.handleValidateDeckFormat)
+
+	// Tool 40: Get Proxy Images
+	proxyImagesTool := mcp.NewTool(
+		"get_proxy_images",
+		mcp.WithDescription(
+			"List a printable image URL for every copy of every card in a decklist or Moxfield deck, as JSON "+
+				"for laying out playtest proxies; double-faced cards get one image per face and unresolved "+
+				"names are listed separately",
+		),
+		mcp.WithString("decklist",
+			mcp.Description(
+				"Decklist as JSON array of card names or newline-separated card names with quantities "+
+					"(e.g., '4 Lightning Bolt'); give either this or deck_id",
+			),
+		),
+		mcp.WithString("deck_id",
+			mcp.Description("Moxfield deck ID or full URL; its commanders and mainboard are included"),
+		),
+		mcp.WithString("size",
+			mcp.Description("Image size: png (full card, default) or art_crop"),
+			mcp.Enum(proxyImageSizes()...),
+		),
+	)
+	mcpServer.AddTool(proxyImagesTool, s.handleGetProxyImages)
+
+	// Tool 41: Get Commander Staples
+	commanderStaplesTool := mcp.NewTool(
+		"get_commander_staples",
+		mcp.WithDescription(
+			"Get a single category of EDHREC recommendations for a commander (e.g. just the ramp package), "+
+				"instead of every category at once",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander card name (e.g., 'Atraxa, Praetors Voice')"),
+		),
+		mcp.WithString("category",
+			mcp.Required(),
+			mcp.Description(
+				"EDHREC category tag, e.g. ramp, carddraw, removal, boardwipes, creatures, or highsynergycards "+
+					"(case-insensitive; an unknown category lists the commander's available ones)",
+			),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum cards to show (default: %d)", defaultCommanderStaplesLimit)),
+		),
+		mcp.WithBoolean("budget",
+			mcp.Description("Use EDHREC's budget page, favoring cheaper alternatives to expensive staples (default: false)"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Card order: 'synergy_desc' (default), 'synergy_asc', or 'inclusion_desc'"),
+			mcp.Enum(recsSortOrders()...),
+		),
+	)
+	mcpServer.AddTool(commanderStaplesTool, s.handleGetCommanderStaples)
+
+	// Tool 42: Draw Probability
+	drawProbabilityTool := mcp.NewTool(
+		"draw_probability",
+		mcp.WithDescription(
+			"Calculate the hypergeometric chance of drawing at least one copy of a card (or one card of a "+
+				"category) by a given point in the game, or the chance a Moxfield deck opens with a turn-1 mana rock",
+		),
+		mcp.WithNumber("copies",
+			mcp.Description("Copies of the card, or cards in the category, in the deck (required without deck_id)"),
+		),
+		mcp.WithNumber("deck_size",
+			mcp.Description(fmt.Sprintf("Cards in the library (default: %d, a Commander deck minus its commander)",
+				defaultProbabilityDeckSize)),
+		),
+		mcp.WithNumber("drawn",
+			mcp.Description(fmt.Sprintf("Cards drawn, counting the opening hand (default: %d)", openingHandSize)),
+		),
+		mcp.WithNumber("turn",
+			mcp.Description("Instead of drawn: cards seen by this turn on the draw, i.e. the opening hand plus one per turn"),
+		),
+		mcp.WithString("deck_id",
+			mcp.Description(
+				"Moxfield deck ID or full URL; counts its mana rocks under 2 mana value and uses its mainboard "+
+					"size, for the chance of a turn-1 ramp piece (replaces copies and deck_size)",
+			),
+		),
+	)
+	mcpServer.AddTool(drawProbabilityTool, s.handleDrawProbability)
+
+	// Tool 43: Explain Commander
+	explainCommanderTool := mcp.NewTool(
+		"explain_commander",
+		mcp.WithDescription(
+			"One-stop overview of a commander: its abilities line by line, its official rulings, and "+
+				"EDHREC's most popular themes for it",
+		),
+		mcp.WithString("commander",
+			mcp.Required(),
+			mcp.Description("Commander card name (e.g., 'Atraxa, Praetors Voice')"),
+		),
+	)
+	mcpServer.AddTool(explainCommanderTool, s.handleExplainCommander)
+
+	// Tool 44: Get Card Price History
+	priceHistoryTool := mcp.NewTool(
+		"get_card_price_history",
+		mcp.WithDescription(
+			"Compare a card's current USD prices across all its printings: the min, median, and max price "+
+				"and every printing cheapest first. Scryfall keeps no price history, so this shows whether "+
+				"a cheaper printing exists rather than a trend",
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Exact card name (e.g., 'Sol Ring')"),
+		),
+	)
+	mcpServer.AddTool(priceHistoryTool, s.handleGetCardPriceHistory)
+}
+
+// registerResources registers MCP resources.
+func (s *MTGCommanderServer) registerResources(mcpServer *server.MCPServer) {
+	// Resource 1: Commander Rules
+	rulesResource := mcp.NewResource(
+		"commander://rules",
+		"Commander Format Rules",
+		mcp.WithResourceDescription("Official Commander format rules and deck construction guidelines"),
+		mcp.WithMIMEType("text/plain"),
+	)
+	mcpServer.AddResource(rulesResource, s.handleCommanderRules)
+
+	// Resource 2: Banned List Resource
+	bannedResource := mcp.NewResource(
+		"commander://banned-list",
+		"Commander Banned List",
+		mcp.WithResourceDescription("Current list of cards banned in Commander format"),
+		mcp.WithMIMEType("application/json"),
+	)
+	mcpServer.AddResource(bannedResource, s.handleBannedListResource)
+
+	// Resource 3: EDHREC Commander Recommendations (template)
+	edhrecCommanderTemplate := mcp.NewResourceTemplate(
+		"edhrec://commander/{name}",
+		"EDHREC Commander Recommendations",
+		mcp.WithTemplateDescription(
+			"EDHREC recommendation data for a commander as JSON: deck count and card lists with "+
+				"synergy and inclusion. {name} is the commander's name, e.g. edhrec://commander/atraxa-praetors-voice",
+		),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+	mcpServer.AddResourceTemplate(edhrecCommanderTemplate, s.handleEDHRECCommanderResource)
+}
+
+// registerPrompts registers MCP prompts.
+func (s *MTGCommanderServer) registerPrompts(mcpServer *server.MCPServer) {
+	// Prompt 1: Build Commander Deck
+	buildDeckPrompt := mcp.NewPrompt(
+		"build_commander_deck",
+		mcp.WithPromptDescription(
+			"Guided Commander deckbuilding: pulls EDHREC recommendations for a commander, "+
+				"checks legality, and prices the picks",
+		),
+		mcp.WithArgument("commander",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Commander name (e.g., 'Atraxa, Praetors' Voice')"),
+		),
+	)
+	mcpServer.AddPrompt(buildDeckPrompt, s.handleBuildCommanderDeckPrompt)
+
+	// Prompt 2: Analyze Moxfield Deck
+	analyzeDeckPrompt := mcp.NewPrompt(
+		"analyze_moxfield_deck",
+		mcp.WithPromptDescription(
+			"Review an existing Moxfield deck: stats, legality problems, and EDHREC-backed cut suggestions",
+		),
+		mcp.WithArgument("deck_url",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Moxfield deck URL or ID (e.g., 'https://www.moxfield.com/decks/abc123')"),
+		),
+	)
+	mcpServer.AddPrompt(analyzeDeckPrompt, s.handleAnalyzeMoxfieldDeckPrompt)
+}
+
+// Tool Handlers
+
+func (s *MTGCommanderServer) handleSearchCards(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "search_cards").Msg("Missing required query parameter")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	const defaultLimit = 10
+	limit := defaultLimit
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = min(int(limitFloat), maxSearchCardsLimit)
+		}
+	}
+
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
+	}
+
+	page := 1
+	if pageVal, ok := args["page"].(float64); ok {
+		if pageVal < 1 {
+			return mcp.NewToolResultError("page must be 1 or greater"), nil
+		}
+		page = int(pageVal)
+	}
+
+	order := string(scryfall.OrderName)
+	if orderVal, ok := args["order"].(string); ok && orderVal != "" {
+		order = strings.ToLower(orderVal)
+	}
+	if !slices.Contains(searchCardOrders(), order) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid order %q (use one of: %s)", order, strings.Join(searchCardOrders(), ", "),
+		)), nil
+	}
+
+	dir := string(scryfall.DirAuto)
+	if dirVal, ok := args["dir"].(string); ok && dirVal != "" {
+		dir = strings.ToLower(dirVal)
+	}
+	if !slices.Contains(searchCardDirs(), dir) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid dir %q (use one of: %s)", dir, strings.Join(searchCardDirs(), ", "),
+		)), nil
+	}
+
+	manaFormat, err := manaFormatArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	outputFormat, err := outputFormatArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	verbose, _ := args["verbose"].(bool)
+
+	var identityNote string
+	if identity, _ := args["identity"].(bool); identity {
+		if rewritten, changed := colorClausesToIdentity(query); changed {
+			identityNote = fmt.Sprintf(
+				"Note: searched color identity instead of color, as `%s` (id: matches cards whose color "+
+					"identity fits within the given colors).\n\n", rewritten,
+			)
+			query = rewritten
+		}
+	}
+
+	GetLogger().Info().
+		Str("tool", "search_cards").
+		Str("query", query).
+		Int("limit", limit).
+		Int("page", page).
+		Str("order", order).
+		Str("dir", dir).
+		Msg("Searching for cards")
+
+	// Search cards using Scryfall
+	searchOpts := scryfall.SearchCardsOptions{
+		Unique: "cards",
+		Order:  scryfall.Order(order),
+		Dir:    scryfall.Dir(dir),
+	}
+
+	offset := (page - 1) * limit
+	cards, totalCards, err := s.searchCardsWindow(ctx, query, searchOpts, offset, limit)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "search_cards").
+			Str("query", query).
+			Int("page", page).
+			Msg("Scryfall search failed")
+		if message, ok := scryfallQueryError(query, err); ok {
+			return mcp.NewToolResultError(message), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if outputFormat == outputFormatJSON {
+		result := cardSearchJSON{
+			Query:      query,
+			Page:       page,
+			TotalCards: totalCards,
+			HasMore:    offset+len(cards) < totalCards,
+			Cards:      make([]cardJSON, len(cards)),
+		}
+		for i, card := range cards {
+			result.Cards[i] = newCardJSON(card)
+		}
+		return jsonToolResult(result), nil
+	}
+
+	if len(cards) == 0 {
+		GetLogger().Info().
+			Str("tool", "search_cards").
+			Str("query", query).
+			Int("page", page).
+			Msg("No cards found")
+		if totalCards > 0 {
+			return mcp.NewToolResultText(identityNote + fmt.Sprintf(
+				"Page %d is past the end of the results (%d cards found).", page, totalCards,
+			)), nil
+		}
+		return mcp.NewToolResultText(identityNote + "No cards found matching your query."), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "search_cards").
+		Str("query", query).
+		Int("results_found", totalCards).
+		Int("results_returned", len(cards)).
+		Msg("Search completed successfully")
+
+	// Format results
+	var output strings.Builder
+	output.WriteString(identityNote)
+	output.WriteString(fmt.Sprintf("Page %d, showing %d of %d cards:\n\n", page, len(cards), totalCards))
+
+	for i, card := range cards {
+		output.WriteString(
+			fmt.Sprintf("%d. **%s** %s\n", offset+i+1, card.Name, FormatManaCost(card.ManaCost, manaFormat)),
+		)
+		output.WriteString(fmt.Sprintf("   Type: %s\n", card.TypeLine))
+		if card.OracleText != "" {
+			output.WriteString(fmt.Sprintf("   Text: %s\n", card.OracleText))
+		}
+		if verbose {
+			details := rarityCode(card.Rarity)
+			if !card.ReleasedAt.IsZero() {
+				details += fmt.Sprintf(", %d", card.ReleasedAt.Year())
+			}
+			output.WriteString(fmt.Sprintf("   Set: %s (%s) [%s]\n", card.SetName, strings.ToUpper(card.Set), details))
+			output.WriteString(fmt.Sprintf("   CMC: %g | Color Identity: %s\n",
+				card.CMC, formatColors(card.ColorIdentity)))
+		} else {
+			output.WriteString(fmt.Sprintf("   Set: %s (%s)\n", card.SetName, strings.ToUpper(card.Set)))
+		}
+		output.WriteString(fmt.Sprintf("   Commander Legal: %s\n", card.Legalities.Commander))
+		output.WriteString(fmt.Sprintf("   Scryfall ID: %s\n\n", card.ID))
+	}
+
+	output.WriteString(fmt.Sprintf("Returned %d of %d matching cards (results %d-%d).\n",
+		len(cards), totalCards, offset+1, offset+len(cards)))
+	if offset+len(cards) < totalCards {
+		output.WriteString(fmt.Sprintf("More results available: use page %d to continue.\n", page+1))
+	}
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetCardDetails(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	manaFormat, err := manaFormatArg(request.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	outputFormat, err := outputFormatArg(request.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get card by name (fuzzy match)
+	card, err := s.cachedGetCardByName(ctx, name)
+	if err != nil {
+		return s.cardNotFoundResult(ctx, name, err), nil
+	}
+
+	if outputFormat == outputFormatJSON {
+		return jsonToolResult(newCardJSON(card)), nil
+	}
+	return mcp.NewToolResultText(FormatCardDetailsForDisplay(card, manaFormat)), nil
+}
+
+func (s *MTGCommanderServer) handleGetCardBySet(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	setCode, err := request.RequireString("set")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	collectorNumber, err := request.RequireString("collector_number")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	setCode = strings.ToLower(strings.TrimSpace(setCode))
+	collectorNumber = strings.TrimSpace(collectorNumber)
+
+	card, err := s.scryfallClient.GetCardBySetCodeAndCollectorNumber(ctx, setCode, collectorNumber)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_card_by_set").
+			Str("set", setCode).
+			Str("collector_number", collectorNumber).
+			Msg("Printing not found")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"No card found in set %s with collector number %s: %v", strings.ToUpper(setCode), collectorNumber, err,
+		)), nil
+	}
+
+	if !cardNameMatches(card, name) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Set %s collector number %s is %s, not %s",
+			strings.ToUpper(setCode), collectorNumber, card.Name, name,
+		)), nil
+	}
+
+	// The set icon is informational; show the card even if the set lookup fails.
+	var set *scryfall.Set
+	if fetched, setErr := s.scryfallClient.GetSet(ctx, card.Set); setErr == nil {
+		set = &fetched
+	} else {
+		GetLogger().Warn().Err(setErr).Str("set", card.Set).Msg("Failed to fetch set metadata")
+	}
+
+	output := FormatCardDetailsForDisplay(card, manaFormatSymbols) + FormatPrintingDetailsForDisplay(card, set)
+	return mcp.NewToolResultText(output), nil
+}
+
+func (s *MTGCommanderServer) handleGetRandomCard(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	query := ""
+	args := request.GetArguments()
+	if queryVal, hasQuery := args["query"]; hasQuery {
+		if q, ok := queryVal.(string); ok {
+			query = strings.TrimSpace(q)
+		}
+	}
+
+	var card scryfall.Card
+	var err error
+	if query != "" {
+		card, err = getRandomCardWithQuery(ctx, s.scryfallBaseURL, query)
+	} else {
+		card, err = s.scryfallClient.GetRandomCard(ctx)
+	}
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_random_card").Str("query", query).Msg("Random card lookup failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get random card: %v", err)), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "get_random_card").
+		Str("query", query).
+		Str("card", card.Name).
+		Msg("Fetched random card")
+
+	return mcp.NewToolResultText(FormatCardDetailsForDisplay(card, manaFormatSymbols)), nil
+}
+
+func (s *MTGCommanderServer) handleGetCardImage(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	size := defaultCardImageSize
+	args := request.GetArguments()
+	if sizeVal, hasSize := args["size"]; hasSize {
+		if sz, ok := sizeVal.(string); ok && sz != "" {
+			size = strings.ToLower(sz)
+		}
+	}
+	if !slices.Contains(cardImageSizes(), size) {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("Invalid size %q (use one of: %s)", size, strings.Join(cardImageSizes(), ", ")),
+		), nil
+	}
+
+	card, err := s.cachedGetCardByName(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
+	}
+
+	images := cardImageURLs(card, size)
+	if len(images) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No %s image is available for %s.", size, card.Name)), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "get_card_image").
+		Str("card", card.Name).
+		Str("size", size).
+		Int("faces", len(images)).
+		Msg("Fetched card image URIs")
+
+	// Always include the URLs; inline the images too when they can be downloaded
+	result := mcp.NewToolResultText(FormatCardImagesForDisplay(card.Name, size, images))
+	for _, image := range images {
+		content, fetchErr := fetchImageContent(ctx, image.URL)
+		if fetchErr != nil {
+			GetLogger().Warn().Err(fetchErr).Str("url", image.URL).Msg("Failed to fetch card image, returning URL only")
+			continue
+		}
+		result.Content = append(result.Content, content)
+	}
+
+	return result, nil
+}
+
+func (s *MTGCommanderServer) handleGetCardPrintings(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limit := defaultPrintingsLimit
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok && limitFloat > 0 {
+			limit = min(int(limitFloat), maxPrintingsLimit)
+		}
+	}
+
+	query := exactNameQuery(name)
+	result, err := s.scryfallClient.SearchCards(ctx, query, scryfall.SearchCardsOptions{
+		Unique: scryfall.UniqueModePrints,
+		Order:  "released",
+		Dir:    scryfall.DirDesc,
+	})
+	if err != nil {
+		// Scryfall answers a search with no matches with a 404
+		var scryfallErr *scryfall.Error
+		if errors.As(err, &scryfallErr) && scryfallErr.Status == http.StatusNotFound {
+			return mcp.NewToolResultError(fmt.Sprintf("No printings found for %s.", name)), nil
+		}
+		GetLogger().Error().Err(err).Str("tool", "get_card_printings").Str("card", name).Msg("Printings search failed")
+		if message, ok := scryfallQueryError(query, err); ok {
+			return mcp.NewToolResultError(message), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Printings lookup failed: %v", err)), nil
+	}
+
+	printings := result.Cards
+	sortPrintingsByRelease(printings)
+	if len(printings) > limit {
+		printings = printings[:limit]
+	}
+
+	GetLogger().Info().
+		Str("tool", "get_card_printings").
+		Str("card", name).
+		Int("total_printings", result.TotalCards).
+		Int("returned", len(printings)).
+		Msg("Fetched card printings")
+
+	if len(printings) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No printings found for %s.", name)), nil
+	}
+
+	return mcp.NewToolResultText(FormatPrintingsForDisplay(printings[0].Name, printings, result.TotalCards)), nil
+}
+
+func (s *MTGCommanderServer) handleGetCardPriceHistory(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	query := exactNameQuery(name)
+	printings, totalPrintings, err := s.searchCardsWindow(ctx, query, scryfall.SearchCardsOptions{
+		Unique: scryfall.UniqueModePrints,
+		Order:  "usd",
+		Dir:    scryfall.DirAsc,
+	}, 0, maxPriceSpreadPrintings)
+	if err != nil {
+		// Scryfall answers a search with no matches with a 404
+		var scryfallErr *scryfall.Error
+		if errors.As(err, &scryfallErr) && scryfallErr.Status == http.StatusNotFound {
+			return mcp.NewToolResultError(fmt.Sprintf("No printings found for %s.", name)), nil
+		}
+		GetLogger().Error().Err(err).Str("tool", "get_card_price_history").Str("card", name).Msg("Printings search failed")
+		if message, ok := scryfallQueryError(query, err); ok {
+			return mcp.NewToolResultError(message), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Price lookup failed: %v", err)), nil
+	}
+	if len(printings) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No printings found for %s.", name)), nil
+	}
+
+	priced, unpriced := pricedPrintings(printings)
+
+	GetLogger().Info().
+		Str("tool", "get_card_price_history").
+		Str("card", name).
+		Int("total_printings", totalPrintings).
+		Int("priced", len(priced)).
+		Msg("Compared printing prices")
+
+	return mcp.NewToolResultText(
+		FormatPriceSpreadForDisplay(printings[0].Name, priced, unpriced, totalPrintings),
+	), nil
+}
+
+func (s *MTGCommanderServer) handleAutocompleteCard(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	suggestions, err := s.scryfallClient.AutocompleteCard(ctx, query)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "autocomplete_card").Str("query", query).Msg("Autocomplete failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Autocomplete failed: %v", err)), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "autocomplete_card").
+		Str("query", query).
+		Int("suggestions", len(suggestions)).
+		Msg("Fetched card name suggestions")
+
+	if len(suggestions) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No card names match %q.", query)), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Card names matching %q\n\n", query))
+	for i, suggestion := range suggestions {
+		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, suggestion))
+	}
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetSet(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	code, _ := args["code"].(string)
+	code = strings.TrimSpace(code)
+	name, _ := args["name"].(string)
+	name = strings.TrimSpace(name)
+
+	if code != "" {
+		set, err := s.scryfallClient.GetSet(ctx, strings.ToLower(code))
+		if err != nil {
+			GetLogger().Error().Err(err).Str("tool", "get_set").Str("code", code).Msg("Set lookup failed")
+			return mcp.NewToolResultError(fmt.Sprintf("Set not found: %s (%v)", code, err)), nil
+		}
+
+		GetLogger().Info().Str("tool", "get_set").Str("code", set.Code).Msg("Fetched set")
+		return mcp.NewToolResultText(FormatSetForDisplay(set)), nil
+	}
+
+	if name == "" {
+		return mcp.NewToolResultError("either code or name is required"), nil
+	}
+
+	sets, err := s.scryfallClient.ListSets(ctx)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_set").Str("name", name).Msg("Set list failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list sets: %v", err)), nil
+	}
+
+	matches := findSetsByName(sets, name)
+	GetLogger().Info().
+		Str("tool", "get_set").
+		Str("name", name).
+		Int("matches", len(matches)).
+		Msg("Searched sets by name")
+
+	switch len(matches) {
+	case 0:
+		return mcp.NewToolResultText(fmt.Sprintf("No sets match %q.", name)), nil
+	case 1:
+		return mcp.NewToolResultText(FormatSetForDisplay(matches[0])), nil
+	default:
+		return mcp.NewToolResultText(FormatSetMatchesForDisplay(name, matches)), nil
+	}
+}
+
+func (s *MTGCommanderServer) handleGetCardsBatch(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	cardList, err := request.RequireString("cards")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Each distinct card is looked up once, in the order first listed
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range ParseDecklist(cardList).Entries {
+		key := normalizeCardName(entry.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, entry.Name)
+	}
+
+	if len(names) == 0 {
+		return mcp.NewToolResultError("no card names found in cards"), nil
+	}
+	if len(names) > maxBatchCards {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("Too many cards: %d (max %d per call)", len(names), maxBatchCards),
+		), nil
+	}
+
+	lookup, err := s.lookupCardsByName(ctx, names)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_cards_batch").Int("cards", len(names)).Msg("Batch lookup failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to look up cards: %v", err)), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "get_cards_batch").
+		Int("requested", len(names)).
+		Int("not_found", len(lookup.NotFound)).
+		Msg("Looked up card batch")
+
+	return mcp.NewToolResultText(FormatCardBatchForDisplay(names, lookup)), nil
+}
+
+func (s *MTGCommanderServer) handleGetProxyImages(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	decklistStr, _ := args["decklist"].(string)
+	deckID, _ := args["deck_id"].(string)
+	decklistStr, deckID = strings.TrimSpace(decklistStr), strings.TrimSpace(deckID)
+	if (decklistStr == "") == (deckID == "") {
+		return mcp.NewToolResultError("give either decklist or deck_id"), nil
+	}
+
+	size := defaultProxyImageSize
+	if sz, ok := args["size"].(string); ok && sz != "" {
+		size = strings.ToLower(sz)
+	}
+	if !slices.Contains(proxyImageSizes(), size) {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("Invalid size %q (use one of: %s)", size, strings.Join(proxyImageSizes(), ", ")),
+		), nil
+	}
+
+	var entries []DecklistEntry
+	if deckID != "" {
+		publicID := ExtractPublicIDFromURL(deckID)
+		moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+		if err != nil {
+			GetLogger().Error().
+				Err(err).
+				Str("tool", "get_proxy_images").
+				Str("deck_id", publicID).
+				Msg("Failed to fetch deck")
+			return mcp.NewToolResultError(fmt.Sprintf(
+				"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+			)), nil
+		}
+		deck := moxfieldDeck.ToDeck()
+		for _, entry := range slices.Concat(deck.Commanders, deck.Mainboard) {
+			entries = append(entries, DecklistEntry{Name: entry.Name, Quantity: entry.Quantity})
+		}
+	} else {
+		entries = ParseDecklist(decklistStr).Entries
+	}
+
+	names := make([]string, 0, len(entries))
+	distinct := make(map[string]bool)
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+		distinct[normalizeCardName(entry.Name)] = true
+	}
+	if len(names) == 0 {
+		return mcp.NewToolResultError("no card names found in the decklist"), nil
+	}
+	if len(distinct) > maxBatchCards {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("Too many cards: %d (max %d per call)", len(distinct), maxBatchCards),
+		), nil
+	}
+
+	lookup, err := s.lookupCardsByName(ctx, names)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_proxy_images").Int("cards", len(names)).Msg("Batch lookup failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to look up cards: %v", err)), nil
+	}
+
+	sheet := newProxySheetJSON(entries, lookup, size)
+
+	GetLogger().Info().
+		Str("tool", "get_proxy_images").
+		Str("size", size).
+		Int("cards", sheet.TotalCards).
+		Int("images", len(sheet.Images)).
+		Int("not_found", len(sheet.NotFound)).
+		Msg("Built proxy sheet")
+
+	return jsonToolResult(sheet), nil
+}
+
+func (s *MTGCommanderServer) handleDrawProbability(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	copiesVal, hasCopies := args["copies"].(float64)
+	deckSizeVal, hasDeckSize := args["deck_size"].(float64)
+	drawnVal, hasDrawn := args["drawn"].(float64)
+	turnVal, hasTurn := args["turn"].(float64)
+	deckID, _ := args["deck_id"].(string)
+	deckID = strings.TrimSpace(deckID)
+
+	if hasDrawn && hasTurn {
+		return mcp.NewToolResultError("give either drawn or turn, not both"), nil
+	}
+	drawn := openingHandSize
+	switch {
+	case hasDrawn:
+		drawn = int(drawnVal)
+	case hasTurn:
+		if turnVal < 0 {
+			return mcp.NewToolResultError("turn must be 0 or greater"), nil
+		}
+		drawn = openingHandSize + int(turnVal)
+	}
+	if drawn < 1 {
+		return mcp.NewToolResultError("drawn must be 1 or greater"), nil
+	}
+
+	if deckID != "" {
+		if hasCopies || hasDeckSize {
+			return mcp.NewToolResultError("copies and deck_size come from the deck when deck_id is given"), nil
+		}
+		return s.turnOneRampProbability(ctx, deckID, drawn)
+	}
+
+	if !hasCopies {
+		return mcp.NewToolResultError("copies is required without deck_id"), nil
+	}
+	deckSize := defaultProbabilityDeckSize
+	if hasDeckSize {
+		deckSize = int(deckSizeVal)
+	}
+	copies := int(copiesVal)
+	if deckSize < 1 {
+		return mcp.NewToolResultError("deck_size must be 1 or greater"), nil
+	}
+	if copies < 0 || copies > deckSize {
+		return mcp.NewToolResultError(fmt.Sprintf("copies must be between 0 and deck_size (%d)", deckSize)), nil
+	}
+	if drawn > deckSize {
+		return mcp.NewToolResultError(fmt.Sprintf("drawn must not exceed deck_size (%d)", deckSize)), nil
+	}
+
+	return mcp.NewToolResultText(FormatDrawProbabilityForDisplay("", deckSize, copies, drawn, "copies", nil)), nil
+}
+
+// turnOneRampProbability calculates the chance of drawing one of a Moxfield deck's
+// turn-1 mana rocks. Moxfield doesn't say which cards produce mana, so the cheap
+// noncreature artifacts are checked against Scryfall.
+func (s *MTGCommanderServer) turnOneRampProbability(
+	ctx context.Context,
+	deckID string,
+	drawn int,
+) (*mcp.CallToolResult, error) {
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "draw_probability").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	deckSize := 0
+	var candidates []CardEntry
+	for _, entry := range deck.Mainboard {
+		deckSize += entry.Quantity
+		if strings.Contains(entry.TypeLine, "Artifact") && !isCreatureCard(entry) && !isLandCard(entry) &&
+			cardManaValue(entry) < turnOneRampMaxCMC {
+			candidates = append(candidates, entry)
+		}
+	}
+	if drawn > deckSize {
+		return mcp.NewToolResultError(fmt.Sprintf("drawn must not exceed the deck's %d mainboard cards", deckSize)), nil
+	}
+
+	copies := 0
+	var counted []string
+	if len(candidates) > 0 {
+		names := make([]string, 0, len(candidates))
+		for _, entry := range candidates {
+			names = append(names, entry.Name)
+		}
+		lookup, err := s.lookupCardsByName(ctx, names)
+		if err != nil {
+			GetLogger().Error().Err(err).Str("tool", "draw_probability").Msg("Batch lookup failed")
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to look up cards: %v", err)), nil
+		}
+		for _, entry := range candidates {
+			if card, ok := lookup.Get(entry.Name); ok && isTurnOneManaRock(card) {
+				copies += entry.Quantity
+				counted = append(counted, entry.Name)
+			}
+		}
+	}
+
+	GetLogger().Info().
+		Str("tool", "draw_probability").
+		Str("deck_id", publicID).
+		Int("deck_size", deckSize).
+		Int("rocks", copies).
+		Int("drawn", drawn).
+		Msg("Calculated turn-1 ramp probability")
+
+	return mcp.NewToolResultText(
+		FormatDrawProbabilityForDisplay(deck.Name, deckSize, copies, drawn, "turn-1 mana rocks", counted),
+	), nil
+}
+
+func (s *MTGCommanderServer) handleFindCommanders(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	input, err := request.RequireString("colors")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	colors, err := parseColorIdentity(input)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args := request.GetArguments()
+	exact, _ := args["exact"].(bool)
+
+	limit := defaultCommanderLimit
+	if limitVal, ok := args["limit"].(float64); ok {
+		limit = min(int(limitVal), maxSearchLimit)
+	}
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
+	}
+
+	query := commanderSearchQuery(colors, exact)
+	GetLogger().Info().
+		Str("tool", "find_commanders").
+		Str("query", query).
+		Int("limit", limit).
+		Msg("Searching for commanders")
+
+	searchOpts := scryfall.SearchCardsOptions{
+		Unique: "cards",
+		Order:  scryfall.OrderEDHREC,
+		Dir:    scryfall.DirAsc,
+	}
+	cards, totalCards, err := s.searchCardsWindow(ctx, query, searchOpts, 0, limit)
+	if err != nil {
+		// Scryfall answers a search with no matches with a 404
+		var scryfallErr *scryfall.Error
+		if errors.As(err, &scryfallErr) && scryfallErr.Status == http.StatusNotFound {
+			return mcp.NewToolResultText(
+				fmt.Sprintf("No commanders found for color identity %s.", strings.ToUpper(colors)),
+			), nil
+		}
+		GetLogger().Error().Err(err).Str("tool", "find_commanders").Str("query", query).Msg("Scryfall search failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if len(cards) == 0 {
+		return mcp.NewToolResultText(
+			fmt.Sprintf("No commanders found for color identity %s.", strings.ToUpper(colors)),
+		), nil
+	}
+
+	return mcp.NewToolResultText(FormatCommandersForDisplay(colors, exact, cards, totalCards)), nil
+}
+
+func (s *MTGCommanderServer) handleGetToken(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	description, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if strings.TrimSpace(description) == "" {
+		return mcp.NewToolResultError("query must not be empty"), nil
+	}
+
+	limit := defaultTokenLimit
+	if limitVal, ok := request.GetArguments()["limit"].(float64); ok {
+		limit = min(int(limitVal), maxSearchLimit)
+	}
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
+	}
+
+	query := tokenSearchQuery(description)
+	GetLogger().Info().Str("tool", "get_token").Str("query", query).Int("limit", limit).Msg("Searching for tokens")
+
+	tokens, totalCards, err := s.searchCardsWindow(ctx, query, scryfall.SearchCardsOptions{Unique: "cards"}, 0, limit)
+	if err != nil {
+		// Scryfall answers a search with no matches with a 404
+		var scryfallErr *scryfall.Error
+		if errors.As(err, &scryfallErr) && scryfallErr.Status == http.StatusNotFound {
+			return mcp.NewToolResultText(fmt.Sprintf("No tokens found matching %q.", description)), nil
+		}
+		GetLogger().Error().Err(err).Str("tool", "get_token").Str("query", query).Msg("Scryfall search failed")
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if len(tokens) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No tokens found matching %q.", description)), nil
+	}
+
+	return mcp.NewToolResultText(FormatTokensForDisplay(description, tokens, totalCards)), nil
+}
+
+func (s *MTGCommanderServer) handleCheckLegality(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	card, err := s.cachedGetCardByName(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Legality Check: %s\n\n", card.Name))
+
+	// Capitalize first letter for display
+	legality := string(card.Legalities.Commander)
+	status := strings.ToUpper(legality[:1]) + legality[1:]
+	output.WriteString(fmt.Sprintf("**Commander Format:** %s\n\n", status))
+
+	switch card.Legalities.Commander {
+	case scryfall.LegalityBanned:
+		output.WriteString("⚠️ This card is **BANNED** in Commander format.\n\n")
+	case scryfall.LegalityLegal:
+		output.WriteString("✅ This card is **LEGAL** in Commander format.\n\n")
+	case scryfall.LegalityRestricted:
+		output.WriteString("⚠️ This card is **RESTRICTED** in Commander format: " +
+			"a deck may run only one copy.\n\n")
+	case scryfall.LegalityNotLegal:
+		output.WriteString("❌ This card is **NOT LEGAL** in Commander format.\n\n")
+	}
+
+	// The Game Changers list only matters for cards that can be played in Commander
+	if card.Legalities.Commander == scryfall.LegalityLegal || card.Legalities.Commander == scryfall.LegalityRestricted {
+		gameChanger, gcErr := s.isGameChanger(ctx, card)
+		switch {
+		case gcErr != nil:
+			GetLogger().Warn().Err(gcErr).Str("card", card.Name).Msg("Failed to check Game Changer status")
+		case gameChanger:
+			output.WriteString("🎯 **Game Changer:** This card is on the Commander Game Changers list, " +
+				"which limits how many such cards a deck can run in each power bracket.\n\n")
+		default:
+			output.WriteString("**Game Changer:** No\n\n")
+		}
+	}
+
+	// Show all format legalities
+	output.WriteString("**All Format Legalities:**\n")
+	output.WriteString(formatLegalityLine("Standard", card.Legalities.Standard))
+	output.WriteString(formatLegalityLine("Pioneer", card.Legalities.Pioneer))
+	output.WriteString(formatLegalityLine("Modern", card.Legalities.Modern))
+	output.WriteString(formatLegalityLine("Legacy", card.Legalities.Legacy))
+	output.WriteString(formatLegalityLine("Vintage", card.Legalities.Vintage))
+	output.WriteString(formatLegalityLine("Pauper", card.Legalities.Pauper))
+	output.WriteString(formatLegalityLine("Commander", card.Legalities.Commander))
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetRulings(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	name, _ := args["name"].(string)
+	name = strings.TrimSpace(name)
+	scryfallID, _ := args["scryfall_id"].(string)
+	scryfallID = strings.TrimSpace(scryfallID)
+
+	// A Scryfall ID identifies the card directly, so only a name needs resolving first
+	title := name
+	if scryfallID == "" {
+		if name == "" {
+			return mcp.NewToolResultError("either name or scryfall_id is required"), nil
+		}
+
+		card, err := s.cachedGetCardByName(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
+		}
+		scryfallID = card.ID
+		title = card.Name
+	} else {
+		if !scryfallIDPattern.MatchString(scryfallID) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid scryfall_id %q (expected a UUID)", scryfallID)), nil
+		}
+		if title == "" {
+			title = "Scryfall ID " + scryfallID
+		}
+	}
+
+	// Get rulings
+	rulings, err := s.scryfallClient.GetRulings(ctx, scryfallID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get rulings: %v", err)), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Rulings for %s\n\n", title))
+
+	if len(rulings) == 0 {
+		output.WriteString("No official rulings found for this card.\n")
+	} else {
+		output.WriteString(fmt.Sprintf("Found %d ruling(s):\n\n", len(rulings)))
+		for i, ruling := range rulings {
+			output.WriteString(fmt.Sprintf("%d. **%s** (%s)\n", i+1, ruling.PublishedAt, ruling.Source))
+			output.WriteString(fmt.Sprintf("   %s\n\n", ruling.Comment))
+		}
+	}
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetPrice(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	setCode := ""
+	currencyList := ""
+	args := request.GetArguments()
+	if setVal, hasSet := args["set"]; hasSet {
+		if set, ok := setVal.(string); ok {
+			setCode = set
+		}
+	}
+	if currencyVal, hasCurrency := args["currency"]; hasCurrency {
+		if c, ok := currencyVal.(string); ok {
+			currencyList = c
+		}
+	}
+
+	currencies, err := parseCurrencies(currencyList)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var card scryfall.Card
+	if setCode != "" {
+		// Search for specific set
+		searchQuery := fmt.Sprintf(`!"%s" set:%s`, name, setCode)
+		result, searchErr := s.scryfallClient.SearchCards(ctx, searchQuery, scryfall.SearchCardsOptions{})
+		if searchErr != nil {
+			return nil, fmt.Errorf("failed to search for card: %w", searchErr)
+		}
+		if len(result.Cards) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Card not found in set %s", setCode)), nil
+		}
+		card = result.Cards[0]
+	} else {
+		// Get default card
+		c, getErr := s.cachedGetCardByName(ctx, name)
+		if getErr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", getErr)), nil
+		}
+		card = c
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Pricing for %s\n", card.Name))
+	output.WriteString(
+		fmt.Sprintf("Set: %s (%s) #%s\n\n", card.SetName, strings.ToUpper(card.Set), card.CollectorNumber),
+	)
+
+	// Currencies other than USD and EUR (which Scryfall prices natively) are converted from USD
+	var converted []string
+	for _, currency := range currencies {
+		if currency != "USD" && currency != "EUR" {
+			converted = append(converted, currency)
+		}
+	}
+
+	rates := map[string]float64{}
+	if len(converted) > 0 {
+		rates, err = getExchangeRates(ctx, converted)
+		if err != nil {
+			GetLogger().Warn().Err(err).Strs("currencies", converted).Msg("Failed to get exchange rates")
+		}
+		if _, hasBRL := rates["BRL"]; !hasBRL && slices.Contains(converted, "BRL") {
+			rates["BRL"] = fallbackUSDToBRLRate
+		}
+	}
+
+	writeConverted := func(usdPrice, suffix string) {
+		for _, currency := range converted {
+			rate, ok := rates[currency]
+			if !ok {
+				output.WriteString(fmt.Sprintf("**%s%s:** exchange rate unavailable\n", currency, suffix))
+				continue
+			}
+			output.WriteString(fmt.Sprintf("**%s%s:** %s%.2f (converted)\n",
+				currency, suffix, currencySymbol(currency), convertFromUSD(usdPrice, rate)))
+		}
+	}
+
+	hasPricing := false
+
+	if card.Prices.USD != "" {
+		output.WriteString(fmt.Sprintf("**USD:** $%s\n", card.Prices.USD))
+		writeConverted(card.Prices.USD, "")
+		hasPricing = true
+	}
+
+	if card.Prices.USDFoil != "" {
+		output.WriteString(fmt.Sprintf("**USD (Foil):** $%s\n", card.Prices.USDFoil))
+		writeConverted(card.Prices.USDFoil, " (Foil)")
+		hasPricing = true
+	}
+
+	if slices.Contains(currencies, "EUR") {
+		if card.Prices.EUR != "" {
+			output.WriteString(fmt.Sprintf("**EUR:** €%s\n", card.Prices.EUR))
+			hasPricing = true
+		}
+
+		if card.Prices.EURFoil != "" {
+			output.WriteString(fmt.Sprintf("**EUR (Foil):** €%s\n", card.Prices.EURFoil))
+			hasPricing = true
+		}
+	}
+
+	if card.Prices.Tix != "" {
+		output.WriteString(fmt.Sprintf("**MTGO Tix:** %s\n", card.Prices.Tix))
+		hasPricing = true
+	}
+
+	if !hasPricing {
+		output.WriteString("No pricing data available for this card.\n")
+	} else if len(rates) > 0 {
+		output.WriteString("\n")
+		for _, currency := range converted {
+			if rate, ok := rates[currency]; ok {
+				output.WriteString(fmt.Sprintf("*Exchange rate: 1 USD = %.4f %s*\n", rate, currency))
+			}
+		}
+		output.WriteString(fmt.Sprintf(
+			"*Note: %s prices are converted from USD and may not reflect local market conditions*\n",
+			strings.Join(converted, "/"),
+		))
+	}
+
+	output.WriteString(FormatCardLinksForDisplay(card))
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetBannedList(
+	ctx context.Context,
+	_ mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	banned, err := s.getBannedList(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch banned list: %v", err)), nil
+	}
+
+	var output strings.Builder
+	output.WriteString("# Commander Format Banned List\n\n")
+	output.WriteString(fmt.Sprintf("Total banned cards: %d\n\n", len(banned.cards)))
+
+	for i, card := range banned.cards {
+		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, card.Name))
+	}
+
+	output.WriteString("\n*Source: Scryfall (powered by Wizards of the Coast official data)*\n")
+	output.WriteString(fmt.Sprintf("*Last updated: %s*\n", banned.fetchedAt.UTC().Format(time.RFC3339)))
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleParseDecklist(
+	_ context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	decklistStr, err := request.RequireString("decklist")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := ParseDecklist(decklistStr)
+
+	GetLogger().Info().
+		Str("tool", "parse_decklist").
+		Int("entries", len(result.Entries)).
+		Int("total_cards", result.TotalCards).
+		Int("warnings", len(result.Warnings)).
+		Msg("Parsed decklist")
+
+	return mcp.NewToolResultStructured(result, FormatDecklistParseForDisplay(result)), nil
+}
+
+func (s *MTGCommanderServer) handleValidateDeck(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	commanderName, err := request.RequireString("commander")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	decklistStr, err := request.RequireString("decklist")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	commanderNames := []string{commanderName}
+	companionName := ""
+	args := request.GetArguments()
+	if partnerVal, hasPartner := args["partner"]; hasPartner {
+		if partner, ok := partnerVal.(string); ok && strings.TrimSpace(partner) != "" {
+			commanderNames = append(commanderNames, strings.TrimSpace(partner))
+		}
+	}
+	if companionVal, hasCompanion := args["companion"]; hasCompanion {
+		if companion, ok := companionVal.(string); ok {
+			companionName = strings.TrimSpace(companion)
+		}
+	}
+
+	// Parse decklist (support both JSON array and text format)
+	return s.validateDeck(ctx, commanderNames, companionName, ParseDecklist(decklistStr))
+}
+
+func (s *MTGCommanderServer) handleValidateDeckFormat(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	format, err := request.RequireString("format")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if !slices.Contains(validationFormats(), format) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid format %q (use one of: %s)", format, strings.Join(validationFormats(), ", "),
+		)), nil
+	}
+
+	decklistStr, err := request.RequireString("decklist")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args := request.GetArguments()
+	sideboardStr, _ := args["sideboard"].(string)
+	commanderName, _ := args["commander"].(string)
+	commanderName = strings.TrimSpace(commanderName)
+
+	GetLogger().Info().
+		Str("tool", "validate_deck_format").
+		Str("format", format).
+		Str("commander", commanderName).
+		Msg("Validating deck")
+
+	if format != "commander" {
+		return s.validateConstructedDeck(ctx, format, ParseDecklist(decklistStr), ParseDecklist(sideboardStr))
+	}
+
+	if commanderName == "" {
+		return mcp.NewToolResultError("commander is required to validate a Commander deck"), nil
+	}
+	if strings.TrimSpace(sideboardStr) != "" {
+		return mcp.NewToolResultError("Commander decks have no sideboard; leave sideboard empty"), nil
+	}
+
+	commanderNames := []string{commanderName}
+	if partner, ok := args["partner"].(string); ok && strings.TrimSpace(partner) != "" {
+		commanderNames = append(commanderNames, strings.TrimSpace(partner))
+	}
+	companionName, _ := args["companion"].(string)
+
+	return s.validateDeck(ctx, commanderNames, strings.TrimSpace(companionName), ParseDecklist(decklistStr))
+}
+
+// validateConstructedDeck checks a main deck and sideboard against the rules of a
+// 60-card format: deck and sideboard size, the four-copy limit across both, and
+// each card's legality in the format.
+func (s *MTGCommanderServer) validateConstructedDeck(
+	ctx context.Context,
+	format string,
+	mainDeck, sideboard DecklistParseResult,
+) (*mcp.CallToolResult, error) {
+	var output strings.Builder
+	invalid := false
+
+	output.WriteString(fmt.Sprintf("**Deck Size:** %d cards ", mainDeck.TotalCards))
+	if mainDeck.TotalCards >= constructedMinDeckSize {
+		output.WriteString("✅\n")
+	} else {
+		invalid = true
+		output.WriteString(fmt.Sprintf("❌ (should be at least %d cards)\n", constructedMinDeckSize))
+	}
+
+	output.WriteString(fmt.Sprintf("**Sideboard:** %d cards ", sideboard.TotalCards))
+	if sideboard.TotalCards <= constructedMaxSideboard {
+		output.WriteString("✅\n")
+	} else {
+		invalid = true
+		output.WriteString(fmt.Sprintf("❌ (should be at most %d cards)\n", constructedMaxSideboard))
+	}
+
+	// The copy limit counts the main deck and sideboard together
+	entries := slices.Concat(mainDeck.Entries, sideboard.Entries)
+	cardNames := slices.Concat(mainDeck.Names(), sideboard.Names())
+
+	// Resolve every card in one or two collection requests for per-card checks
+	lookup, lookupErr := s.lookupCardsByName(ctx, cardNames)
+	if lookupErr != nil {
+		GetLogger().Warn().Err(lookupErr).Str("tool", "validate_deck_format").Msg("Per-card lookup failed")
+	}
+
+	excess := findCopyLimitViolations(entries, lookup, constructedCopyLimit)
+	output.WriteString("\n**Copy Limit:** ")
+	if len(excess) == 0 {
+		output.WriteString(fmt.Sprintf("✅ No card over %d copies\n", constructedCopyLimit))
+	} else {
+		invalid = true
+		output.WriteString(fmt.Sprintf("❌ Found cards over the %d-copy limit:\n", constructedCopyLimit))
+		for _, v := range excess {
+			output.WriteString(fmt.Sprintf("  - %s (x%d, max %d)\n", v.Name, v.Quantity, v.Limit))
+		}
+	}
+
+	if lookupErr != nil {
+		output.WriteString(fmt.Sprintf("\n⚠️ **WARNING:** Could not look up decklist cards: %v\n", lookupErr))
+	} else {
+		violations := findFormatLegalityViolations(cardNames, lookup, format)
+
+		output.WriteString("\n**Card Legality:** ")
+		if len(violations) == 0 {
+			output.WriteString(fmt.Sprintf("✅ All cards legal in %s\n", format))
+		} else {
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ Found %d card(s) not legal in %s:\n", len(violations), format))
+			for _, v := range violations {
+				output.WriteString(fmt.Sprintf("  - %s (%s: %s)\n", v.Name, v.Legality, legalityExplanation(v.Legality)))
+			}
+		}
+
+		if len(lookup.NotFound) > 0 {
+			output.WriteString(fmt.Sprintf("\n**Unrecognized Cards (%d):**\n", len(lookup.NotFound)))
+			for _, name := range lookup.NotFound {
+				output.WriteString(fmt.Sprintf("  - %s\n", name))
+			}
+		}
+	}
+
+	if len(mainDeck.Warnings)+len(sideboard.Warnings) > 0 {
+		output.WriteString("\n**Parse Warnings:**\n")
+		for _, warning := range mainDeck.Warnings {
+			output.WriteString(fmt.Sprintf("  - %s\n", warning))
+		}
+		for _, warning := range sideboard.Warnings {
+			output.WriteString(fmt.Sprintf("  - sideboard %s\n", warning))
+		}
+	}
+
+	var report strings.Builder
+	report.WriteString("# Deck Validation\n\n")
+	report.WriteString(fmt.Sprintf("**Format:** %s\n", format))
+	if invalid {
+		report.WriteString("**Status:** ❌ INVALID\n\n")
+	} else {
+		report.WriteString("**Status:** ✅ VALID\n\n")
+	}
+	report.WriteString(output.String())
+
+	return mcp.NewToolResultText(report.String()), nil
+}
+
+func (s *MTGCommanderServer) handleValidateMoxfieldDeck(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "validate_moxfield_deck").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	allowNonCommander, _ := request.GetArguments()["allow_noncommander"].(bool)
+	formatProblem := moxfieldFormatProblem(deck.Format)
+	if formatProblem != "" && !allowNonCommander {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Not validating Moxfield deck %s: %s. Set allow_noncommander to validate it anyway.",
+			publicID, formatProblem,
+		)), nil
+	}
+
+	commanderNames, companionName := deckCommandZone(deck)
+	if len(commanderNames) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Moxfield deck %s has no commander set", publicID)), nil
+	}
+
+	GetLogger().Info().
+		Str("tool", "validate_moxfield_deck").
+		Str("deck_id", publicID).
+		Strs("commanders", commanderNames).
+		Str("companion", companionName).
+		Str("format", deck.Format).
+		Int("mainboard_entries", len(deck.Mainboard)).
+		Msg("Validating Moxfield deck")
+
+	result, err := s.validateDeck(ctx, commanderNames, companionName, deckDecklist(deck))
+	if err != nil || formatProblem == "" || len(result.Content) == 0 {
+		return result, err
+	}
+
+	// Put the format warning ahead of the report so the verdict isn't read without it
+	if text, ok := result.Content[0].(mcp.TextContent); ok {
+		text.Text = fmt.Sprintf("⚠️ **WARNING:** %s.\n\n%s", formatProblem, text.Text)
+		result.Content[0] = text
+	}
+	return result, nil
+}
+
+// validateDeck checks a parsed decklist against the Commander deck construction
+// rules for one commander or a pair of partners, plus an optional companion, and
+// reports the result.
+func (s *MTGCommanderServer) validateDeck(
+	ctx context.Context,
+	commanderNames []string,
+	companionName string,
+	parsed DecklistParseResult,
+) (*mcp.CallToolResult, error) {
+	if len(commanderNames) > maxCommanders {
+		return mcp.NewToolResultError(fmt.Sprintf("A deck can have at most %d commanders", maxCommanders)), nil
+	}
+
+	cardNames := parsed.Names()
+
+	// Get commander cards
+	commanders := make([]scryfall.Card, 0, len(commanderNames))
+	for _, name := range commanderNames {
+		commander, err := s.cachedGetCardByName(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Commander card not found: %v", err)), nil
+		}
+		commanders = append(commanders, commander)
+	}
+	colorIdentity := mergeColorIdentities(commanders)
+
+	// Checks are written to output; the header and overall status are prepended once all have run
+	var output strings.Builder
+	invalid := false
+
+	for _, commander := range commanders {
+		// Check if commander is legal
+		if commander.Legalities.Commander == "banned" {
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ **ERROR:** %s is banned in Commander format!\n\n", commander.Name))
+		}
+
+		// Validate commander can be a commander
+		if !canBeCommander(commander) {
+			invalid = true
+			output.WriteString(fmt.Sprintf(
+				"❌ **ERROR:** %s cannot be a commander (must be legendary or have special text allowing it)!\n\n",
+				commander.Name,
+			))
+		}
+	}
+
+	// Two commanders must be allowed to share command: partners or a commander and a Background
+	if len(commanders) == maxCommanders {
+		if problem := commanderPairingProblem(commanders[0], commanders[1]); problem != "" {
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ **ERROR:** %s\n\n", problem))
+		}
+	}
+
+	// Check deck size: 100 cards including the commanders
+	expectedCards := deckValidationCommanderCount - len(commanders)
+	commanderLabel := "commander"
+	if len(commanders) > 1 {
+		commanderLabel = fmt.Sprintf("%d commanders", len(commanders))
+	}
+	totalCards := len(cardNames)
+	output.WriteString(fmt.Sprintf("**Deck Size:** %d cards ", totalCards))
+	switch totalCards {
+	case expectedCards:
+		output.WriteString("✅\n")
+	case deckValidationCommanderCount:
+		output.WriteString(fmt.Sprintf(
+			"(Note: 100 cards including %s, should be %d in decklist)\n", commanderLabel, expectedCards,
+		))
+	default:
+		invalid = true
+		output.WriteString(fmt.Sprintf("❌ (should be %d cards plus %s)\n", expectedCards, commanderLabel))
+	}
+
+	// The companion starts outside the game, so it isn't part of the decklist
+	var companion *scryfall.Card
+	if companionName != "" {
+		card, err := s.cachedGetCardByName(ctx, companionName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Companion card not found: %v", err)), nil
+		}
+		companion = &card
+
+		output.WriteString(fmt.Sprintf("\n**Companion:** %s ", companion.Name))
+		switch {
+		case !hasKeyword(*companion, "Companion"):
+			invalid = true
+			output.WriteString("❌ (this card doesn't have companion)\n")
+		case companion.Legalities.Commander == scryfall.LegalityBanned:
+			invalid = true
+			output.WriteString("❌ (banned in Commander)\n")
+		case !isColorSubset(companion.ColorIdentity, colorIdentity):
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ (%s is outside %s)\n",
+				formatColors(companion.ColorIdentity), formatColors(colorIdentity)))
+		default:
+			output.WriteString("✅ (its deckbuilding condition isn't checked)\n")
+		}
+	}
+
+	// Resolve every card in one or two collection requests for per-card checks
+	lookup, lookupErr := s.lookupCardsByName(ctx, cardNames)
+	if lookupErr != nil {
+		GetLogger().Warn().Err(lookupErr).Str("tool", "validate_deck").Msg("Per-card lookup failed")
+	}
+
+	// Check singleton (no duplicates except basic lands and cards that allow more copies)
+	duplicates := findSingletonViolations(parsed.Entries, lookup)
+
+	output.WriteString("\n**Singleton Rule:** ")
+	if len(duplicates) == 0 {
+		output.WriteString("✅ No duplicates\n")
+	} else {
+		invalid = true
+		output.WriteString("❌ Found duplicates:\n")
+		for _, dup := range duplicates {
+			if dup.Limit > 1 {
+				output.WriteString(fmt.Sprintf("  - %s (x%d, max %d)\n", dup.Name, dup.Quantity, dup.Limit))
+			} else {
+				output.WriteString(fmt.Sprintf("  - %s (x%d)\n", dup.Name, dup.Quantity))
+			}
+		}
+	}
+
+	if lookupErr != nil {
+		output.WriteString(fmt.Sprintf("\n⚠️ **WARNING:** Could not look up decklist cards: %v\n", lookupErr))
+	} else {
+		violations := findColorIdentityViolations(cardNames, lookup, colorIdentity)
+
+		output.WriteString("\n**Color Identity:** ")
+		if len(violations) == 0 {
+			output.WriteString("✅ All cards within commander's color identity\n")
+		} else {
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ Found %d card(s) outside %s:\n", len(violations),
+				formatColors(colorIdentity)))
+			for _, v := range violations {
+				output.WriteString(fmt.Sprintf("  - %s (%s)\n", v.Name, formatColors(v.Colors)))
+			}
+		}
+
+		banned := findBannedCards(cardNames, lookup)
+
+		output.WriteString("\n**Banned Cards:** ")
+		if len(banned) == 0 {
+			output.WriteString("✅ None\n")
+		} else {
+			invalid = true
+			output.WriteString(fmt.Sprintf("❌ Found %d banned card(s):\n", len(banned)))
+			for _, name := range banned {
+				output.WriteString(fmt.Sprintf("  - %s\n", name))
+			}
+		}
+
+		if len(lookup.NotFound) > 0 {
+			output.WriteString(fmt.Sprintf("\n**Unrecognized Cards (%d):**\n", len(lookup.NotFound)))
+			for _, name := range lookup.NotFound {
+				output.WriteString(fmt.Sprintf("  - %s\n", name))
+			}
+		}
+	}
+
+	if len(parsed.Warnings) > 0 {
+		output.WriteString("\n**Parse Warnings:**\n")
+		for _, warning := range parsed.Warnings {
+			output.WriteString(fmt.Sprintf("  - %s\n", warning))
+		}
+	}
+
+	var report strings.Builder
+	report.WriteString("# Commander Deck Validation\n\n")
+	names := make([]string, len(commanders))
+	for i, commander := range commanders {
+		names[i] = commander.Name
+	}
+	report.WriteString(fmt.Sprintf("**Commander:** %s\n", strings.Join(names, " & ")))
+	report.WriteString(fmt.Sprintf("**Color Identity:** %s\n", formatColors(colorIdentity)))
+	if invalid {
+		report.WriteString("**Status:** ❌ INVALID\n\n")
+	} else {
+		report.WriteString("**Status:** ✅ VALID\n\n")
+	}
+	report.WriteString(output.String())
+
+	return mcp.NewToolResultText(report.String()), nil
+}
+
+func (s *MTGCommanderServer) handleGetMoxfieldDeck(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_moxfield_deck").Msg("Missing deck_id parameter")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	outputFormat, err := outputFormatArg(request.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Extract public ID if URL was provided
+	publicID := ExtractPublicIDFromURL(deckID)
+	version, _ := request.GetArguments()["version"].(string)
+
+	GetLogger().Info().
+		Str("tool", "get_moxfield_deck").
+		Str("deck_id", publicID).
+		Str("version", version).
+		Msg("Fetching Moxfield deck")
+
+	var moxfieldDeck *MoxfieldDeck
+	if version != "" {
+		moxfieldDeck, err = GetMoxfieldDeckVersion(ctx, publicID, version)
+	} else {
+		moxfieldDeck, err = GetMoxfieldDeck(ctx, publicID)
+	}
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_moxfield_deck").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch Moxfield deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	GetLogger().Info().
+		Str("tool", "get_moxfield_deck").
+		Str("deck_id", publicID).
+		Str("deck_name", deck.Name).
+		Str("format", deck.Format).
+		Int("mainboard_cards", len(deck.Mainboard)).
+		Msg("Successfully fetched Moxfield deck")
+
+	withPrices, _ := request.GetArguments()["with_prices"].(bool)
+	var prices *DeckPriceSummary
+	var priceErr error
+	if withPrices {
+		prices, priceErr = s.priceDeck(ctx, deck)
+		if priceErr != nil {
+			// The decklist is still useful without prices
+			GetLogger().Warn().Err(priceErr).Str("tool", "get_moxfield_deck").Str("deck_id", publicID).
+				Msg("Failed to price deck")
+		}
+	}
+
+	if outputFormat == outputFormatJSON {
+		return jsonToolResult(newDeckJSON(deck, prices)), nil
+	}
+
+	output := FormatDeckForDisplay(deck, prices)
+	if priceErr != nil {
+		output += fmt.Sprintf("\n⚠️ Deck price unavailable: %v\n", priceErr)
+	}
+	return mcp.NewToolResultText(output), nil
+}
+
+// priceDeck prices a deck's mainboard with one batched Scryfall lookup.
+func (s *MTGCommanderServer) priceDeck(ctx context.Context, deck *Deck) (*DeckPriceSummary, error) {
+	entries := deckDecklist(deck).Entries
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+
+	lookup, err := s.lookupCardsByName(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summarizeDeckPrices(entries, lookup)
+	GetLogger().Info().
+		Str("deck_id", deck.ID).
+		Float64("total_usd", summary.TotalUSD).
+		Int("unpriced", len(summary.Unpriced)).
+		Msg("Priced Moxfield deck")
+	return &summary, nil
+}
+
+func (s *MTGCommanderServer) handleCompareMoxfieldDecks(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckAID, err := request.RequireString("deck_a")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	deckBID, err := request.RequireString("deck_b")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	publicIDA := ExtractPublicIDFromURL(deckAID)
+	publicIDB := ExtractPublicIDFromURL(deckBID)
+
+	moxfieldDeckA, err := GetMoxfieldDeck(ctx, publicIDA)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "compare_moxfield_decks").
+			Str("deck_id", publicIDA).
+			Msg("Failed to fetch deck A")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Deck A (%s): %v%s", publicIDA, err, upstreamErrorHint(err),
+		)), nil
+	}
+	deckA := moxfieldDeckA.ToDeck()
+
+	moxfieldDeckB, err := GetMoxfieldDeck(ctx, publicIDB)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "compare_moxfield_decks").
+			Str("deck_id", publicIDB).
+			Msg("Failed to fetch deck B")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Deck B (%s): %v%s", publicIDB, err, upstreamErrorHint(err),
+		)), nil
+	}
+	deckB := moxfieldDeckB.ToDeck()
+
+	comparison := CompareDecks(deckA, deckB)
+
+	GetLogger().Info().
+		Str("tool", "compare_moxfield_decks").
+		Str("deck_a", publicIDA).
+		Str("deck_b", publicIDB).
+		Int("only_in_a", len(comparison.OnlyInA)).
+		Int("only_in_b", len(comparison.OnlyInB)).
+		Int("shared", len(comparison.Shared)).
+		Msg("Compared Moxfield decks")
+
+	return mcp.NewToolResultText(FormatDeckComparisonForDisplay(deckA, deckB, comparison)), nil
+}
+
+func (s *MTGCommanderServer) handleExportDeck(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	format := "text"
+	args := request.GetArguments()
+	if formatVal, hasFormat := args["format"]; hasFormat {
+		if f, ok := formatVal.(string); ok && f != "" {
+			format = strings.ToLower(f)
+		}
+	}
+	if !slices.Contains(deckExportFormats(), format) {
+		return mcp.NewToolResultError(
+			fmt.Sprintf("Invalid format %q (use one of: %s)", format, strings.Join(deckExportFormats(), ", ")),
+		), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "export_deck").Str("deck_id", publicID).Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	GetLogger().Info().
+		Str("tool", "export_deck").
+		Str("deck_id", publicID).
+		Str("format", format).
+		Msg("Exporting Moxfield deck")
+
+	if format == "mtgo" {
+		dek, dekErr := FormatDeckAsMTGODek(deck)
+		if dekErr != nil {
+			return mcp.NewToolResultError(dekErr.Error()), nil
+		}
+		return mcp.NewToolResultText(dek), nil
+	}
+
+	return mcp.NewToolResultText(FormatDeckAsText(deck)), nil
+}
+
+func (s *MTGCommanderServer) handleGetDeckStats(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_deck_stats").Str("deck_id", publicID).Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	GetLogger().Info().
+		Str("tool", "get_deck_stats").
+		Str("deck_id", publicID).
+		Int("mainboard_entries", len(deck.Mainboard)).
+		Msg("Computing Moxfield deck stats")
+
+	return mcp.NewToolResultText(FormatDeckStatsForDisplay(deck)), nil
+}
+
+func (s *MTGCommanderServer) handleGetDeckCurveChart(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_deck_curve_chart").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	GetLogger().Info().
+		Str("tool", "get_deck_curve_chart").
+		Str("deck_id", publicID).
+		Int("mainboard_entries", len(deck.Mainboard)).
+		Msg("Charting Moxfield deck mana curve")
+
+	return mcp.NewToolResultText(FormatCurveChartForDisplay(deck)), nil
+}
+
+func (s *MTGCommanderServer) handleEstimatePowerLevel(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "estimate_power_level").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	// Combos are best-effort: without them the estimate just skips that signal
+	colors := strings.ToLower(strings.Join(deckColorIdentity(deck), ""))
+	if colors == "" {
+		colors = "colorless"
+	}
+	combos, err := getCombosForColorsWithURL(ctx, colors, s.edhrecBaseURL)
+	if err != nil {
+		GetLogger().Warn().Err(err).Str("tool", "estimate_power_level").Str("colors", colors).
+			Msg("Failed to fetch EDHREC combos, skipping combo check")
+		combos = nil
+	}
+
+	estimate := estimatePowerLevel(deck, combos)
+	GetLogger().Info().
+		Str("tool", "estimate_power_level").
+		Str("deck_id", publicID).
+		Float64("score", estimate.score).
+		Msg("Estimated deck power level")
+
+	return mcp.NewToolResultText(FormatPowerLevelForDisplay(deck, estimate)), nil
+}
+
+func (s *MTGCommanderServer) handleGetDeckMissingStaples(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limit := defaultMissingStaplesLimit
+	if limitVal, ok := request.GetArguments()["limit"].(float64); ok {
+		limit = min(int(limitVal), maxSearchLimit)
+	}
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_deck_missing_staples").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	commanders := deckCommanderNames(deck)
+	if len(commanders) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Deck %q has no commander set, so there are no EDHREC recommendations to compare against. "+
+				"Set a commander on Moxfield and try again.", deck.Name,
+		)), nil
+	}
+
+	// EDHREC pages are per commander; partner decks are compared against the first one
+	commander := commanders[0]
+	GetLogger().Info().
+		Str("tool", "get_deck_missing_staples").
+		Str("deck_id", publicID).
+		Str("commander", commander).
+		Int("limit", limit).
+		Msg("Finding missing EDHREC staples")
+
+	data, err := s.cachedCommanderRecommendations(ctx, commander, false, false)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_deck_missing_staples").
+			Str("commander", commander).
+			Msg("Failed to fetch EDHREC recommendations")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC recommendations: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+
+	missing := missingStaples(deck, data)
+	return mcp.NewToolResultText(FormatMissingStaplesForDisplay(deck, data, missing, limit)), nil
+}
+
+func (s *MTGCommanderServer) handleSuggestCuts(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	deckID, err := request.RequireString("deck_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limitVal, hasLimit := request.GetArguments()["limit"].(float64)
+	if hasLimit && limitVal < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
+	}
+
+	publicID := ExtractPublicIDFromURL(deckID)
+	moxfieldDeck, err := GetMoxfieldDeck(ctx, publicID)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "suggest_cuts").
+			Str("deck_id", publicID).
+			Msg("Failed to fetch deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Moxfield deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
+	deck := moxfieldDeck.ToDeck()
+
+	limit := max(deckSize(deck)-deckValidationCommanderCount, defaultSuggestedCuts)
+	if hasLimit {
+		limit = int(limitVal)
+	}
+	limit = min(limit, maxSearchLimit)
+
+	// Without a commander or its EDHREC page, cuts fall back to mana value and card type
+	var data *EDHRECData
+	if commanders := deckCommanderNames(deck); len(commanders) > 0 {
+		// EDHREC pages are per commander; partner decks are compared against the first one
+		data, err = s.cachedCommanderRecommendations(ctx, commanders[0], false, false)
+		if err != nil {
+			GetLogger().Warn().
+				Err(err).
+				Str("tool", "suggest_cuts").
+				Str("commander", commanders[0]).
+				Msg("EDHREC recommendations unavailable, using heuristics")
+		}
+	}
+
+	GetLogger().Info().
+		Str("tool", "suggest_cuts").
+		Str("deck_id", publicID).
+		Int("limit", limit).
+		Bool("edhrec", data != nil).
+		Msg("Suggesting cuts")
+
+	cuts := suggestCuts(deck, data)
+	return mcp.NewToolResultText(FormatSuggestedCutsForDisplay(deck, data, cuts, limit)), nil
+}
+
+func (s *MTGCommanderServer) handleGetMoxfieldUserDecks(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	username, err := request.RequireString("username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	const defaultPageSize = 20
+	pageSize := defaultPageSize
+	args := request.GetArguments()
+	if pageSizeVal, hasPageSize := args["page_size"]; hasPageSize {
+		if pageSizeFloat, ok := pageSizeVal.(float64); ok {
+			pageSize = int(pageSizeFloat)
+			if pageSize > maxPageSize {
+				pageSize = maxPageSize
+			}
+		}
+	}
+
+	decks, err := GetUserDecks(ctx, username, pageSize)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch user decks: %v%s", err, upstreamErrorHint(err))), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Decks by %s\n\n", username))
+	output.WriteString(fmt.Sprintf("**Total Decks:** %d\n", decks.TotalResults))
+	output.WriteString(
+		fmt.Sprintf("**Showing:** %d decks (Page %d of %d)\n\n", len(decks.Data), decks.PageNumber, decks.TotalPages),
+	)
+
+	for i, deck := range decks.Data {
+		output.WriteString(fmt.Sprintf("%d. **%s** (%s)\n", i+1, deck.Name, deck.Format))
+		output.WriteString(fmt.Sprintf("   - Deck ID: %s\n", deck.PublicID))
+		output.WriteString(fmt.Sprintf("   - Views: %d | Likes: %d\n", deck.ViewCount, deck.LikeCount))
+		output.WriteString(fmt.Sprintf("   - URL: %s\n\n", deck.PublicURL))
+	}
+
+	return mcp.NewToolResultText(output.String()), nil
+}
+
+func (s *MTGCommanderServer) handleSearchMoxfieldDecks(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+
+	query, _ := args["query"].(string)
+	commander, _ := args["commander"].(string)
+	var hubs []string
+	if hubsVal, ok := args["hubs"].(string); ok {
+		for hub := range strings.SplitSeq(hubsVal, ",") {
+			if hub = strings.TrimSpace(hub); hub != "" {
+				hubs = append(hubs, hub)
+			}
+		}
+	}
+	if query == "" && commander == "" && len(hubs) == 0 {
+		GetLogger().Error().Str("tool", "search_moxfield_decks").Msg("Missing search parameters")
+		return mcp.NewToolResultError("at least one of query, commander, or hubs is required"), nil
+	}
+
+	format := "commander"
+	if formatVal, ok := args["format"].(string); ok && formatVal != "" {
+		format = formatVal
+	}
+
+	sortType := "updated"
+	if sortTypeVal, ok := args["sort_type"].(string); ok && sortTypeVal != "" {
+		sortType = strings.ToLower(sortTypeVal)
+	}
+	if !slices.Contains(moxfieldSortTypes(), sortType) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid sort_type %q (use one of: %s)", sortType, strings.Join(moxfieldSortTypes(), ", "),
+		)), nil
+	}
+
+	sortDirection := "Descending"
+	if sortDirVal, ok := args["sort_direction"].(string); ok && sortDirVal != "" {
+		sortDirection = sortDirVal
+		for _, direction := range moxfieldSortDirections() {
+			if strings.EqualFold(direction, sortDirVal) {
+				sortDirection = direction
+			}
+		}
 	}
-
-	if card.Power != nil && card.Toughness != nil {
-		output.WriteString(fmt.Sprintf("**Power/Toughness:** %s/%s\n", *card.Power, *card.Toughness))
+	if !slices.Contains(moxfieldSortDirections(), sortDirection) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid sort_direction %q (use one of: %s)",
+			sortDirection, strings.Join(moxfieldSortDirections(), ", "),
+		)), nil
 	}
 
-	if card.Loyalty != nil {
-		output.WriteString(fmt.Sprintf("**Loyalty:** %s\n", *card.Loyalty))
+	const defaultPageSize = 20
+	pageSize := defaultPageSize
+	if pageSizeVal, ok := args["page_size"].(float64); ok {
+		pageSize = min(int(pageSizeVal), maxPageSize)
+	}
+	if pageSize < 1 {
+		return mcp.NewToolResultError("page_size must be 1 or greater"), nil
 	}
 
-	// Color Identity
-	if len(card.ColorIdentity) > 0 {
-		colors := make([]string, len(card.ColorIdentity))
-		for i, c := range card.ColorIdentity {
-			colors[i] = string(c)
+	pageNumber := 1
+	if pageNumberVal, ok := args["page_number"].(float64); ok {
+		if pageNumberVal < 1 {
+			return mcp.NewToolResultError("page_number must be 1 or greater"), nil
 		}
-		output.WriteString(fmt.Sprintf("**Color Identity:** %s\n", strings.Join(colors, ", ")))
+		pageNumber = int(pageNumberVal)
 	}
 
-	// Legalities
-	output.WriteString("\n**Format Legalities:**\n")
-	output.WriteString(fmt.Sprintf("- Commander: %s\n", card.Legalities.Commander))
-	output.WriteString(fmt.Sprintf("- Legacy: %s\n", card.Legalities.Legacy))
-	output.WriteString(fmt.Sprintf("- Vintage: %s\n", card.Legalities.Vintage))
-	output.WriteString(fmt.Sprintf("- Modern: %s\n", card.Legalities.Modern))
-	output.WriteString(fmt.Sprintf("- Standard: %s\n", card.Legalities.Standard))
+	GetLogger().Info().
+		Str("tool", "search_moxfield_decks").
+		Str("query", query).
+		Str("commander", commander).
+		Strs("hubs", hubs).
+		Str("format", format).
+		Str("sort_type", sortType).
+		Str("sort_direction", sortDirection).
+		Int("page_size", pageSize).
+		Int("page_number", pageNumber).
+		Msg("Searching Moxfield decks")
 
-	// Additional info
-	if card.Artist != nil {
-		output.WriteString(fmt.Sprintf("\n**Artist:** %s\n", *card.Artist))
+	params := MoxfieldSearchParams{
+		Query:         query,
+		Format:        format,
+		SortType:      sortType,
+		SortDirection: sortDirection,
+		PageSize:      pageSize,
+		PageNumber:    pageNumber,
+		CommanderName: commander,
+		Hubs:          hubs,
 	}
 
-	output.WriteString(fmt.Sprintf("\n**Scryfall Link:** %s\n", card.ScryfallURI))
+	results, err := SearchMoxfieldDecks(ctx, params)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "search_moxfield_decks").
+			Str("query", query).
+			Msg("Failed to search Moxfield decks")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to search Moxfield decks: %v%s", err, upstreamErrorHint(err),
+		)), nil
+	}
 
-	return mcp.NewToolResultText(output.String()), nil
+	GetLogger().Info().
+		Str("tool", "search_moxfield_decks").
+		Str("query", query).
+		Int("results_count", len(results.Data)).
+		Msg("Successfully searched Moxfield decks")
+
+	subject := cmp.Or(commander, query, strings.Join(hubs, ", "))
+	return mcp.NewToolResultText(FormatMoxfieldSearchForDisplay(subject, format, results)), nil
 }
 
-func (s *MTGCommanderServer) handleCheckLegality(
+func (s *MTGCommanderServer) handleGetEDHRECRecommendations(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
+	commander, err := request.RequireString("commander")
 	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_edhrec_recommendations").Msg("Missing commander parameter")
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	card, err := s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
+	limit := 10
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
+		}
 	}
 
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# Legality Check: %s\n\n", card.Name))
-
-	// Capitalize first letter for display
-	legality := string(card.Legalities.Commander)
-	status := strings.ToUpper(legality[:1]) + legality[1:]
-	output.WriteString(fmt.Sprintf("**Commander Format:** %s\n\n", status))
-
-	switch card.Legalities.Commander {
-	case scryfall.LegalityBanned:
-		output.WriteString("⚠️ This card is **BANNED** in Commander format.\n\n")
-	case scryfall.LegalityLegal:
-		output.WriteString("✅ This card is **LEGAL** in Commander format.\n\n")
-	case scryfall.LegalityNotLegal, scryfall.LegalityRestricted:
-		output.WriteString("❌ This card is **NOT LEGAL** in Commander format.\n\n")
+	setCode := ""
+	if setVal, hasSet := args["set"]; hasSet {
+		if set, ok := setVal.(string); ok {
+			setCode = strings.TrimSpace(set)
+		}
 	}
 
-	// Show all format legalities
-	output.WriteString("**All Format Legalities:**\n")
-	output.WriteString(fmt.Sprintf("- Standard: %s\n", card.Legalities.Standard))
-	output.WriteString(fmt.Sprintf("- Pioneer: %s\n", card.Legalities.Pioneer))
-	output.WriteString(fmt.Sprintf("- Modern: %s\n", card.Legalities.Modern))
-	output.WriteString(fmt.Sprintf("- Legacy: %s\n", card.Legalities.Legacy))
-	output.WriteString(fmt.Sprintf("- Vintage: %s\n", card.Legalities.Vintage))
-	output.WriteString(fmt.Sprintf("- Pauper: %s\n", card.Legalities.Pauper))
-	output.WriteString(fmt.Sprintf("- Commander: %s\n", card.Legalities.Commander))
-
-	return mcp.NewToolResultText(output.String()), nil
-}
-
-func (s *MTGCommanderServer) handleGetRulings(
-	ctx context.Context,
-	request mcp.CallToolRequest,
-) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	budget := false
+	if budgetVal, hasBudget := args["budget"]; hasBudget {
+		if b, ok := budgetVal.(bool); ok {
+			budget = b
+		}
 	}
 
-	// First get the card
-	card, err := s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
+	sortOrder := recsSortSynergyDesc
+	if sortVal, hasSort := args["sort"]; hasSort {
+		if sortStr, ok := sortVal.(string); ok && sortStr != "" {
+			sortOrder = sortStr
+		}
+	}
+	if !slices.Contains(recsSortOrders(), sortOrder) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid sort %q (use one of: %s)", sortOrder, strings.Join(recsSortOrders(), ", "),
+		)), nil
 	}
 
-	// Get rulings
-	rulings, err := s.scryfallClient.GetRulings(ctx, card.ID)
+	fresh, _ := args["fresh"].(bool)
+
+	GetLogger().Info().
+		Str("tool", "get_edhrec_recommendations").
+		Str("commander", commander).
+		Int("limit", limit).
+		Str("set", setCode).
+		Bool("budget", budget).
+		Str("sort", sortOrder).
+		Bool("fresh", fresh).
+		Msg("Fetching EDHREC recommendations")
+
+	data, err := s.cachedCommanderRecommendations(ctx, commander, budget, fresh)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get rulings: %v", err)), nil
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_edhrec_recommendations").
+			Str("commander", commander).
+			Msg("Failed to fetch EDHREC recommendations")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC recommendations: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# Rulings for %s\n\n", card.Name))
+	GetLogger().Info().
+		Str("tool", "get_edhrec_recommendations").
+		Str("commander", commander).
+		Int("num_decks", data.NumDecks).
+		Int("card_lists", len(data.CardLists)).
+		Msg("Successfully fetched EDHREC recommendations")
 
-	if len(rulings) == 0 {
-		output.WriteString("No official rulings found for this card.\n")
-	} else {
-		output.WriteString(fmt.Sprintf("Found %d ruling(s):\n\n", len(rulings)))
-		for i, ruling := range rulings {
-			output.WriteString(fmt.Sprintf("%d. **%s** (%s)\n", i+1, ruling.PublishedAt, ruling.Source))
-			output.WriteString(fmt.Sprintf("   %s\n\n", ruling.Comment))
-		}
+	if setCode != "" {
+		return s.setScopedEDHRECRecommendations(ctx, data, setCode, limit, sortOrder)
 	}
 
-	return mcp.NewToolResultText(output.String()), nil
+	output := FormatCommanderRecsForDisplay(data, limit, sortOrder)
+	return mcp.NewToolResultText(output), nil
 }
 
-func (s *MTGCommanderServer) handleGetPrice(
+func (s *MTGCommanderServer) handleGetCommanderStaples(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	name, err := request.RequireString("name")
+	commander, err := request.RequireString("commander")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	setCode := ""
-	args := request.GetArguments()
-	if setVal, hasSet := args["set"]; hasSet {
-		if set, ok := setVal.(string); ok {
-			setCode = set
-		}
+	category, err := request.RequireString("category")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	var card scryfall.Card
-	if setCode != "" {
-		// Search for specific set
-		searchQuery := fmt.Sprintf(`!"%s" set:%s`, name, setCode)
-		result, searchErr := s.scryfallClient.SearchCards(ctx, searchQuery, scryfall.SearchCardsOptions{})
-		if searchErr != nil {
-			return nil, fmt.Errorf("failed to search for card: %w", searchErr)
-		}
-		if len(result.Cards) == 0 {
-			return mcp.NewToolResultError(fmt.Sprintf("Card not found in set %s", setCode)), nil
-		}
-		card = result.Cards[0]
-	} else {
-		// Get default card
-		c, getErr := s.scryfallClient.GetCardByName(ctx, name, false, scryfall.GetCardByNameOptions{})
-		if getErr != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", getErr)), nil
-		}
-		card = c
+	args := request.GetArguments()
+	limit := defaultCommanderStaplesLimit
+	if limitVal, ok := args["limit"].(float64); ok {
+		limit = int(limitVal)
 	}
-
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# Pricing for %s\n", card.Name))
-	output.WriteString(
-		fmt.Sprintf("Set: %s (%s) #%s\n\n", card.SetName, strings.ToUpper(card.Set), card.CollectorNumber),
-	)
-
-	// Get exchange rate for BRL
-	usdToBRL, err := getUSDToBRLRate(ctx)
-	if err != nil {
-		GetLogger().Warn().Err(err).Msg("Failed to get exchange rate, using fallback")
-		usdToBRL = 5.40 // Fallback rate
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be 1 or greater"), nil
 	}
 
-	hasPricing := false
-
-	if card.Prices.USD != "" {
-		output.WriteString(fmt.Sprintf("**USD:** $%s\n", card.Prices.USD))
-		output.WriteString(fmt.Sprintf("**BRL:** R$ %.2f (converted)\n", convertToBRL(card.Prices.USD, usdToBRL)))
-		hasPricing = true
-	}
+	budget, _ := args["budget"].(bool)
 
-	if card.Prices.USDFoil != "" {
-		output.WriteString(fmt.Sprintf("**USD (Foil):** $%s\n", card.Prices.USDFoil))
-		output.WriteString(
-			fmt.Sprintf("**BRL (Foil):** R$ %.2f (converted)\n", convertToBRL(card.Prices.USDFoil, usdToBRL)),
-		)
-		hasPricing = true
+	sortOrder := recsSortSynergyDesc
+	if sortStr, ok := args["sort"].(string); ok && sortStr != "" {
+		sortOrder = sortStr
 	}
-
-	if card.Prices.EUR != "" {
-		output.WriteString(fmt.Sprintf("**EUR:** €%s\n", card.Prices.EUR))
-		hasPricing = true
+	if !slices.Contains(recsSortOrders(), sortOrder) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Invalid sort %q (use one of: %s)", sortOrder, strings.Join(recsSortOrders(), ", "),
+		)), nil
 	}
 
-	if card.Prices.EURFoil != "" {
-		output.WriteString(fmt.Sprintf("**EUR (Foil):** €%s\n", card.Prices.EURFoil))
-		hasPricing = true
-	}
+	GetLogger().Info().
+		Str("tool", "get_commander_staples").
+		Str("commander", commander).
+		Str("category", category).
+		Int("limit", limit).
+		Bool("budget", budget).
+		Msg("Fetching EDHREC category")
 
-	if card.Prices.Tix != "" {
-		output.WriteString(fmt.Sprintf("**MTGO Tix:** %s\n", card.Prices.Tix))
-		hasPricing = true
+	data, err := s.cachedCommanderRecommendations(ctx, commander, budget, false)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_commander_staples").
+			Str("commander", commander).
+			Msg("Failed to fetch EDHREC recommendations")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC recommendations: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	if !hasPricing {
-		output.WriteString("No pricing data available for this card.\n")
-	} else {
-		output.WriteString(fmt.Sprintf("\n*Exchange rate: 1 USD = %.4f BRL*\n", usdToBRL))
-		output.WriteString("*Note: BRL prices are converted from USD and may not reflect Brazilian market conditions*\n")
+	filtered, ok := FilterRecsByCategory(data, category)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Unknown category %q for %s (use one of: %s)",
+			category, data.Card.Name, strings.Join(recsCategories(data), ", "),
+		)), nil
 	}
 
-	return mcp.NewToolResultText(output.String()), nil
+	return mcp.NewToolResultText(FormatCommanderRecsForDisplay(filtered, limit, sortOrder)), nil
 }
 
-func (s *MTGCommanderServer) handleGetBannedList(
+// setScopedEDHRECRecommendations filters recommendations to cards printed in a set.
+// EDHREC card views carry no set information, so printings are resolved via Scryfall.
+func (s *MTGCommanderServer) setScopedEDHRECRecommendations(
 	ctx context.Context,
-	_ mcp.CallToolRequest,
+	data *EDHRECData,
+	setCode string,
+	limit int,
+	sortOrder string,
 ) (*mcp.CallToolResult, error) {
-	// Search for banned cards in Commander
-	searchQuery := "banned:commander"
-	result, err := s.scryfallClient.SearchCards(ctx, searchQuery, scryfall.SearchCardsOptions{
-		Order: "name",
-	})
+	names := recommendedCardNames(data)
+
+	lookup, err := s.lookupCardsInSet(ctx, names, setCode)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch banned list: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to look up cards in set %s: %v", setCode, err)), nil
 	}
 
-	var output strings.Builder
-	output.WriteString("# Commander Format Banned List\n\n")
-	output.WriteString(fmt.Sprintf("Total banned cards: %d\n\n", result.TotalCards))
+	filtered := FilterRecsByCards(data, func(name string) bool {
+		_, ok := lookup.Get(name)
+		return ok
+	})
 
-	for i, card := range result.Cards {
-		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, card.Name))
+	GetLogger().Info().
+		Str("tool", "get_edhrec_recommendations").
+		Str("set", setCode).
+		Int("candidates", len(names)).
+		Int("in_set", len(lookup.Cards)).
+		Msg("Filtered EDHREC recommendations by set")
+
+	var output strings.Builder
+	if len(filtered.CardLists) == 0 {
+		output.WriteString(fmt.Sprintf("# EDHREC Recommendations for %s\n\n", data.Card.Name))
+		output.WriteString(fmt.Sprintf(
+			"None of the %d recommended cards are printed in set %s.\n", len(names), strings.ToUpper(setCode),
+		))
+		return mcp.NewToolResultText(output.String()), nil
 	}
 
-	output.WriteString("\n*Source: Scryfall (powered by Wizards of the Coast official data)*\n")
-	output.WriteString("*Last updated: This query fetches real-time data*\n")
+	output.WriteString(FormatCommanderRecsForDisplay(filtered, limit, sortOrder))
+	output.WriteString(fmt.Sprintf(
+		"\n*Filtered to %d of %d recommended cards printed in set %s.*\n",
+		len(lookup.Cards), len(names), strings.ToUpper(setCode),
+	))
 
 	return mcp.NewToolResultText(output.String()), nil
 }
 
-// parseDecklistString converts a decklist from JSON or text format to card names.
-func parseDecklistString(decklistStr string) []string {
-	var cardNames []string
-
-	// Try JSON first
-	if unmarshalErr := json.Unmarshal([]byte(decklistStr), &cardNames); unmarshalErr != nil {
-		// Parse as text format (one card per line, optional quantity prefix)
-		cardNames = parseTextDecklist(decklistStr)
+func (s *MTGCommanderServer) handleGetEDHRECCombos(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	colors, err := request.RequireString("colors")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return cardNames
-}
-
-// parseTextDecklist parses a text-format decklist into card names.
-func parseTextDecklist(decklistStr string) []string {
-	var cardNames []string
-	lines := strings.Split(decklistStr, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
+	const defaultLimit = 10
+	limit := defaultLimit
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
 		}
-		cardNames = append(cardNames, parseCardLine(line))
-	}
-
-	return cardNames
-}
-
-// parseCardLine extracts card name from a line, removing quantity prefix if present.
-func parseCardLine(line string) string {
-	// Remove quantity prefix (e.g., "1 Sol Ring" -> "Sol Ring")
-	parts := strings.SplitN(line, " ", defaultSplitLimit)
-	if len(parts) != defaultSplitLimit {
-		return line
 	}
 
-	// Check if first part is a number
-	if _, scanErr := fmt.Sscanf(parts[0], "%d", new(int)); scanErr == nil {
-		return strings.TrimSpace(parts[1])
+	data, err := GetCombosForColors(ctx, colors)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC combos: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	return line
+	output := FormatCombosForDisplay(data, limit, s.comboManaCosts(ctx, data.CardLists, limit))
+	return mcp.NewToolResultText(output), nil
 }
 
-func (s *MTGCommanderServer) handleValidateDeck(
+// comboManaCosts looks up the mana cost of every card in the first limit combos,
+// keyed by normalized card name. Lookups are best-effort: on failure the combos
+// are shown without mana costs.
+func (s *MTGCommanderServer) comboManaCosts(
 	ctx context.Context,
-	request mcp.CallToolRequest,
-) (*mcp.CallToolResult, error) {
-	commanderName, err := request.RequireString("commander")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	combos []EDHRECComboList,
+	limit int,
+) map[string]string {
+	combos = uniqueCombos(combos)
+	if limit > 0 && len(combos) > limit {
+		combos = combos[:limit]
 	}
 
-	decklistStr, err := request.RequireString("decklist")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	var names []string
+	for _, combo := range combos {
+		names = append(names, ComboCardNames(combo)...)
+	}
+	if len(names) == 0 {
+		return nil
 	}
 
-	// Parse decklist (support both JSON array and text format)
-	cardNames := parseDecklistString(decklistStr)
-
-	var output strings.Builder
-	output.WriteString("# Commander Deck Validation\n\n")
-
-	// Get commander card
-	commander, err := s.scryfallClient.GetCardByName(ctx, commanderName, false, scryfall.GetCardByNameOptions{})
+	lookup, err := s.lookupCardsByName(ctx, names)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Commander card not found: %v", err)), nil
+		GetLogger().Warn().Err(err).Int("cards", len(names)).Msg("Failed to look up combo mana costs")
+		return nil
 	}
 
-	// Convert color identity to strings
-	colorIdentity := make([]string, len(commander.ColorIdentity))
-	for i, c := range commander.ColorIdentity {
-		colorIdentity[i] = string(c)
+	costs := make(map[string]string, len(lookup.Cards))
+	for key, card := range lookup.Cards {
+		cost := card.ManaCost
+		if cost == "" && len(card.CardFaces) > 0 {
+			cost = card.CardFaces[0].ManaCost
+		}
+		costs[key] = cost
 	}
+	return costs
+}
 
-	output.WriteString(fmt.Sprintf("**Commander:** %s\n", commander.Name))
-	output.WriteString(fmt.Sprintf("**Color Identity:** %s\n\n", strings.Join(colorIdentity, ", ")))
+func (s *MTGCommanderServer) handleGetCommanderCombos(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	commander, err := request.RequireString("commander")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	// Check if commander is legal
-	if commander.Legalities.Commander == "banned" {
-		output.WriteString("❌ **ERROR:** Your commander is banned in Commander format!\n\n")
+	const defaultLimit = 10
+	limit := defaultLimit
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
+		}
 	}
 
-	// Validate commander can be a commander
-	isLegendary := strings.Contains(strings.ToLower(commander.TypeLine), "legendary")
-	canBeCommander := isLegendary || strings.Contains(strings.ToLower(commander.OracleText), "can be your commander")
-
-	if !canBeCommander {
-		output.WriteString(
-			"❌ **ERROR:** This card cannot be a commander (must be legendary or have special text allowing it)!\n\n",
-		)
+	page, err := getCommanderPageWithURL(ctx, commander, "", s.edhrecBaseURL)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_commander_combos").
+			Str("commander", commander).
+			Msg("Failed to fetch EDHREC commander page")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC combos: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	// Check deck size
-	totalCards := len(cardNames)
-	output.WriteString(fmt.Sprintf("**Deck Size:** %d cards ", totalCards))
-	switch totalCards {
-	case deckValidationBasicCardCount:
-		output.WriteString("✅\n")
-	case deckValidationCommanderCount:
-		output.WriteString("(Note: 100 cards including commander, should be 99 in decklist)\n")
-	default:
-		output.WriteString("❌ (should be 99 cards plus commander)\n")
-	}
+	combos := ExtractCommanderCombos(page)
 
-	// Check singleton (no duplicates except basic lands)
-	cardCounts := make(map[string]int)
-	for _, name := range cardNames {
-		cardCounts[strings.ToLower(strings.TrimSpace(name))]++
-	}
+	GetLogger().Info().
+		Str("tool", "get_commander_combos").
+		Str("commander", commander).
+		Int("combos", len(combos)).
+		Msg("Fetched EDHREC commander combos")
 
-	var duplicates []string
-	basicLands := []string{"plains", "island", "swamp", "mountain", "forest", "wastes"}
-	for name, count := range cardCounts {
-		if count > 1 {
-			isBasic := false
-			for _, basic := range basicLands {
-				if name == basic {
-					isBasic = true
-					break
-				}
-			}
-			if !isBasic {
-				duplicates = append(duplicates, fmt.Sprintf("%s (x%d)", name, count))
-			}
+	return mcp.NewToolResultText(FormatCommanderCombosForDisplay(&page.Container.JSONDict, combos, limit)), nil
+}
+
+func (s *MTGCommanderServer) handleGetSaltList(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	page := 1
+	args := request.GetArguments()
+	if pageVal, hasPage := args["page"]; hasPage {
+		if pageFloat, ok := pageVal.(float64); ok {
+			page = int(pageFloat)
 		}
 	}
+	if page < 1 {
+		return mcp.NewToolResultError("page must be 1 or greater"), nil
+	}
 
-	output.WriteString("\n**Singleton Rule:** ")
-	if len(duplicates) == 0 {
-		output.WriteString("✅ No duplicates\n")
-	} else {
-		output.WriteString("❌ Found duplicates:\n")
-		for _, dup := range duplicates {
-			output.WriteString(fmt.Sprintf("  - %s\n", dup))
-		}
+	cards, err := getTopCardsForCategoryWithURL(ctx, "salt", page, s.edhrecBaseURL)
+	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_salt_list").Int("page", page).Msg("Failed to fetch salt list")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC salt list: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	output.WriteString(
-		"\n*Note: Full color identity and banned card validation requires checking each card individually, which may take some time.*",
-	)
+	GetLogger().Info().
+		Str("tool", "get_salt_list").
+		Int("page", page).
+		Int("cards", len(cards)).
+		Msg("Fetched EDHREC salt list")
 
-	return mcp.NewToolResultText(output.String()), nil
+	return mcp.NewToolResultText(FormatSaltListForDisplay(cards, page)), nil
 }
 
-func (s *MTGCommanderServer) handleGetMoxfieldDeck(
+func (s *MTGCommanderServer) handleGetCommanderThemes(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	deckID, err := request.RequireString("deck_id")
+	commander, err := request.RequireString("commander")
 	if err != nil {
-		GetLogger().Error().Err(err).Str("tool", "get_moxfield_deck").Msg("Missing deck_id parameter")
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Extract public ID if URL was provided
-	publicID := ExtractPublicIDFromURL(deckID)
-
-	GetLogger().Info().
-		Str("tool", "get_moxfield_deck").
-		Str("deck_id", publicID).
-		Msg("Fetching Moxfield deck")
+	const defaultLimit = 15
+	limit := defaultLimit
+	args := request.GetArguments()
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
+		}
+	}
 
-	deck, err := GetMoxfieldDeck(ctx, publicID)
+	page, err := getCommanderPageWithURL(ctx, commander, "", s.edhrecBaseURL)
 	if err != nil {
 		GetLogger().Error().
 			Err(err).
-			Str("tool", "get_moxfield_deck").
-			Str("deck_id", publicID).
-			Msg("Failed to fetch Moxfield deck")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch Moxfield deck: %v", err)), nil
+			Str("tool", "get_commander_themes").
+			Str("commander", commander).
+			Msg("Failed to fetch EDHREC commander page")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC themes: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
+	themes := ExtractCommanderThemes(page)
+
 	GetLogger().Info().
-		Str("tool", "get_moxfield_deck").
-		Str("deck_id", publicID).
-		Str("deck_name", deck.Name).
-		Str("format", deck.Format).
-		Int("mainboard_cards", len(deck.Mainboard)).
-		Msg("Successfully fetched Moxfield deck")
+		Str("tool", "get_commander_themes").
+		Str("commander", commander).
+		Int("themes", len(themes)).
+		Msg("Fetched EDHREC commander themes")
 
-	output := FormatDeckForDisplay(deck)
-	return mcp.NewToolResultText(output), nil
+	return mcp.NewToolResultText(FormatThemesForDisplay(&page.Container.JSONDict, themes, limit)), nil
 }
 
-func (s *MTGCommanderServer) handleGetMoxfieldUserDecks(
+func (s *MTGCommanderServer) handleExplainCommander(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	username, err := request.RequireString("username")
+	name, err := request.RequireString("commander")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	const defaultPageSize = 20
-	pageSize := defaultPageSize
-	args := request.GetArguments()
-	if pageSizeVal, hasPageSize := args["page_size"]; hasPageSize {
-		if pageSizeFloat, ok := pageSizeVal.(float64); ok {
-			pageSize = int(pageSizeFloat)
-			if pageSize > maxPageSize {
-				pageSize = maxPageSize
-			}
-		}
+	card, err := s.cachedGetCardByName(ctx, name)
+	if err != nil {
+		GetLogger().Warn().Err(err).Str("tool", "explain_commander").Str("commander", name).Msg("Card lookup failed")
+		return s.cardNotFoundResult(ctx, name, err), nil
 	}
 
-	decks, err := GetUserDecks(ctx, username, pageSize)
+	rulings, err := s.scryfallClient.GetRulings(ctx, card.ID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch user decks: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get rulings: %v", err)), nil
 	}
 
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# Decks by %s\n\n", username))
-	output.WriteString(fmt.Sprintf("**Total Decks:** %d\n", decks.TotalResults))
-	output.WriteString(
-		fmt.Sprintf("**Showing:** %d decks (Page %d of %d)\n\n", len(decks.Data), decks.PageNumber, decks.TotalPages),
-	)
-
-	for i, deck := range decks.Data {
-		output.WriteString(fmt.Sprintf("%d. **%s** (%s)\n", i+1, deck.Name, deck.Format))
-		output.WriteString(fmt.Sprintf("   - Deck ID: %s\n", deck.PublicID))
-		output.WriteString(fmt.Sprintf("   - Views: %d | Likes: %d\n", deck.ViewCount, deck.LikeCount))
-		output.WriteString(fmt.Sprintf("   - URL: %s\n\n", deck.PublicURL))
+	// The overview is still useful without EDHREC, so a failure only drops the themes
+	var data *EDHRECData
+	var themes []EDHRECTheme
+	page, err := getCommanderPageWithURL(ctx, card.Name, "", s.edhrecBaseURL)
+	if err != nil {
+		GetLogger().Warn().
+			Err(err).
+			Str("tool", "explain_commander").
+			Str("commander", card.Name).
+			Msg("EDHREC commander page unavailable, skipping themes")
+	} else {
+		data = &page.Container.JSONDict
+		themes = ExtractCommanderThemes(page)
 	}
 
-	return mcp.NewToolResultText(output.String()), nil
+	GetLogger().Info().
+		Str("tool", "explain_commander").
+		Str("commander", card.Name).
+		Int("rulings", len(rulings)).
+		Int("themes", len(themes)).
+		Msg("Built commander overview")
+
+	return mcp.NewToolResultText(FormatCommanderOverviewForDisplay(card, rulings, data, themes)), nil
 }
 
-func (s *MTGCommanderServer) handleSearchMoxfieldDecks(
+func (s *MTGCommanderServer) handleSimilarCards(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	commander, err := request.RequireString("commander")
+	name, err := request.RequireString("name")
 	if err != nil {
-		GetLogger().Error().Err(err).Str("tool", "search_moxfield_decks").Msg("Missing commander parameter")
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Get optional parameters
+	const defaultLimit = 10
+	limit := defaultLimit
 	args := request.GetArguments()
-
-	format := "commander"
-	if formatVal, hasFormat := args["format"]; hasFormat {
-		if formatStr, ok := formatVal.(string); ok {
-			format = formatStr
+	if limitVal, hasLimit := args["limit"]; hasLimit {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
 		}
 	}
 
-	sortType := "updated"
-	if sortTypeVal, hasSortType := args["sort_type"]; hasSortType {
-		if sortTypeStr, ok := sortTypeVal.(string); ok {
-			sortType = sortTypeStr
-		}
+	card, err := s.cachedGetCardByName(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", err)), nil
 	}
 
-	sortDirection := "Descending"
-	if sortDirVal, hasSortDir := args["sort_direction"]; hasSortDir {
-		if sortDirStr, ok := sortDirVal.(string); ok {
-			sortDirection = sortDirStr
+	roles := classifyCardFunction(card)
+
+	// EDHREC similarity data is best-effort; fall back to rules-text matching alone.
+	var edhrecSimilar []EDHRECCardView
+	page, err := getCardPageWithURL(ctx, card.Name, s.edhrecBaseURL)
+	if err != nil {
+		GetLogger().Warn().
+			Err(err).
+			Str("tool", "similar_cards").
+			Str("card", card.Name).
+			Msg("EDHREC card page unavailable")
+	} else if len(page.Similar) > 0 {
+		// EDHREC's similar cards ignore color identity and legality, so check them against Scryfall
+		names := make([]string, 0, len(page.Similar))
+		for _, view := range page.Similar {
+			names = append(names, view.Name)
+		}
+		lookup, lookupErr := s.lookupCardsByName(ctx, names)
+		if lookupErr != nil {
+			GetLogger().Warn().
+				Err(lookupErr).
+				Str("tool", "similar_cards").
+				Str("card", card.Name).
+				Msg("Couldn't check EDHREC similar cards, skipping them")
+		} else {
+			edhrecSimilar = filterSimilarViews(card, page.Similar, lookup)
 		}
 	}
 
-	const defaultPageSize = 20
-	pageSize := defaultPageSize
-	if pageSizeVal, hasPageSize := args["page_size"]; hasPageSize {
-		if pageSizeFloat, ok := pageSizeVal.(float64); ok {
-			pageSize = int(pageSizeFloat)
-			if pageSize > maxPageSize {
-				pageSize = maxPageSize
-			}
+	roleMatches := make([][]scryfall.Card, len(roles))
+	for i, role := range roles {
+		query := buildSimilarCardsQuery(card, role)
+		result, searchErr := s.scryfallClient.SearchCards(ctx, query, scryfall.SearchCardsOptions{
+			Order: scryfall.OrderEDHREC,
+		})
+		if searchErr != nil {
+			GetLogger().Debug().Err(searchErr).Str("role", role.Name).Msg("No Scryfall matches for role")
+			continue
 		}
+		roleMatches[i] = result.Cards
 	}
 
+	candidates := buildSimilarCards(card, roles, edhrecSimilar, roleMatches, limit)
+
 	GetLogger().Info().
-		Str("tool", "search_moxfield_decks").
-		Str("commander", commander).
-		Str("format", format).
-		Str("sort_type", sortType).
-		Int("page_size", pageSize).
-		Msg("Searching Moxfield decks")
+		Str("tool", "similar_cards").
+		Str("card", card.Name).
+		Int("roles", len(roles)).
+		Int("candidates", len(candidates)).
+		Msg("Found similar cards")
 
-	params := MoxfieldSearchParams{
-		Query:         commander,
-		Format:        format,
-		SortType:      sortType,
-		SortDirection: sortDirection,
-		PageSize:      pageSize,
-		PageNumber:    1,
+	return mcp.NewToolResultText(FormatSimilarCardsForDisplay(card, roles, candidates)), nil
+}
+
+func (s *MTGCommanderServer) handleAverageDeckPrice(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	commander, err := request.RequireString("commander")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	results, err := SearchMoxfieldDecks(ctx, params)
+	GetLogger().Info().
+		Str("tool", "average_deck_price").
+		Str("commander", commander).
+		Msg("Fetching EDHREC average deck")
+
+	avgDeck, err := getAverageDeckWithURL(ctx, commander, s.edhrecBaseURL)
 	if err != nil {
 		GetLogger().Error().
 			Err(err).
-			Str("tool", "search_moxfield_decks").
+			Str("tool", "average_deck_price").
 			Str("commander", commander).
-			Msg("Failed to search Moxfield decks")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Moxfield decks: %v", err)), nil
+			Msg("Failed to fetch EDHREC average deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC average deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf("# Moxfield Decks for %s\n\n", commander))
-	output.WriteString(fmt.Sprintf("**Format:** %s\n", format))
-	output.WriteString(fmt.Sprintf("**Total Results:** %d\n", results.TotalResults))
-	output.WriteString(
-		fmt.Sprintf(
-			"**Showing:** %d decks (Page %d of %d)\n\n",
-			len(results.Data),
-			results.PageNumber,
-			results.TotalPages,
-		),
-	)
+	entries := avgDeck.Entries()
+	if len(entries) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("EDHREC has no average deck for %s", commander)), nil
+	}
 
-	if len(results.Data) == 0 {
-		output.WriteString("No decks found for this commander.\n")
-	} else {
-		for i, deck := range results.Data {
-			output.WriteString(fmt.Sprintf("## %d. %s\n", i+1, deck.Name))
-			output.WriteString(fmt.Sprintf("- **Format:** %s\n", deck.Format))
-			output.WriteString(fmt.Sprintf("- **Deck ID:** %s\n", deck.PublicID))
-			output.WriteString(fmt.Sprintf("- **Views:** %d | **Likes:** %d\n", deck.ViewCount, deck.LikeCount))
-			output.WriteString(fmt.Sprintf("- **URL:** %s\n\n", deck.PublicURL))
-		}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+
+	lookup, err := s.lookupCardsByName(ctx, names)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to price average deck: %v", err)), nil
+	}
+
+	usdToBRL, err := getUSDToBRLRate(ctx)
+	if err != nil {
+		GetLogger().Warn().Err(err).Msg("Failed to get exchange rate, using fallback")
+		usdToBRL = fallbackUSDToBRLRate
 	}
 
+	summary := summarizeDeckPrices(entries, lookup)
+
 	GetLogger().Info().
-		Str("tool", "search_moxfield_decks").
+		Str("tool", "average_deck_price").
 		Str("commander", commander).
-		Int("results_count", len(results.Data)).
-		Msg("Successfully searched Moxfield decks")
+		Float64("total_usd", summary.TotalUSD).
+		Int("unpriced", len(summary.Unpriced)).
+		Msg("Priced EDHREC average deck")
 
-	return mcp.NewToolResultText(output.String()), nil
+	name := avgDeck.Container.JSONDict.Card.Name
+	if name == "" {
+		name = commander
+	}
+
+	return mcp.NewToolResultText(FormatAverageDeckPriceForDisplay(name, summary, usdToBRL)), nil
 }
 
-func (s *MTGCommanderServer) handleGetEDHRECRecommendations(
+func (s *MTGCommanderServer) handleGetAverageDeck(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
 	commander, err := request.RequireString("commander")
 	if err != nil {
-		GetLogger().Error().Err(err).Str("tool", "get_edhrec_recommendations").Msg("Missing commander parameter")
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	limit := 10
-	args := request.GetArguments()
-	if limitVal, hasLimit := args["limit"]; hasLimit {
-		if limitFloat, ok := limitVal.(float64); ok {
-			limit = int(limitFloat)
-		}
-	}
-
 	GetLogger().Info().
-		Str("tool", "get_edhrec_recommendations").
+		Str("tool", "get_average_deck").
 		Str("commander", commander).
-		Int("limit", limit).
-		Msg("Fetching EDHREC recommendations")
+		Msg("Fetching EDHREC average deck")
 
-	data, err := GetCommanderRecommendations(ctx, commander)
+	avgDeck, err := getAverageDeckWithURL(ctx, commander, s.edhrecBaseURL)
 	if err != nil {
 		GetLogger().Error().
 			Err(err).
-			Str("tool", "get_edhrec_recommendations").
+			Str("tool", "get_average_deck").
 			Str("commander", commander).
-			Msg("Failed to fetch EDHREC recommendations")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch EDHREC recommendations: %v", err)), nil
+			Msg("Failed to fetch EDHREC average deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch EDHREC average deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	GetLogger().Info().
-		Str("tool", "get_edhrec_recommendations").
-		Str("commander", commander).
-		Int("num_decks", data.NumDecks).
-		Int("card_lists", len(data.CardLists)).
-		Msg("Successfully fetched EDHREC recommendations")
+	if len(avgDeck.Entries()) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("EDHREC has no average deck for %s", commander)), nil
+	}
 
-	output := FormatCommanderRecsForDisplay(data, limit)
-	return mcp.NewToolResultText(output), nil
+	return mcp.NewToolResultText(FormatDeckAsText(avgDeck.ToDeck(commander))), nil
 }
 
-func (s *MTGCommanderServer) handleGetEDHRECCombos(
+func (s *MTGCommanderServer) handleServerInfo(
+	ctx context.Context,
+	_ mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	statuses := checkServices(ctx, s.serviceEndpoints())
+
+	event := GetLogger().Info().Str("tool", "server_info").Str("version", version)
+	for _, status := range statuses {
+		event = event.Bool(strings.ToLower(status.name)+"_ok", status.ok())
+	}
+	event.Msg("Checked service reachability")
+
+	recent := scryfallRequests.count(time.Now())
+	return mcp.NewToolResultText(FormatServerInfoForDisplay(s.offlineIndex != nil, recent, statuses)), nil
+}
+
+func (s *MTGCommanderServer) handleGetArchidektDeck(
 	ctx context.Context,
 	request mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	colors, err := request.RequireString("colors")
+	deckID, err := request.RequireString("deck_id")
 	if err != nil {
+		GetLogger().Error().Err(err).Str("tool", "get_archidekt_deck").Msg("Missing deck_id parameter")
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	const defaultLimit = 10
-	limit := defaultLimit
-	args := request.GetArguments()
-	if limitVal, hasLimit := args["limit"]; hasLimit {
-		if limitFloat, ok := limitVal.(float64); ok {
-			limit = int(limitFloat)
-		}
-	}
+	deckID = ExtractArchidektIDFromURL(deckID)
 
-	data, err := GetCombosForColors(ctx, colors)
+	GetLogger().Info().
+		Str("tool", "get_archidekt_deck").
+		Str("deck_id", deckID).
+		Msg("Fetching Archidekt deck")
+
+	deck, err := getArchidektDeckWithURL(ctx, deckID, s.archidektBaseURL)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch EDHREC combos: %v", err)), nil
+		GetLogger().Error().
+			Err(err).
+			Str("tool", "get_archidekt_deck").
+			Str("deck_id", deckID).
+			Msg("Failed to fetch Archidekt deck")
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Failed to fetch Archidekt deck: %v%s", err, upstreamErrorHint(err),
+		)), nil
 	}
 
-	output := FormatCombosForDisplay(data, limit)
-	return mcp.NewToolResultText(output), nil
+	GetLogger().Info().
+		Str("tool", "get_archidekt_deck").
+		Str("deck_id", deckID).
+		Str("deck_name", deck.Name).
+		Int("cards", len(deck.Cards)).
+		Msg("Successfully fetched Archidekt deck")
+
+	return mcp.NewToolResultText(FormatDeckForDisplay(deck.ToDeck(), nil)), nil
 }
 
 // Resource Handlers
@@ -1099,29 +4024,12 @@ func (s *MTGCommanderServer) handleBannedListResource(
 	ctx context.Context,
 	request mcp.ReadResourceRequest,
 ) ([]mcp.ResourceContents, error) {
-	// Fetch current banned list from Scryfall
-	result, err := s.scryfallClient.SearchCards(ctx, "banned:commander", scryfall.SearchCardsOptions{
-		Order: "name",
-	})
+	banned, err := s.getBannedList(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch banned list: %w", err)
 	}
 
-	bannedCards := make([]map[string]string, len(result.Cards))
-	for i, card := range result.Cards {
-		bannedCards[i] = map[string]string{
-			"name":      card.Name,
-			"type":      card.TypeLine,
-			"mana_cost": card.ManaCost,
-		}
-	}
-
-	data, err := json.MarshalIndent(map[string]interface{}{
-		"format":       "commander",
-		"total_banned": result.TotalCards,
-		"cards":        bannedCards,
-		"last_updated": "real-time",
-	}, "", "  ")
+	data, err := json.MarshalIndent(newBannedListJSON(banned, time.Now()), "", "  ")
 	if err != nil {
 		return nil, err
 	}
@@ -1135,33 +4043,46 @@ func (s *MTGCommanderServer) handleBannedListResource(
 	}, nil
 }
 
-// Helper functions
-
-// getUSDToBRLRate fetches the current USD to BRL exchange rate.
-func getUSDToBRLRate(ctx context.Context) (float64, error) {
-	// Use Frankfurter API for currency conversion (free, no API key needed)
-	resp, err := HTTPGet(ctx, "https://api.frankfurter.app/latest?from=USD&to=BRL")
-	if err != nil {
-		return 0, err
+func (s *MTGCommanderServer) handleEDHRECCommanderResource(
+	ctx context.Context,
+	request mcp.ReadResourceRequest,
+) ([]mcp.ResourceContents, error) {
+	// URI template variables arrive as a list of values
+	var name string
+	switch value := request.Params.Arguments["name"].(type) {
+	case string:
+		name = value
+	case []string:
+		name = strings.Join(value, " ")
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
 	}
-	defer resp.Body.Close()
 
-	var result struct {
-		Rates struct {
-			BRL float64 `json:"BRL"`
-		} `json:"rates"`
+	if SanitizeCardName(name) == "" {
+		return nil, fmt.Errorf("invalid commander name in %s", request.Params.URI)
 	}
 
-	if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
-		return 0, decodeErr
+	data, err := s.cachedCommanderRecommendations(ctx, name, false, false)
+	if err != nil {
+		GetLogger().Error().
+			Err(err).
+			Str("resource", request.Params.URI).
+			Str("commander", name).
+			Msg("Failed to fetch EDHREC recommendations")
+		return nil, fmt.Errorf("failed to fetch EDHREC data for %s: %w", name, err)
 	}
 
-	return result.Rates.BRL, nil
-}
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
 
-// convertToBRL converts a USD price string to BRL using the given exchange rate.
-func convertToBRL(priceStr string, rate float64) float64 {
-	var price float64
-	_, _ = fmt.Sscanf(priceStr, "%f", &price)
-	return price * rate
+	return []mcp.ResourceContents{
+		&mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(jsonData),
+		},
+	}, nil
 }
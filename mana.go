@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Mana cost display formats.
+const (
+	// manaFormatSymbols shows costs as Scryfall's brace symbols, e.g. {2}{W}{U}.
+	manaFormatSymbols = "symbols"
+	// manaFormatCompact drops the braces, e.g. 2WU, for plain-text and ASCII-only clients.
+	manaFormatCompact = "compact"
+)
+
+// manaFormats returns the supported mana cost display formats.
+func manaFormats() []string {
+	return []string{manaFormatSymbols, manaFormatCompact}
+}
+
+// FormatManaCost renders a mana cost in the given format. The compact format writes
+// plain symbols without braces ("{2}{W}{U}" -> "2WU") and parenthesizes hybrid and
+// Phyrexian symbols so they stay unambiguous ("{W/U}" -> "(W/U)", "{W/P}" -> "(W/P)").
+// Multi-faced costs keep their " // " separator. Unknown formats return the cost unchanged.
+func FormatManaCost(cost, format string) string {
+	if format != manaFormatCompact {
+		return cost
+	}
+
+	return manaSymbolPattern.ReplaceAllStringFunc(cost, func(symbol string) string {
+		inner := strings.ToUpper(symbol[1 : len(symbol)-1])
+		if strings.Contains(inner, "/") {
+			return "(" + inner + ")"
+		}
+		return inner
+	})
+}
+
+// manaFormatArg reads a tool's optional mana_format argument, defaulting to symbols.
+func manaFormatArg(args map[string]any) (string, error) {
+	format, _ := args["mana_format"].(string)
+	if format == "" {
+		return manaFormatSymbols, nil
+	}
+
+	format = strings.ToLower(format)
+	if !slices.Contains(manaFormats(), format) {
+		return "", fmt.Errorf("invalid mana_format %q (use one of: %s)", format, strings.Join(manaFormats(), ", "))
+	}
+	return format, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+func TestFormatManaCost(t *testing.T) {
+	tests := []struct {
+		cost   string
+		format string
+		want   string
+	}{
+		{cost: "{2}{W}{U}", format: manaFormatSymbols, want: "{2}{W}{U}"},
+		{cost: "{2}{W}{U}", format: manaFormatCompact, want: "2WU"},
+		{cost: "{10}{G}{G}", format: manaFormatCompact, want: "10GG"},
+		{cost: "{X}{R}", format: manaFormatCompact, want: "XR"},
+		{cost: "{W/U}{W/U}", format: manaFormatCompact, want: "(W/U)(W/U)"},
+		{cost: "{2/B}", format: manaFormatCompact, want: "(2/B)"},
+		{cost: "{1}{W/P}", format: manaFormatCompact, want: "1(W/P)"},
+		{cost: "{G/U/P}", format: manaFormatCompact, want: "(G/U/P)"},
+		{cost: "{1}{U} // {U}", format: manaFormatCompact, want: "1U // U"},
+		{cost: "{C}", format: manaFormatCompact, want: "C"},
+		{cost: "", format: manaFormatCompact, want: ""},
+		{cost: "{W/P}", format: "unknown", want: "{W/P}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.cost, func(t *testing.T) {
+			if got := FormatManaCost(tt.cost, tt.format); got != tt.want {
+				t.Errorf("FormatManaCost(%q, %q) = %q, want %q", tt.cost, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManaFormatArg(t *testing.T) {
+	tests := []struct {
+		args    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{args: map[string]any{}, want: manaFormatSymbols},
+		{args: map[string]any{"mana_format": "Compact"}, want: manaFormatCompact},
+		{args: map[string]any{"mana_format": "unicode"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := manaFormatArg(tt.args)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("manaFormatArg(%v) = %q, %v; want %q (error %v)", tt.args, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
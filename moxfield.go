@@ -5,9 +5,45 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultMoxfieldRPS is the default cap on outbound Moxfield requests per second.
+	defaultMoxfieldRPS = 2.0
+	// moxfieldRPSEnvVar overrides defaultMoxfieldRPS.
+	moxfieldRPSEnvVar = "MOXFIELD_RPS"
+	// moxfieldAPIURL is the Moxfield API root for deck and user lookups.
+	moxfieldAPIURL = "https://api.moxfield.com/v2"
 )
 
+// moxfieldLimiter throttles all outbound Moxfield requests; Moxfield's API is strict
+// and throttles bursts of deck lookups.
+var moxfieldLimiter = rate.NewLimiter(defaultMoxfieldRPS, 1) //nolint:gochecknoglobals // shared limiter
+
+// ConfigureMoxfieldRateLimit sets the Moxfield request rate from the MOXFIELD_RPS
+// environment variable, falling back to defaultMoxfieldRPS when unset or invalid.
+func ConfigureMoxfieldRateLimit() float64 {
+	rps := defaultMoxfieldRPS
+	if value := os.Getenv(moxfieldRPSEnvVar); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			GetLogger().Warn().Str("value", value).Msgf("Invalid %s, using default", moxfieldRPSEnvVar)
+		} else {
+			rps = parsed
+		}
+	}
+
+	moxfieldLimiter.SetLimit(rate.Limit(rps))
+	return rps
+}
+
 // MoxfieldDeck represents a deck from Moxfield.
 type MoxfieldDeck struct {
 	ID           string                       `json:"id"`
@@ -18,6 +54,7 @@ type MoxfieldDeck struct {
 	Mainboard    map[string]MoxfieldCardEntry `json:"mainboard"`
 	Sideboard    map[string]MoxfieldCardEntry `json:"sideboard"`
 	Commanders   map[string]MoxfieldCardEntry `json:"commanders"`
+	Companions   map[string]MoxfieldCardEntry `json:"companions"`
 	Maybeboard   map[string]MoxfieldCardEntry `json:"maybeboard"`
 	CreatedAt    string                       `json:"createdAtUtc"`
 	LastUpdated  string                       `json:"lastUpdatedAtUtc"`
@@ -35,11 +72,14 @@ type MoxfieldCardEntry struct {
 
 // MoxfieldCardInfo represents card information.
 type MoxfieldCardInfo struct {
-	Name     string `json:"name"`
-	Set      string `json:"set"`
-	TypeLine string `json:"type_line"`
-	ManaCost string `json:"mana_cost"`
-	Rarity   string `json:"rarity"`
+	Name     string  `json:"name"`
+	Set      string  `json:"set"`
+	TypeLine string  `json:"type_line"`
+	ManaCost string  `json:"mana_cost"`
+	CMC      float64 `json:"cmc"`
+	Rarity   string  `json:"rarity"`
+	// ColorIdentity lists the card's color identity as color letters (e.g., ["W", "U"]).
+	ColorIdentity []string `json:"color_identity"`
 }
 
 // MoxfieldUserDecksResponse represents paginated user decks.
@@ -78,18 +118,37 @@ type MoxfieldSearchParams struct {
 	SortDirection string
 	PageSize      int
 	PageNumber    int
+	// CommanderName limits results to decks led by this commander.
+	CommanderName string
+	// Hubs limits results to decks tagged with all of these Moxfield hubs (e.g. "cEDH", "Budget").
+	Hubs []string
 }
 
 // GetMoxfieldDeck fetches a deck by its public ID.
 func GetMoxfieldDeck(ctx context.Context, publicID string) (*MoxfieldDeck, error) {
-	return getMoxfieldDeckWithURL(ctx, publicID, "https://api.moxfield.com/v2")
+	return getMoxfieldDeckWithURL(ctx, publicID, moxfieldAPIURL)
 }
 
 // getMoxfieldDeckWithURL fetches a deck with a custom base URL.
 func getMoxfieldDeckWithURL(ctx context.Context, publicID, baseURL string) (*MoxfieldDeck, error) {
-	url := fmt.Sprintf("%s/decks/all/%s", baseURL, publicID)
+	return fetchMoxfieldDeck(ctx, fmt.Sprintf("%s/decks/all/%s", baseURL, publicID), publicID)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// GetMoxfieldDeckVersion fetches a deck as it was at a version from its change history.
+func GetMoxfieldDeckVersion(ctx context.Context, publicID, versionID string) (*MoxfieldDeck, error) {
+	return getMoxfieldDeckVersionWithURL(ctx, publicID, versionID, moxfieldAPIURL)
+}
+
+// getMoxfieldDeckVersionWithURL fetches a deck version with a custom base URL.
+func getMoxfieldDeckVersionWithURL(ctx context.Context, publicID, versionID, baseURL string) (*MoxfieldDeck, error) {
+	requestURL := fmt.Sprintf("%s/decks/all/%s/versions/%s", baseURL, publicID, url.PathEscape(versionID))
+	return fetchMoxfieldDeck(ctx, requestURL, publicID+" version "+versionID)
+}
+
+// fetchMoxfieldDeck fetches and decodes a deck from a Moxfield deck endpoint.
+// subject names the deck in status errors.
+func fetchMoxfieldDeck(ctx context.Context, requestURL, subject string) (*MoxfieldDeck, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,15 +157,14 @@ func getMoxfieldDeckWithURL(ctx context.Context, publicID, baseURL string) (*Mox
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetryWait(sharedHTTPClient, req, moxfieldLimiter.Wait)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("moxfield API returned status %d", resp.StatusCode)
+		return nil, newStatusError("moxfield API", subject, resp.StatusCode)
 	}
 
 	var deck MoxfieldDeck
@@ -119,7 +177,7 @@ func getMoxfieldDeckWithURL(ctx context.Context, publicID, baseURL string) (*Mox
 
 // GetUserDecks fetches a user's deck list.
 func GetUserDecks(ctx context.Context, username string, pageSize int) (*MoxfieldUserDecksResponse, error) {
-	return getUserDecksWithURL(ctx, username, pageSize, "https://api.moxfield.com/v2")
+	return getUserDecksWithURL(ctx, username, pageSize, moxfieldAPIURL)
 }
 
 // getUserDecksWithURL fetches user decks with a custom base URL.
@@ -145,15 +203,14 @@ func getUserDecksWithURL(
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetryWait(sharedHTTPClient, req, moxfieldLimiter.Wait)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("moxfield API returned status %d", resp.StatusCode)
+		return nil, newStatusError("moxfield API", username, resp.StatusCode)
 	}
 
 	var decksResp MoxfieldUserDecksResponse
@@ -164,6 +221,16 @@ func getUserDecksWithURL(
 	return &decksResp, nil
 }
 
+// moxfieldSortTypes returns the sort types Moxfield's deck search accepts.
+func moxfieldSortTypes() []string {
+	return []string{"updated", "created", "views", "likes", "name"}
+}
+
+// moxfieldSortDirections returns the sort directions Moxfield's deck search accepts.
+func moxfieldSortDirections() []string {
+	return []string{"Ascending", "Descending"}
+}
+
 // SearchMoxfieldDecks searches for decks on Moxfield.
 func SearchMoxfieldDecks(ctx context.Context, params MoxfieldSearchParams) (*MoxfieldSearchResponse, error) {
 	return searchMoxfieldDecksWithURL(ctx, params, "https://api2.moxfield.com/v2/decks/search")
@@ -185,23 +252,29 @@ func searchMoxfieldDecksWithURL(
 	}
 
 	// Build query parameters
-	url := fmt.Sprintf("%s?pageSize=%d&pageNumber=%d",
+	requestURL := fmt.Sprintf("%s?pageSize=%d&pageNumber=%d",
 		searchURL, params.PageSize, params.PageNumber)
 
 	if params.Query != "" {
-		url += fmt.Sprintf("&board=commanders&query=%s", params.Query)
+		requestURL += fmt.Sprintf("&board=commanders&query=%s", url.QueryEscape(params.Query))
 	}
 	if params.Format != "" {
-		url += fmt.Sprintf("&fmt=%s", params.Format)
+		requestURL += fmt.Sprintf("&fmt=%s", url.QueryEscape(params.Format))
 	}
 	if params.SortType != "" {
-		url += fmt.Sprintf("&sortType=%s", params.SortType)
+		requestURL += fmt.Sprintf("&sortType=%s", url.QueryEscape(params.SortType))
 	}
 	if params.SortDirection != "" {
-		url += fmt.Sprintf("&sortDirection=%s", params.SortDirection)
+		requestURL += fmt.Sprintf("&sortDirection=%s", url.QueryEscape(params.SortDirection))
+	}
+	if params.CommanderName != "" {
+		requestURL += fmt.Sprintf("&commander=%s", url.QueryEscape(params.CommanderName))
+	}
+	if len(params.Hubs) > 0 {
+		requestURL += fmt.Sprintf("&hubs=%s", url.QueryEscape(strings.Join(params.Hubs, ",")))
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -210,15 +283,14 @@ func searchMoxfieldDecksWithURL(
 	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doWithRateLimitRetryWait(sharedHTTPClient, req, moxfieldLimiter.Wait)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("moxfield search API returned status %d", resp.StatusCode)
+		return nil, newStatusError("moxfield search API", "", resp.StatusCode)
 	}
 
 	var searchResp MoxfieldSearchResponse
@@ -229,16 +301,48 @@ func searchMoxfieldDecksWithURL(
 	return &searchResp, nil
 }
 
-// ExtractPublicIDFromURL extracts the public ID from a Moxfield URL.
-func ExtractPublicIDFromURL(url string) string {
-	// Expected format: https://www.moxfield.com/decks/{publicId}
-	parts := strings.Split(url, "/")
+// FormatMoxfieldSearchForDisplay formats one page of deck search results.
+func FormatMoxfieldSearchForDisplay(query, format string, results *MoxfieldSearchResponse) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Moxfield Decks for %s\n\n", query))
+	output.WriteString(fmt.Sprintf("**Format:** %s\n", format))
+	output.WriteString(fmt.Sprintf("**Total Results:** %d\n", results.TotalResults))
+	output.WriteString(fmt.Sprintf(
+		"**Showing:** %d decks (Page %d of %d)\n\n", len(results.Data), results.PageNumber, results.TotalPages,
+	))
+
+	if len(results.Data) == 0 {
+		output.WriteString("No decks found.\n")
+		return output.String()
+	}
+
+	for i, deck := range results.Data {
+		output.WriteString(fmt.Sprintf("%d. **%s** (%s)\n", i+1, deck.Name, deck.Format))
+		output.WriteString(fmt.Sprintf("   - Deck ID: %s\n", deck.PublicID))
+		output.WriteString(fmt.Sprintf("   - Views: %d | Likes: %d\n", deck.ViewCount, deck.LikeCount))
+		output.WriteString(fmt.Sprintf("   - URL: %s\n\n", deck.PublicURL))
+	}
+
+	return output.String()
+}
+
+// ExtractPublicIDFromURL extracts the public ID from a Moxfield URL: always the path
+// segment right after "decks", whatever follows it.
+func ExtractPublicIDFromURL(deckURL string) string {
+	// Expected format: https://www.moxfield.com/decks/{publicId}, optionally followed by
+	// a subpage such as /primer, /export, or /history/{version}, a query, or a fragment
+	parsed, err := url.Parse(deckURL)
+	if err != nil {
+		return deckURL
+	}
+
+	parts := strings.Split(parsed.Path, "/")
 	for i, part := range parts {
-		if part == "decks" && i+1 < len(parts) {
+		if part == "decks" && i+1 < len(parts) && parts[i+1] != "" {
 			return parts[i+1]
 		}
 	}
-	return url // Return as-is if no parsing needed
+	return deckURL // Return as-is if no parsing needed
 }
 
 // deckCardGroups organizes deck cards by type for display formatting.
@@ -252,10 +356,45 @@ type deckCardGroups struct {
 	lands         []string
 	others        []string
 	totalCards    int
+	// typeCounts maps each group's label (see deckCardTypes) to its number of cards.
+	typeCounts map[string]int
+	// landCount, nonlandCount, and nonlandCMCSum use isLandCard, matching the mana curve.
+	landCount     int
+	nonlandCount  int
+	nonlandCMCSum int
+}
+
+// deckCardTypes returns the labels of the card type groups in display order.
+func deckCardTypes() []string {
+	return []string{"Creatures", "Instants", "Sorceries", "Artifacts", "Enchantments", "Planeswalkers", "Lands", "Other"}
+}
+
+// deckCardType returns the group label for a type line. Cards with several types
+// go in the first matching group, so artifact creatures count as creatures.
+func deckCardType(typeLine string) string {
+	typeLine = strings.ToLower(typeLine)
+	switch {
+	case strings.Contains(typeLine, "creature"):
+		return "Creatures"
+	case strings.Contains(typeLine, "instant"):
+		return "Instants"
+	case strings.Contains(typeLine, "sorcery"):
+		return "Sorceries"
+	case strings.Contains(typeLine, "artifact"):
+		return "Artifacts"
+	case strings.Contains(typeLine, "enchantment"):
+		return "Enchantments"
+	case strings.Contains(typeLine, "planeswalker"):
+		return "Planeswalkers"
+	case strings.Contains(typeLine, "land"):
+		return "Lands"
+	default:
+		return "Other"
+	}
 }
 
 // groupDeckCards categorizes mainboard cards by type.
-func groupDeckCards(mainboard map[string]MoxfieldCardEntry) deckCardGroups {
+func groupDeckCards(mainboard []CardEntry) deckCardGroups {
 	groups := deckCardGroups{
 		creatures:     []string{},
 		instants:      []string{},
@@ -265,27 +404,36 @@ func groupDeckCards(mainboard map[string]MoxfieldCardEntry) deckCardGroups {
 		planeswalkers: []string{},
 		lands:         []string{},
 		others:        []string{},
+		typeCounts:    make(map[string]int),
 	}
 
 	for _, entry := range mainboard {
-		cardLine := fmt.Sprintf("%dx %s", entry.Quantity, entry.Card.Name)
+		cardLine := fmt.Sprintf("%dx %s", entry.Quantity, entry.Name)
 		groups.totalCards += entry.Quantity
 
-		typeLine := strings.ToLower(entry.Card.TypeLine)
-		switch {
-		case strings.Contains(typeLine, "creature"):
+		if isLandCard(entry) {
+			groups.landCount += entry.Quantity
+		} else {
+			groups.nonlandCount += entry.Quantity
+			groups.nonlandCMCSum += cardManaValue(entry) * entry.Quantity
+		}
+
+		cardType := deckCardType(entry.TypeLine)
+		groups.typeCounts[cardType] += entry.Quantity
+		switch cardType {
+		case "Creatures":
 			groups.creatures = append(groups.creatures, cardLine)
-		case strings.Contains(typeLine, "instant"):
+		case "Instants":
 			groups.instants = append(groups.instants, cardLine)
-		case strings.Contains(typeLine, "sorcery"):
+		case "Sorceries":
 			groups.sorceries = append(groups.sorceries, cardLine)
-		case strings.Contains(typeLine, "artifact"):
+		case "Artifacts":
 			groups.artifacts = append(groups.artifacts, cardLine)
-		case strings.Contains(typeLine, "enchantment"):
+		case "Enchantments":
 			groups.enchantments = append(groups.enchantments, cardLine)
-		case strings.Contains(typeLine, "planeswalker"):
+		case "Planeswalkers":
 			groups.planeswalkers = append(groups.planeswalkers, cardLine)
-		case strings.Contains(typeLine, "land"):
+		case "Lands":
 			groups.lands = append(groups.lands, cardLine)
 		default:
 			groups.others = append(groups.others, cardLine)
@@ -296,31 +444,15 @@ func groupDeckCards(mainboard map[string]MoxfieldCardEntry) deckCardGroups {
 }
 
 // formatDeckHeader formats deck metadata and commander information.
-func formatDeckHeader(deck *MoxfieldDeck) string {
+func formatDeckHeader(deck *Deck) string {
 	var output strings.Builder
 
 	output.WriteString(fmt.Sprintf("# %s\n\n", deck.Name))
 	output.WriteString(fmt.Sprintf("**Format:** %s\n", deck.Format))
 
-	// Authors field can be either []string or an object, handle gracefully
-	if deck.Authors != nil {
-		authors, ok := deck.Authors.([]interface{})
-		if !ok || len(authors) == 0 {
-			goto skipAuthors
-		}
-
-		authorStrs := make([]string, 0, len(authors))
-		for _, author := range authors {
-			if authorStr, isString := author.(string); isString {
-				authorStrs = append(authorStrs, authorStr)
-			}
-		}
-
-		if len(authorStrs) > 0 {
-			output.WriteString(fmt.Sprintf("**Author:** %s\n", strings.Join(authorStrs, ", ")))
-		}
+	if len(deck.Authors) > 0 {
+		output.WriteString(fmt.Sprintf("**Author:** %s\n", strings.Join(deck.Authors, ", ")))
 	}
-skipAuthors:
 
 	output.WriteString(fmt.Sprintf("**Views:** %d | **Likes:** %d | **Comments:** %d\n",
 		deck.ViewCount, deck.LikeCount, deck.CommentCount))
@@ -336,7 +468,7 @@ skipAuthors:
 	if len(deck.Commanders) > 0 {
 		output.WriteString("\n## Commanders\n")
 		for _, entry := range deck.Commanders {
-			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Card.Name))
+			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
 		}
 	}
 
@@ -359,8 +491,9 @@ func formatCardGroup(title string, cards []string) string {
 	return output.String()
 }
 
-// FormatDeckForDisplay formats a Moxfield deck for text display.
-func FormatDeckForDisplay(deck *MoxfieldDeck) string {
+// FormatDeckForDisplay formats a deck for text display. A non-nil prices
+// adds the deck's estimated price after the mana analysis.
+func FormatDeckForDisplay(deck *Deck, prices *DeckPriceSummary) string {
 	var output strings.Builder
 
 	output.WriteString(formatDeckHeader(deck))
@@ -382,11 +515,18 @@ func FormatDeckForDisplay(deck *MoxfieldDeck) string {
 		output.WriteString(formatCardGroup("Other", groups.others))
 	}
 
+	output.WriteString(formatManaCurve(computeManaCurve(deck.Mainboard)))
+	output.WriteString(formatColorPips(computeColorPips(deck.Mainboard)))
+
+	if prices != nil {
+		output.WriteString(formatDeckPriceSection(*prices))
+	}
+
 	// Sideboard
 	if len(deck.Sideboard) > 0 {
 		output.WriteString("\n## Sideboard\n")
 		for _, entry := range deck.Sideboard {
-			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Card.Name))
+			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
 		}
 	}
 
@@ -394,7 +534,79 @@ func FormatDeckForDisplay(deck *MoxfieldDeck) string {
 	if len(deck.Maybeboard) > 0 {
 		output.WriteString("\n## Maybeboard\n")
 		for _, entry := range deck.Maybeboard {
-			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Card.Name))
+			output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
+		}
+	}
+
+	return output.String()
+}
+
+// DeckComparison holds the mainboard differences between two decks.
+type DeckComparison struct {
+	OnlyInA []CardEntry
+	OnlyInB []CardEntry
+	Shared  []SharedCard
+}
+
+// SharedCard is a card present in both decks, with each deck's quantity.
+type SharedCard struct {
+	Name      string
+	QuantityA int
+	QuantityB int
+}
+
+// CompareDecks diffs two decks' mainboards by card name. Each section is sorted by name.
+func CompareDecks(a, b *Deck) DeckComparison {
+	quantitiesA := boardQuantities(a.Mainboard)
+	quantitiesB := boardQuantities(b.Mainboard)
+
+	var comparison DeckComparison
+	for name, qtyA := range quantitiesA {
+		if qtyB, ok := quantitiesB[name]; ok {
+			comparison.Shared = append(comparison.Shared, SharedCard{Name: name, QuantityA: qtyA, QuantityB: qtyB})
+		} else {
+			comparison.OnlyInA = append(comparison.OnlyInA, CardEntry{Name: name, Quantity: qtyA})
+		}
+	}
+	for name, qtyB := range quantitiesB {
+		if _, ok := quantitiesA[name]; !ok {
+			comparison.OnlyInB = append(comparison.OnlyInB, CardEntry{Name: name, Quantity: qtyB})
+		}
+	}
+
+	sortCardEntries(comparison.OnlyInA)
+	sortCardEntries(comparison.OnlyInB)
+	slices.SortFunc(comparison.Shared, func(x, y SharedCard) int { return strings.Compare(x.Name, y.Name) })
+
+	return comparison
+}
+
+// FormatDeckComparisonForDisplay formats a deck comparison for text display.
+func FormatDeckComparisonForDisplay(a, b *Deck, comparison DeckComparison) string {
+	var output strings.Builder
+
+	output.WriteString("# Deck Comparison\n\n")
+	output.WriteString(fmt.Sprintf("**Deck A:** %s\n", a.Name))
+	output.WriteString(fmt.Sprintf("**Deck B:** %s\n\n", b.Name))
+	output.WriteString(fmt.Sprintf("**Only in A:** %d | **Only in B:** %d | **Shared:** %d\n",
+		len(comparison.OnlyInA), len(comparison.OnlyInB), len(comparison.Shared)))
+
+	output.WriteString(fmt.Sprintf("\n## Only in Deck A (%d)\n", len(comparison.OnlyInA)))
+	for _, entry := range comparison.OnlyInA {
+		output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
+	}
+
+	output.WriteString(fmt.Sprintf("\n## Only in Deck B (%d)\n", len(comparison.OnlyInB)))
+	for _, entry := range comparison.OnlyInB {
+		output.WriteString(fmt.Sprintf("- %dx %s\n", entry.Quantity, entry.Name))
+	}
+
+	output.WriteString(fmt.Sprintf("\n## Shared (%d)\n", len(comparison.Shared)))
+	for _, card := range comparison.Shared {
+		if card.QuantityA == card.QuantityB {
+			output.WriteString(fmt.Sprintf("- %dx %s\n", card.QuantityA, card.Name))
+		} else {
+			output.WriteString(fmt.Sprintf("- %s (A: %d, B: %d)\n", card.Name, card.QuantityA, card.QuantityB))
 		}
 	}
 
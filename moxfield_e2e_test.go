@@ -220,7 +220,7 @@ func TestMoxfieldFormatDeckE2E(t *testing.T) {
 	}
 
 	// Format the deck
-	output := FormatDeckForDisplay(deck)
+	output := FormatDeckForDisplay(deck.ToDeck(), nil)
 	if output == "" {
 		t.Error("Expected non-empty formatted output")
 	}
@@ -3,12 +3,26 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
+// withoutMoxfieldRateLimit lifts the shared Moxfield rate limit for the duration of a test.
+func withoutMoxfieldRateLimit(t *testing.T) {
+	t.Helper()
+
+	previous := moxfieldLimiter.Limit()
+	moxfieldLimiter.SetLimit(rate.Inf)
+	t.Cleanup(func() { moxfieldLimiter.SetLimit(previous) })
+}
+
 func TestExtractPublicIDFromURL(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -33,7 +47,42 @@ func TestExtractPublicIDFromURL(t *testing.T) {
 		{
 			name:  "URL with query parameters",
 			input: "https://www.moxfield.com/decks/ghi789?tab=visual",
-			want:  "ghi789?tab=visual",
+			want:  "ghi789",
+		},
+		{
+			name:  "URL with fragment",
+			input: "https://www.moxfield.com/decks/jkl012#comments",
+			want:  "jkl012",
+		},
+		{
+			name:  "primer URL",
+			input: "https://www.moxfield.com/decks/mno345/primer",
+			want:  "mno345",
+		},
+		{
+			name:  "primer URL with trailing slash and query",
+			input: "https://www.moxfield.com/decks/pqr678/primer/?utm_source=share",
+			want:  "pqr678",
+		},
+		{
+			name:  "export URL",
+			input: "https://www.moxfield.com/decks/vwx234/export",
+			want:  "vwx234",
+		},
+		{
+			name:  "versioned URL",
+			input: "https://www.moxfield.com/decks/yza567/history/5d1f2c8e9b",
+			want:  "yza567",
+		},
+		{
+			name:  "versioned URL with version query",
+			input: "https://www.moxfield.com/decks/bcd890?version=5d1f2c8e9b",
+			want:  "bcd890",
+		},
+		{
+			name:  "URL without scheme",
+			input: "moxfield.com/decks/stu901?tab=stats",
+			want:  "stu901",
 		},
 	}
 
@@ -48,6 +97,8 @@ func TestExtractPublicIDFromURL(t *testing.T) {
 }
 
 func TestGetMoxfieldDeck(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
 	mockDeck := MoxfieldDeck{
 		ID:          "test-id",
 		PublicID:    "abc123",
@@ -142,6 +193,8 @@ func TestGetMoxfieldDeck(t *testing.T) {
 }
 
 func TestGetUserDecks(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
 	mockResponse := MoxfieldUserDecksResponse{
 		PageNumber:   1,
 		PageSize:     20,
@@ -228,6 +281,8 @@ func TestGetUserDecks(t *testing.T) {
 }
 
 func TestSearchMoxfieldDecks(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
 	mockResponse := MoxfieldSearchResponse{
 		PageNumber:   1,
 		PageSize:     20,
@@ -346,7 +401,68 @@ func TestSearchMoxfieldDecks(t *testing.T) {
 	}
 }
 
+func TestSearchMoxfieldDecks_CommanderAndHubs(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		json.NewEncoder(w).Encode(MoxfieldSearchResponse{})
+	}))
+	defer server.Close()
+
+	params := MoxfieldSearchParams{
+		CommanderName: "Kinnan, Bonder Prodigy",
+		Hubs:          []string{"cEDH", "Budget"},
+	}
+	if _, err := searchMoxfieldDecksWithURL(t.Context(), params, server.URL); err != nil {
+		t.Fatalf("searchMoxfieldDecksWithURL() error = %v", err)
+	}
+
+	if commander := got.Get("commander"); commander != "Kinnan, Bonder Prodigy" {
+		t.Errorf("commander parameter = %q, want %q", commander, "Kinnan, Bonder Prodigy")
+	}
+	if hubs := got.Get("hubs"); hubs != "cEDH,Budget" {
+		t.Errorf("hubs parameter = %q, want %q", hubs, "cEDH,Budget")
+	}
+	if got.Has("query") || got.Has("board") {
+		t.Errorf("search without a query should not send query or board parameters, got %v", got)
+	}
+}
+
+func TestSearchMoxfieldDecks_EscapesQuery(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		json.NewEncoder(w).Encode(MoxfieldSearchResponse{})
+	}))
+	defer server.Close()
+
+	params := MoxfieldSearchParams{
+		Query:    "Kinnan, Bonder Prodigy & Friends",
+		Format:   "commander",
+		SortType: "views",
+	}
+	if _, err := searchMoxfieldDecksWithURL(t.Context(), params, server.URL); err != nil {
+		t.Fatalf("searchMoxfieldDecksWithURL() error = %v", err)
+	}
+
+	if query := got.Get("query"); query != params.Query {
+		t.Errorf("query parameter = %q, want %q", query, params.Query)
+	}
+	if got.Has(" Friends") {
+		t.Errorf("query leaked into another parameter: %v", got)
+	}
+	if got.Get("fmt") != "commander" || got.Get("sortType") != "views" {
+		t.Errorf("fmt and sortType parameters = %v", got)
+	}
+}
+
 func TestSearchMoxfieldDecks_PageSizeValidation(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
 	tests := []struct {
 		name           string
 		inputPageSize  int
@@ -407,6 +523,82 @@ func TestSearchMoxfieldDecks_PageSizeValidation(t *testing.T) {
 	}
 }
 
+func TestFormatMoxfieldSearchForDisplay(t *testing.T) {
+	results := &MoxfieldSearchResponse{
+		PageNumber:   2,
+		TotalResults: 41,
+		TotalPages:   3,
+		Data: []MoxfieldDeckSummary{{
+			PublicID:  "deck1",
+			Name:      "Kinnan cEDH",
+			Format:    "commander",
+			PublicURL: "https://moxfield.com/decks/deck1",
+			ViewCount: 1000,
+			LikeCount: 50,
+		}},
+	}
+
+	got := FormatMoxfieldSearchForDisplay("Kinnan", "commander", results)
+	for _, want := range []string{
+		"# Moxfield Decks for Kinnan",
+		"**Total Results:** 41",
+		"**Showing:** 1 decks (Page 2 of 3)",
+		"1. **Kinnan cEDH** (commander)",
+		"   - Views: 1000 | Likes: 50",
+		"   - URL: https://moxfield.com/decks/deck1",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatMoxfieldSearchForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+
+	empty := FormatMoxfieldSearchForDisplay("Nobody", "commander", &MoxfieldSearchResponse{})
+	if !strings.Contains(empty, "No decks found.") {
+		t.Errorf("FormatMoxfieldSearchForDisplay() with no results = %s", empty)
+	}
+}
+
+func TestHandleSearchMoxfieldDecksValidation(t *testing.T) {
+	s := &MTGCommanderServer{}
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{name: "missing search", args: map[string]any{}, want: "at least one of query, commander, or hubs is required"},
+		{
+			name: "invalid sort type",
+			args: map[string]any{"query": "Kinnan", "sort_type": "popularity"},
+			want: `Invalid sort_type "popularity"`,
+		},
+		{
+			name: "invalid sort direction",
+			args: map[string]any{"query": "Kinnan", "sort_direction": "up"},
+			want: `Invalid sort_direction "up"`,
+		},
+		{
+			name: "invalid page number",
+			args: map[string]any{"query": "Kinnan", "page_number": float64(0)},
+			want: "page_number must be 1 or greater",
+		},
+		{
+			name: "invalid page size",
+			args: map[string]any{"commander": "Kinnan", "page_size": float64(-1)},
+			want: "page_size must be 1 or greater",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleSearchMoxfieldDecks, tt.args)
+			if !isErr || !strings.Contains(got, tt.want) {
+				t.Errorf("handleSearchMoxfieldDecks() = %q (error %v), want error containing %q", got, isErr, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatDeckForDisplay(t *testing.T) {
 	deck := &MoxfieldDeck{
 		Name:         "Test Deck",
@@ -476,7 +668,7 @@ func TestFormatDeckForDisplay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatDeckForDisplay(tt.deck)
+			got := FormatDeckForDisplay(tt.deck.ToDeck(), nil)
 
 			for _, want := range tt.wantContains {
 				if !strings.Contains(got, want) {
@@ -486,3 +678,154 @@ func TestFormatDeckForDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMoxfieldDeck_RateLimited(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(MoxfieldDeck{PublicID: "abc123", Name: "Test Deck"})
+	}))
+	defer server.Close()
+
+	deck, err := getMoxfieldDeckWithURL(context.Background(), "abc123", server.URL)
+	if err != nil {
+		t.Fatalf("getMoxfieldDeckWithURL() error = %v", err)
+	}
+
+	if deck.Name != "Test Deck" || attempts != 2 {
+		t.Errorf("getMoxfieldDeckWithURL() = %q after %d attempts, want Test Deck after 2", deck.Name, attempts)
+	}
+}
+
+func TestGetMoxfieldDeckVersion(t *testing.T) {
+	withoutMoxfieldRateLimit(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/decks/all/abc123/versions/5d1f2c8e9b" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(MoxfieldDeck{PublicID: "abc123", Name: "Tournament Build"})
+	}))
+	defer server.Close()
+
+	deck, err := getMoxfieldDeckVersionWithURL(t.Context(), "abc123", "5d1f2c8e9b", server.URL)
+	if err != nil {
+		t.Fatalf("getMoxfieldDeckVersionWithURL() error = %v", err)
+	}
+	if deck.Name != "Tournament Build" {
+		t.Errorf("getMoxfieldDeckVersionWithURL() name = %q, want Tournament Build", deck.Name)
+	}
+
+	_, err = getMoxfieldDeckVersionWithURL(t.Context(), "abc123", "missing", server.URL)
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "abc123 version missing") {
+		t.Errorf("getMoxfieldDeckVersionWithURL() for an unknown version error = %v, want not found", err)
+	}
+}
+
+func TestConfigureMoxfieldRateLimit(t *testing.T) {
+	previous := moxfieldLimiter.Limit()
+	t.Cleanup(func() { moxfieldLimiter.SetLimit(previous) })
+
+	tests := []struct {
+		value string
+		want  float64
+	}{
+		{value: "", want: defaultMoxfieldRPS},
+		{value: "5", want: 5},
+		{value: "0.5", want: 0.5},
+		{value: "0", want: defaultMoxfieldRPS},
+		{value: "fast", want: defaultMoxfieldRPS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(moxfieldRPSEnvVar, tt.value)
+
+			if got := ConfigureMoxfieldRateLimit(); got != tt.want {
+				t.Errorf("ConfigureMoxfieldRateLimit() = %v, want %v", got, tt.want)
+			}
+			if got := float64(moxfieldLimiter.Limit()); got != tt.want {
+				t.Errorf("moxfieldLimiter.Limit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoxfieldRateLimitHonorsContext(t *testing.T) {
+	previous := moxfieldLimiter.Limit()
+	moxfieldLimiter.SetLimit(rate.Every(time.Hour))
+	t.Cleanup(func() { moxfieldLimiter.SetLimit(previous) })
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(MoxfieldDeck{PublicID: "abc123"})
+	}))
+	defer server.Close()
+
+	// Drain the single burst token so the next request has to wait
+	moxfieldLimiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := getMoxfieldDeckWithURL(ctx, "abc123", server.URL); err == nil {
+		t.Error("getMoxfieldDeckWithURL() expected rate limit wait to fail, got nil")
+	}
+	if requests != 0 {
+		t.Errorf("getMoxfieldDeckWithURL() made %d requests while throttled, want 0", requests)
+	}
+}
+
+func TestCompareDecks(t *testing.T) {
+	deckA := &MoxfieldDeck{
+		Name: "Deck A",
+		Mainboard: map[string]MoxfieldCardEntry{
+			"1": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring"}},
+			"2": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Cultivate"}},
+			"3": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Forest"}},
+			"4": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Beast Within"}},
+		},
+	}
+	deckB := &MoxfieldDeck{
+		Name: "Deck B",
+		Mainboard: map[string]MoxfieldCardEntry{
+			"1": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sol Ring"}},
+			"2": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Kodama's Reach"}},
+			"3": {Quantity: 12, Card: MoxfieldCardInfo{Name: "Forest"}},
+		},
+	}
+
+	comparison := CompareDecks(deckA.ToDeck(), deckB.ToDeck())
+
+	if len(comparison.OnlyInA) != 2 || comparison.OnlyInA[0].Name != "Beast Within" ||
+		comparison.OnlyInA[1].Name != "Cultivate" {
+		t.Errorf("CompareDecks() OnlyInA = %+v, want Beast Within, Cultivate", comparison.OnlyInA)
+	}
+	if len(comparison.OnlyInB) != 1 || comparison.OnlyInB[0].Name != "Kodama's Reach" {
+		t.Errorf("CompareDecks() OnlyInB = %+v, want Kodama's Reach", comparison.OnlyInB)
+	}
+	if len(comparison.Shared) != 2 {
+		t.Fatalf("CompareDecks() Shared = %+v, want Forest and Sol Ring", comparison.Shared)
+	}
+
+	got := FormatDeckComparisonForDisplay(deckA.ToDeck(), deckB.ToDeck(), comparison)
+	for _, want := range []string{
+		"**Only in A:** 2 | **Only in B:** 1 | **Shared:** 2",
+		"## Only in Deck A (2)\n- 1x Beast Within\n- 1x Cultivate\n",
+		"## Only in Deck B (1)\n- 1x Kodama's Reach\n",
+		"## Shared (2)\n- Forest (A: 10, B: 12)\n- 1x Sol Ring\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDeckComparisonForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+}
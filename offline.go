@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// offlineEnvVar enables offline mode when set to a true value (e.g. OFFLINE=1).
+	offlineEnvVar = "OFFLINE"
+	// bulkDataFileEnvVar overrides where the Scryfall bulk data file is stored.
+	bulkDataFileEnvVar = "MTG_BULK_DATA_FILE"
+	// defaultBulkDataFileName is the bulk data file's name in the user cache directory.
+	defaultBulkDataFileName = "oracle-cards.json"
+	// oracleCardsBulkType is Scryfall's bulk data type with one entry per Oracle card.
+	oracleCardsBulkType = "oracle_cards"
+)
+
+// offlineSkippedLayouts are layouts left out of the offline index, so a token or art
+// card never shadows the real card with the same name.
+func offlineSkippedLayouts() []string {
+	return []string{"art_series", "token", "double_faced_token", "emblem"}
+}
+
+// offlineCard is a bulk data card with the fields go-scryfall doesn't decode.
+type offlineCard struct {
+	scryfall.Card
+	GameChanger bool `json:"game_changer"`
+}
+
+// offlineIndex is an in-memory name index over Scryfall's Oracle cards bulk data.
+// It is safe for concurrent use; refresh swaps in a new index without blocking lookups for long.
+type offlineIndex struct {
+	mu       sync.RWMutex
+	cards    map[string]offlineCard
+	loadedAt time.Time
+}
+
+// get returns the card with the given name, or the card with a face of that name,
+// ignoring case.
+func (idx *offlineIndex) get(name string) (offlineCard, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	card, ok := idx.cards[normalizeCardName(name)]
+	return card, ok
+}
+
+// len returns the number of indexed names, including face names.
+func (idx *offlineIndex) len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.cards)
+}
+
+// load replaces the index with the cards in a bulk data file.
+func (idx *offlineIndex) load(path string) error {
+	cards, err := readBulkDataFile(path)
+	if err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.cards = cards
+	idx.loadedAt = time.Now()
+	return nil
+}
+
+// readBulkDataFile decodes a bulk data JSON array one card at a time, indexing each
+// card by its full name and by each face name.
+func readBulkDataFile(path string) (map[string]offlineCard, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bulk data file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	if _, tokenErr := decoder.Token(); tokenErr != nil {
+		return nil, fmt.Errorf("failed to read bulk data file: %w", tokenErr)
+	}
+
+	cards := make(map[string]offlineCard)
+	for decoder.More() {
+		var card offlineCard
+		if decodeErr := decoder.Decode(&card); decodeErr != nil {
+			return nil, fmt.Errorf("failed to decode bulk data card: %w", decodeErr)
+		}
+		if slices.Contains(offlineSkippedLayouts(), string(card.Layout)) {
+			continue
+		}
+
+		cards[normalizeCardName(card.Name)] = card
+		for _, face := range card.CardFaces {
+			// A card's own name wins over another card's face of the same name
+			if _, exists := cards[normalizeCardName(face.Name)]; !exists {
+				cards[normalizeCardName(face.Name)] = card
+			}
+		}
+	}
+
+	return cards, nil
+}
+
+// offlineModeFromEnv reports whether offlineEnvVar enables offline mode.
+func offlineModeFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(offlineEnvVar))
+	return err == nil && enabled
+}
+
+// defaultBulkDataPath returns the bulk data file path: $MTG_BULK_DATA_FILE, or
+// defaultBulkDataFileName in an mtg-commander directory under the user cache directory.
+func defaultBulkDataPath() string {
+	if path := os.Getenv(bulkDataFileEnvVar); path != "" {
+		return path
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return defaultBulkDataFileName
+	}
+	return filepath.Join(cacheDir, logDirName, defaultBulkDataFileName)
+}
+
+// downloadBulkData downloads Scryfall's Oracle cards bulk data to path. The file is
+// written next to path and renamed into place, so a failed download never leaves a
+// truncated index behind.
+func (s *MTGCommanderServer) downloadBulkData(ctx context.Context, path string) error {
+	bulk, err := s.scryfallClient.GetBulkDataByType(ctx, oracleCardsBulkType)
+	if err != nil {
+		return fmt.Errorf("failed to look up bulk data: %w", err)
+	}
+
+	resp, err := HTTPGet(ctx, bulk.DownloadURI)
+	if err != nil {
+		return fmt.Errorf("failed to download bulk data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bulk data download returned status %d", resp.StatusCode)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o700); mkdirErr != nil {
+		return fmt.Errorf("failed to create bulk data directory: %w", mkdirErr)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create bulk data file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, copyErr := io.Copy(tmp, resp.Body)
+	closeErr := tmp.Close()
+	if err = errors.Join(copyErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write bulk data file: %w", err)
+	}
+
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return fmt.Errorf("failed to save bulk data file: %w", renameErr)
+	}
+
+	GetLogger().Info().
+		Str("path", path).
+		Time("updated_at", bulk.UpdatedAt.Time).
+		Msg("Downloaded Scryfall bulk data")
+	return nil
+}
+
+// refreshOfflineIndex downloads fresh bulk data to path and reloads the offline index from it.
+func (s *MTGCommanderServer) refreshOfflineIndex(ctx context.Context, path string) error {
+	if err := s.downloadBulkData(ctx, path); err != nil {
+		return err
+	}
+	return s.offlineIndex.load(path)
+}
+
+// enableOfflineMode loads the offline index from path, downloading the bulk data
+// first if the file doesn't exist yet.
+func (s *MTGCommanderServer) enableOfflineMode(ctx context.Context, path string) error {
+	s.offlineIndex = &offlineIndex{}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		GetLogger().Info().Str("path", path).Msg("No bulk data file yet, downloading")
+		return s.refreshOfflineIndex(ctx, path)
+	}
+	return s.offlineIndex.load(path)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// offlineTestBulkData is a trimmed Oracle cards bulk data file.
+const offlineTestBulkData = `[
+	{"object": "card", "id": "e7a8ae2f-2bd4-4e4a-a5d1-0e9c4d7ab1a2", "name": "Rhystic Study", "layout": "normal",
+	 "type_line": "Enchantment", "game_changer": true,
+	 "legalities": {"commander": "legal", "vintage": "legal"}},
+	{"object": "card", "id": "c2a4a1f0-1d0b-4a38-a6c8-5d7e1b6c1f00", "name": "Treasure", "layout": "token",
+	 "type_line": "Token Artifact — Treasure"},
+	{"object": "card", "id": "11bf83bb-c95b-4b4f-9a56-ce7a1816307a",
+	 "name": "Delver of Secrets // Insectile Aberration", "layout": "transform",
+	 "legalities": {"commander": "legal"},
+	 "card_faces": [{"name": "Delver of Secrets"}, {"name": "Insectile Aberration"}]},
+	{"object": "card", "id": "3b9b2a54-0a6e-4c45-8d4e-8f8b8d8e3c11", "name": "Sol Ring", "layout": "normal",
+	 "type_line": "Artifact", "game_changer": false,
+	 "legalities": {"commander": "legal", "vintage": "restricted"}}
+]`
+
+// writeOfflineTestBulkData writes offlineTestBulkData to a temp file and returns its path.
+func writeOfflineTestBulkData(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), defaultBulkDataFileName)
+	if err := os.WriteFile(path, []byte(offlineTestBulkData), 0o600); err != nil {
+		t.Fatalf("Failed to write bulk data: %v", err)
+	}
+	return path
+}
+
+func TestOfflineIndex(t *testing.T) {
+	idx := &offlineIndex{}
+	if err := idx.load(writeOfflineTestBulkData(t)); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	tests := []struct {
+		lookup   string
+		wantName string
+	}{
+		{lookup: "Rhystic Study", wantName: "Rhystic Study"},
+		{lookup: "  sol ring ", wantName: "Sol Ring"},
+		{lookup: "Insectile Aberration", wantName: "Delver of Secrets // Insectile Aberration"},
+		{lookup: "delver of secrets // insectile aberration", wantName: "Delver of Secrets // Insectile Aberration"},
+		{lookup: "Treasure"},
+		{lookup: "Sol Rin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lookup, func(t *testing.T) {
+			card, ok := idx.get(tt.lookup)
+			if ok != (tt.wantName != "") || card.Name != tt.wantName {
+				t.Errorf("get(%q) = %q, %v; want %q", tt.lookup, card.Name, ok, tt.wantName)
+			}
+		})
+	}
+
+	badPath := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(badPath, []byte(`{"not": "an array"}`), 0o600); err != nil {
+		t.Fatalf("Failed to write bulk data: %v", err)
+	}
+	if err := idx.load(badPath); err == nil {
+		t.Error("load() accepted a malformed bulk data file")
+	}
+	if _, ok := idx.get("Rhystic Study"); !ok {
+		t.Error("a failed load() should keep the previous index")
+	}
+}
+
+func TestRefreshOfflineIndex(t *testing.T) {
+	var downloadURL string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bulk-data/oracle_cards":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"object": "bulk_data", "type": oracleCardsBulkType, "download_uri": downloadURL,
+			})
+		case "/download/oracle-cards.json":
+			_, _ = w.Write([]byte(offlineTestBulkData))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+	downloadURL = s.scryfallBaseURL + "/download/oracle-cards.json"
+
+	path := filepath.Join(t.TempDir(), "nested", defaultBulkDataFileName)
+	if err := s.enableOfflineMode(t.Context(), path); err != nil {
+		t.Fatalf("enableOfflineMode() error = %v", err)
+	}
+	if _, ok := s.offlineIndex.get("Rhystic Study"); !ok {
+		t.Error("enableOfflineMode() did not index the downloaded bulk data")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("enableOfflineMode() did not save the bulk data file: %v", err)
+	}
+
+	// A failed download keeps the existing file and index
+	downloadURL = s.scryfallBaseURL + "/download/missing.json"
+	if err := s.refreshOfflineIndex(t.Context(), path); err == nil {
+		t.Error("refreshOfflineIndex() succeeded with a missing download")
+	}
+	if _, ok := s.offlineIndex.get("Rhystic Study"); !ok {
+		t.Error("a failed refresh should keep the previous index")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("refreshOfflineIndex() left temp files behind: %v", entries)
+	}
+}
+
+func TestOfflineModeHandlers(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Offline mode called Scryfall: %s %s", r.Method, r.URL)
+		writeScryfallNotFound(w)
+	}))
+	s.offlineIndex = &offlineIndex{}
+	if err := s.offlineIndex.load(writeOfflineTestBulkData(t)); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	got, isErr := callTool(t, s.handleGetCardDetails, map[string]any{"name": "rhystic study"})
+	if isErr || !strings.Contains(got, "# Rhystic Study") {
+		t.Errorf("handleGetCardDetails() = %s", got)
+	}
+
+	got, isErr = callTool(t, s.handleCheckLegality, map[string]any{"name": "Rhystic Study"})
+	if isErr || !strings.Contains(got, "Game Changers list") {
+		t.Errorf("handleCheckLegality() = %s", got)
+	}
+
+	got, isErr = callTool(t, s.handleCheckLegality, map[string]any{"name": "Sol Ring"})
+	if isErr || !strings.Contains(got, "**Game Changer:** No") || !strings.Contains(got, "- Vintage: ⚠️ restricted") {
+		t.Errorf("handleCheckLegality() = %s", got)
+	}
+
+	got, isErr = callTool(t, s.handleGetCardDetails, map[string]any{"name": "Black Lotus"})
+	if !isErr || !strings.Contains(got, "not in the offline card index") {
+		t.Errorf("handleGetCardDetails() for a missing card = %s", got)
+	}
+}
+
+func TestOfflineModeFromEnv(t *testing.T) {
+	for value, want := range map[string]bool{"1": true, "true": true, "0": false, "": false, "yes": false} {
+		t.Setenv(offlineEnvVar, value)
+		if got := offlineModeFromEnv(); got != want {
+			t.Errorf("offlineModeFromEnv() with %s=%q = %v, want %v", offlineEnvVar, value, got, want)
+		}
+	}
+}
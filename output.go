@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// outputFormatText is the default markdown output meant for people.
+	outputFormatText = "text"
+	// outputFormatJSON is typed JSON output meant for programs.
+	outputFormatJSON = "json"
+	// outputFormatEnvVar sets the default output format for tools that support a format argument.
+	outputFormatEnvVar = "MTG_OUTPUT_FORMAT"
+)
+
+// outputFormats returns the output formats tools accept for their format argument.
+func outputFormats() []string {
+	return []string{outputFormatText, outputFormatJSON}
+}
+
+// outputFormatArg reads a tool's optional format argument, defaulting to
+// $MTG_OUTPUT_FORMAT and then to text.
+func outputFormatArg(args map[string]any) (string, error) {
+	format, _ := args["format"].(string)
+	if format == "" {
+		format = os.Getenv(outputFormatEnvVar)
+	}
+	if format == "" {
+		return outputFormatText, nil
+	}
+
+	format = strings.ToLower(format)
+	if !slices.Contains(outputFormats(), format) {
+		return "", fmt.Errorf("invalid format %q (use one of: %s)", format, strings.Join(outputFormats(), ", "))
+	}
+	return format, nil
+}
+
+// jsonToolResult returns v as structured content, with the same JSON as the text
+// content for clients that only read text.
+func jsonToolResult(v any) *mcp.CallToolResult {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode JSON output: %v", err))
+	}
+	return mcp.NewToolResultStructured(v, string(data))
+}
+
+// cardFaceJSON is one face of a multi-faced card in JSON output.
+type cardFaceJSON struct {
+	Name       string  `json:"name"`
+	ManaCost   string  `json:"mana_cost"`
+	TypeLine   string  `json:"type_line"`
+	OracleText *string `json:"oracle_text,omitempty"`
+	Power      *string `json:"power,omitempty"`
+	Toughness  *string `json:"toughness,omitempty"`
+	Loyalty    *string `json:"loyalty,omitempty"`
+}
+
+// cardJSON is a card in JSON output. Mana costs are Scryfall's symbol notation
+// regardless of mana_format.
+type cardJSON struct {
+	ID            string              `json:"id"`
+	OracleID      string              `json:"oracle_id,omitempty"`
+	Name          string              `json:"name"`
+	ManaCost      string              `json:"mana_cost"`
+	CMC           float64             `json:"cmc"`
+	TypeLine      string              `json:"type_line"`
+	OracleText    string              `json:"oracle_text,omitempty"`
+	Power         *string             `json:"power,omitempty"`
+	Toughness     *string             `json:"toughness,omitempty"`
+	Loyalty       *string             `json:"loyalty,omitempty"`
+	Colors        []scryfall.Color    `json:"colors"`
+	ColorIdentity []scryfall.Color    `json:"color_identity"`
+	Keywords      []string            `json:"keywords"`
+	Set           string              `json:"set"`
+	SetName       string              `json:"set_name"`
+	Rarity        string              `json:"rarity"`
+	Legalities    scryfall.Legalities `json:"legalities"`
+	Prices        scryfall.Prices     `json:"prices"`
+	EDHRECRank    *int                `json:"edhrec_rank,omitempty"`
+	ScryfallURI   string              `json:"scryfall_uri,omitempty"`
+	Faces         []cardFaceJSON      `json:"faces,omitempty"`
+}
+
+// newCardJSON converts a Scryfall card to its JSON output form.
+func newCardJSON(card scryfall.Card) cardJSON {
+	out := cardJSON{
+		ID:            card.ID,
+		OracleID:      card.OracleID,
+		Name:          card.Name,
+		ManaCost:      card.ManaCost,
+		CMC:           card.CMC,
+		TypeLine:      card.TypeLine,
+		OracleText:    card.OracleText,
+		Power:         card.Power,
+		Toughness:     card.Toughness,
+		Loyalty:       card.Loyalty,
+		Colors:        card.Colors,
+		ColorIdentity: card.ColorIdentity,
+		Keywords:      card.Keywords,
+		Set:           card.Set,
+		SetName:       card.SetName,
+		Rarity:        card.Rarity,
+		Legalities:    card.Legalities,
+		Prices:        card.Prices,
+		EDHRECRank:    card.EDHRECRank,
+		ScryfallURI:   card.ScryfallURI,
+	}
+	for _, face := range card.CardFaces {
+		out.Faces = append(out.Faces, cardFaceJSON{
+			Name:       face.Name,
+			ManaCost:   face.ManaCost,
+			TypeLine:   face.TypeLine,
+			OracleText: face.OracleText,
+			Power:      face.Power,
+			Toughness:  face.Toughness,
+			Loyalty:    face.Loyalty,
+		})
+	}
+	return out
+}
+
+// cardSearchJSON is one page of search_cards results in JSON output.
+type cardSearchJSON struct {
+	Query      string     `json:"query"`
+	Page       int        `json:"page"`
+	TotalCards int        `json:"total_cards"`
+	HasMore    bool       `json:"has_more"`
+	Cards      []cardJSON `json:"cards"`
+}
+
+// deckCardJSON is one deck entry in JSON output.
+type deckCardJSON struct {
+	Name          string   `json:"name"`
+	Quantity      int      `json:"quantity"`
+	TypeLine      string   `json:"type_line,omitempty"`
+	ManaCost      string   `json:"mana_cost,omitempty"`
+	CMC           float64  `json:"cmc"`
+	ColorIdentity []string `json:"color_identity,omitempty"`
+}
+
+// pricedCardJSON is a priced deck entry in JSON output.
+type pricedCardJSON struct {
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	UnitUSD  float64 `json:"unit_usd"`
+	TotalUSD float64 `json:"total_usd"`
+}
+
+// deckPriceJSON is a deck's estimated price in JSON output.
+type deckPriceJSON struct {
+	TotalUSD      float64          `json:"total_usd"`
+	Tier          string           `json:"tier"`
+	MostExpensive []pricedCardJSON `json:"most_expensive"`
+	Unpriced      []string         `json:"unpriced"`
+}
+
+// deckJSON is a deck in JSON output. Each board is sorted by card name.
+type deckJSON struct {
+	PublicID      string         `json:"public_id"`
+	Name          string         `json:"name"`
+	Format        string         `json:"format"`
+	Description   string         `json:"description,omitempty"`
+	URL           string         `json:"url"`
+	ColorIdentity []string       `json:"color_identity"`
+	TotalCards    int            `json:"total_cards"`
+	ViewCount     int            `json:"view_count"`
+	LikeCount     int            `json:"like_count"`
+	CommentCount  int            `json:"comment_count"`
+	CreatedAt     string         `json:"created_at,omitempty"`
+	LastUpdated   string         `json:"last_updated,omitempty"`
+	Commanders    []deckCardJSON `json:"commanders"`
+	Companions    []deckCardJSON `json:"companions"`
+	Mainboard     []deckCardJSON `json:"mainboard"`
+	Sideboard     []deckCardJSON `json:"sideboard"`
+	Maybeboard    []deckCardJSON `json:"maybeboard"`
+	Price         *deckPriceJSON `json:"price,omitempty"`
+}
+
+// newDeckBoardJSON converts a deck board to its JSON output form.
+func newDeckBoardJSON(board []CardEntry) []deckCardJSON {
+	cards := make([]deckCardJSON, 0, len(board))
+	for _, entry := range board {
+		cards = append(cards, deckCardJSON{
+			Name:          entry.Name,
+			Quantity:      entry.Quantity,
+			TypeLine:      entry.TypeLine,
+			ManaCost:      entry.ManaCost,
+			CMC:           entry.CMC,
+			ColorIdentity: entry.ColorIdentity,
+		})
+	}
+	return cards
+}
+
+// newDeckJSON converts a deck, and its price when non-nil, to its JSON output form.
+func newDeckJSON(deck *Deck, prices *DeckPriceSummary) deckJSON {
+	out := deckJSON{
+		PublicID:      deck.ID,
+		Name:          deck.Name,
+		Format:        deck.Format,
+		Description:   deck.Description,
+		URL:           deck.URL,
+		ColorIdentity: deckColorIdentity(deck),
+		TotalCards:    groupDeckCards(deck.Mainboard).totalCards + len(deck.Commanders),
+		ViewCount:     deck.ViewCount,
+		LikeCount:     deck.LikeCount,
+		CommentCount:  deck.CommentCount,
+		CreatedAt:     deck.CreatedAt,
+		LastUpdated:   deck.LastUpdated,
+		Commanders:    newDeckBoardJSON(deck.Commanders),
+		Companions:    newDeckBoardJSON(deck.Companions),
+		Mainboard:     newDeckBoardJSON(deck.Mainboard),
+		Sideboard:     newDeckBoardJSON(deck.Sideboard),
+		Maybeboard:    newDeckBoardJSON(deck.Maybeboard),
+	}
+
+	if prices != nil {
+		count := min(defaultTopPricedCards, len(prices.Cards))
+		price := &deckPriceJSON{
+			TotalUSD:      prices.TotalUSD,
+			Tier:          deckPriceTier(prices.TotalUSD),
+			MostExpensive: make([]pricedCardJSON, count),
+			Unpriced:      prices.Unpriced,
+		}
+		for i, card := range prices.Cards[:count] {
+			price.MostExpensive[i] = pricedCardJSON{
+				Name: card.Name, Quantity: card.Quantity, UnitUSD: card.UnitUSD, TotalUSD: card.TotalUSD(),
+			}
+		}
+		out.Price = price
+	}
+
+	return out
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestOutputFormatArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", args: map[string]any{}, want: outputFormatText},
+		{name: "json", args: map[string]any{"format": "JSON"}, want: outputFormatJSON},
+		{name: "env default", args: map[string]any{}, env: "json", want: outputFormatJSON},
+		{name: "argument overrides env", args: map[string]any{"format": "text"}, env: "json", want: outputFormatText},
+		{name: "invalid", args: map[string]any{"format": "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(outputFormatEnvVar, tt.env)
+			got, err := outputFormatArg(tt.args)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("outputFormatArg() = %q, %v, want %q, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchCardsJSON(t *testing.T) {
+	power, toughness := "1", "1"
+	cards := []scryfall.Card{{
+		ID:            "abc",
+		Name:          "Llanowar Elves",
+		ManaCost:      "{G}",
+		CMC:           1,
+		TypeLine:      "Creature — Elf Druid",
+		OracleText:    "{T}: Add {G}.",
+		Power:         &power,
+		Toughness:     &toughness,
+		ColorIdentity: []scryfall.Color{"G"},
+		Legalities:    scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		Prices:        scryfall.Prices{USD: "0.25"},
+	}}
+
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/search" {
+			writeScryfallNotFound(w)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list", "total_cards": 3, "has_more": true, "data": cards,
+		})
+	}))
+
+	got, isErr := callTool(t, s.handleSearchCards, map[string]any{"query": "elf", "limit": float64(1), "format": "json"})
+	if isErr {
+		t.Fatalf("handleSearchCards() returned error: %s", got)
+	}
+
+	var result cardSearchJSON
+	if err := json.Unmarshal([]byte(got), &result); err != nil {
+		t.Fatalf("handleSearchCards() output is not JSON: %v\n%s", err, got)
+	}
+	if result.TotalCards != 3 || !result.HasMore || len(result.Cards) != 1 {
+		t.Fatalf("handleSearchCards() = %+v", result)
+	}
+	card := result.Cards[0]
+	if card.Name != "Llanowar Elves" || card.ManaCost != "{G}" || *card.Power != "1" ||
+		card.Legalities.Commander != scryfall.LegalityLegal || card.Prices.USD != "0.25" {
+		t.Errorf("handleSearchCards() card = %+v", card)
+	}
+}
+
+func TestGetCardDetailsJSON(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/named" {
+			writeScryfallNotFound(w)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"object": "card",
+			"name": "Fire // Ice",
+			"type_line": "Instant // Instant",
+			"card_faces": [
+				{"object": "card_face", "name": "Fire", "mana_cost": "{1}{R}", "type_line": "Instant"},
+				{"object": "card_face", "name": "Ice", "mana_cost": "{1}{U}", "type_line": "Instant"}
+			]
+		}`))
+	}))
+
+	got, isErr := callTool(t, s.handleGetCardDetails, map[string]any{"name": "Fire // Ice", "format": "json"})
+	if isErr {
+		t.Fatalf("handleGetCardDetails() returned error: %s", got)
+	}
+
+	var card cardJSON
+	if err := json.Unmarshal([]byte(got), &card); err != nil {
+		t.Fatalf("handleGetCardDetails() output is not JSON: %v\n%s", err, got)
+	}
+	if card.Name != "Fire // Ice" || len(card.Faces) != 2 || card.Faces[1].ManaCost != "{1}{U}" {
+		t.Errorf("handleGetCardDetails() card = %+v", card)
+	}
+
+	if got, isErr = callTool(t, s.handleGetCardDetails, map[string]any{"name": "Fire", "format": "yaml"}); !isErr {
+		t.Errorf("handleGetCardDetails() with an invalid format = %s, want an error", got)
+	}
+}
+
+func TestNewDeckJSON(t *testing.T) {
+	deck := &MoxfieldDeck{
+		PublicID: "abc123",
+		Name:     "Elves",
+		Format:   "commander",
+		Commanders: map[string]MoxfieldCardEntry{
+			"Lathril": {Quantity: 1, Card: MoxfieldCardInfo{
+				Name: "Lathril, Blade of the Elves", ColorIdentity: []string{"B", "G"},
+			}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"Llanowar Elves": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Llanowar Elves", TypeLine: "Creature — Elf"}},
+			"Forest":         {Quantity: 10, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+		},
+	}
+	prices := &DeckPriceSummary{TotalUSD: 3.5, Cards: []PricedCard{{Name: "Forest", Quantity: 10, UnitUSD: 0.35}}}
+
+	got := newDeckJSON(deck.ToDeck(), prices)
+	if got.TotalCards != 12 || got.URL != "https://www.moxfield.com/decks/abc123" {
+		t.Errorf("newDeckJSON() = %+v", got)
+	}
+	if len(got.Mainboard) != 2 || got.Mainboard[0].Name != "Forest" || got.Mainboard[0].Quantity != 10 {
+		t.Errorf("newDeckJSON() mainboard = %+v", got.Mainboard)
+	}
+	if len(got.ColorIdentity) != 2 || got.Sideboard == nil {
+		t.Errorf("newDeckJSON() identity = %v, sideboard = %v", got.ColorIdentity, got.Sideboard)
+	}
+	if got.Price == nil || got.Price.Tier != "Budget" || got.Price.MostExpensive[0].TotalUSD != 3.5 {
+		t.Errorf("newDeckJSON() price = %+v", got.Price)
+	}
+
+	if got := newDeckJSON(deck.ToDeck(), nil); got.Price != nil {
+		t.Errorf("newDeckJSON() without prices = %+v, want no price", got.Price)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Power level weighting. The estimate starts at powerLevelBase and each signal adds
+// points per card found, up to its cap; a low average mana value adds a point and a
+// high one takes one away. The result is clamped to 1-10 and rounded to a half point.
+const (
+	powerLevelBase         = 4.0
+	powerLevelMin          = 1.0
+	powerLevelMax          = 10.0
+	fastManaPoints         = 0.5
+	fastManaCap            = 2.0
+	tutorPoints            = 0.5
+	tutorCap               = 2.0
+	freeCounterspellPoints = 0.5
+	freeCounterspellCap    = 1.0
+	comboPoints            = 1.0
+	comboCap               = 2.0
+	lowCurveThreshold      = 2.5
+	highCurveThreshold     = 3.5
+	curvePoints            = 1.0
+	powerLevelRoundFactor  = 2
+)
+
+// fastManaCards are mana rocks and rituals that accelerate far past one land per turn.
+func fastManaCards() []string {
+	return []string{
+		"Sol Ring", "Mana Crypt", "Mana Vault", "Chrome Mox", "Mox Diamond", "Mox Opal", "Mox Amber",
+		"Lion's Eye Diamond", "Lotus Petal", "Jeweled Lotus", "Grim Monolith", "Ancient Tomb",
+		"Dark Ritual", "Simian Spirit Guide", "Elvish Spirit Guide", "Gemstone Caverns",
+	}
+}
+
+// tutorCards are unconditional or near-unconditional tutors.
+func tutorCards() []string {
+	return []string{
+		"Demonic Tutor", "Vampiric Tutor", "Imperial Seal", "Mystical Tutor", "Enlightened Tutor",
+		"Worldly Tutor", "Gamble", "Diabolic Intent", "Grim Tutor", "Wishclaw Talisman",
+		"Personal Tutor", "Idyllic Tutor", "Sylvan Tutor", "Merchant Scroll", "Intuition",
+		"Eladamri's Call", "Finale of Devastation", "Green Sun's Zenith", "Tainted Pact", "Demonic Consultation",
+	}
+}
+
+// freeCounterspellCards are counterspells that can be cast without spending mana.
+func freeCounterspellCards() []string {
+	return []string{
+		"Force of Will", "Force of Negation", "Fierce Guardianship", "Deflecting Swat",
+		"Pact of Negation", "Mental Misstep", "Misdirection", "Daze", "Commandeer", "Deadly Rollick",
+	}
+}
+
+// powerFactor is one signal that contributed to a power level estimate.
+type powerFactor struct {
+	label  string
+	points float64
+	cards  []string
+}
+
+// powerEstimate is a deck's estimated power level and the factors behind it.
+type powerEstimate struct {
+	score          float64
+	averageCMC     float64
+	factors        []powerFactor
+	combosChecked  bool
+	nonlandEntries int
+}
+
+// deckCardNames returns the normalized names of every commander and mainboard card.
+func deckCardNames(deck *Deck) map[string]string {
+	names := make(map[string]string)
+	for _, board := range [][]CardEntry{deck.Commanders, deck.Mainboard} {
+		for _, entry := range board {
+			names[normalizeCardName(entry.Name)] = entry.Name
+		}
+	}
+	return names
+}
+
+// matchCards returns the cards from list that are in the deck, sorted by name.
+func matchCards(deckNames map[string]string, list []string) []string {
+	var found []string
+	for _, name := range list {
+		if deckName, ok := deckNames[normalizeCardName(name)]; ok {
+			found = append(found, deckName)
+		}
+	}
+	slices.Sort(found)
+	return found
+}
+
+// completeCombos returns the combos whose every card is in the deck, each titled by its cards.
+func completeCombos(deckNames map[string]string, combos *EDHRECComboData) []string {
+	if combos == nil {
+		return nil
+	}
+
+	var found []string
+	for _, combo := range uniqueCombos(combos.CardLists) {
+		names := ComboCardNames(combo)
+		if len(names) == 0 {
+			continue
+		}
+		complete := true
+		for _, name := range names {
+			if _, ok := deckNames[normalizeCardName(name)]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			found = append(found, strings.Join(names, " + "))
+		}
+	}
+	return found
+}
+
+// estimatePowerLevel scores a deck from 1 to 10 using the weighting documented on
+// the constants above. combos are EDHREC's combos for the deck's colors; nil skips
+// the combo check.
+func estimatePowerLevel(deck *Deck, combos *EDHRECComboData) powerEstimate {
+	deckNames := deckCardNames(deck)
+	estimate := powerEstimate{combosChecked: combos != nil}
+
+	signals := []struct {
+		label  string
+		cards  []string
+		points float64
+		limit  float64
+	}{
+		{"Fast mana", matchCards(deckNames, fastManaCards()), fastManaPoints, fastManaCap},
+		{"Tutors", matchCards(deckNames, tutorCards()), tutorPoints, tutorCap},
+		{
+			"Free counterspells", matchCards(deckNames, freeCounterspellCards()),
+			freeCounterspellPoints, freeCounterspellCap,
+		},
+		{"Complete combos", completeCombos(deckNames, combos), comboPoints, comboCap},
+	}
+
+	score := powerLevelBase
+	for _, signal := range signals {
+		if len(signal.cards) == 0 {
+			continue
+		}
+		points := min(float64(len(signal.cards))*signal.points, signal.limit)
+		score += points
+		estimate.factors = append(estimate.factors,
+			powerFactor{label: signal.label, points: points, cards: signal.cards})
+	}
+
+	groups := groupDeckCards(deck.Mainboard)
+	estimate.nonlandEntries = groups.nonlandCount
+	if groups.nonlandCount > 0 {
+		estimate.averageCMC = float64(groups.nonlandCMCSum) / float64(groups.nonlandCount)
+		switch {
+		case estimate.averageCMC < lowCurveThreshold:
+			score += curvePoints
+			estimate.factors = append(estimate.factors, powerFactor{label: "Low curve", points: curvePoints})
+		case estimate.averageCMC > highCurveThreshold:
+			score -= curvePoints
+			estimate.factors = append(estimate.factors, powerFactor{label: "High curve", points: -curvePoints})
+		}
+	}
+
+	score = math.Max(powerLevelMin, math.Min(powerLevelMax, score))
+	estimate.score = math.Round(score*powerLevelRoundFactor) / powerLevelRoundFactor
+	return estimate
+}
+
+// FormatPowerLevelForDisplay formats a power level estimate with its contributing factors.
+func FormatPowerLevelForDisplay(deck *Deck, estimate powerEstimate) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Power Level Estimate: %s\n\n", deck.Name))
+	output.WriteString(fmt.Sprintf("**Estimated Power Level:** %.1f / 10\n\n", estimate.score))
+
+	output.WriteString("## Contributing Factors\n\n")
+	output.WriteString(fmt.Sprintf("- Baseline: %+.1f\n", powerLevelBase))
+	for _, factor := range estimate.factors {
+		line := fmt.Sprintf("- %s: %+.1f", factor.label, factor.points)
+		if len(factor.cards) > 0 {
+			line += " (" + strings.Join(factor.cards, ", ") + ")"
+		}
+		output.WriteString(line + "\n")
+	}
+	if estimate.nonlandEntries > 0 {
+		output.WriteString(fmt.Sprintf("- Average nonland CMC: %.2f\n", estimate.averageCMC))
+	}
+	if !estimate.combosChecked {
+		output.WriteString("- Combo check skipped: EDHREC combo data was unavailable\n")
+	}
+
+	output.WriteString(fmt.Sprintf("\n## How This Is Scored\n\n"+
+		"Starts at %.0f. Fast mana and tutors add %.1f each (up to %+.0f), free counterspells add %.1f each "+
+		"(up to %+.0f), and each complete EDHREC combo for the deck's colors adds %.0f (up to %+.0f). "+
+		"An average nonland CMC under %.1f adds %.0f; over %.1f subtracts %.0f. The result is clamped to 1-10.\n",
+		powerLevelBase, fastManaPoints, fastManaCap, freeCounterspellPoints, freeCounterspellCap,
+		comboPoints, comboCap, lowCurveThreshold, curvePoints, highCurveThreshold, curvePoints))
+	output.WriteString("\n*This is a heuristic, not gospel: it can't see synergy, interaction density, " +
+		"or play patterns. Use it to start the power level conversation with your pod, not to end it.*\n")
+
+	return output.String()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// powerLevelTestDeck builds a Kinnan deck from artifact card names, each with the given mana value.
+func powerLevelTestDeck(cmc float64, names ...string) *MoxfieldDeck {
+	deck := &MoxfieldDeck{
+		Name: "Test Deck",
+		Commanders: map[string]MoxfieldCardEntry{
+			"Kinnan": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Kinnan, Bonder Prodigy"}},
+		},
+		Mainboard: make(map[string]MoxfieldCardEntry),
+	}
+	for _, name := range names {
+		deck.Mainboard[name] = MoxfieldCardEntry{
+			Quantity: 1,
+			Card:     MoxfieldCardInfo{Name: name, TypeLine: "Artifact", CMC: cmc},
+		}
+	}
+	return deck
+}
+
+func TestEstimatePowerLevel(t *testing.T) {
+	combos := &EDHRECComboData{CardLists: []EDHRECComboList{
+		{CardViews: []EDHRECCardView{{Name: "Kinnan, Bonder Prodigy"}, {Name: "Basalt Monolith"}}},
+		{CardViews: []EDHRECCardView{{Name: "Thassa's Oracle"}, {Name: "Demonic Consultation"}}},
+	}}
+
+	tests := []struct {
+		name         string
+		deck         *MoxfieldDeck
+		combos       *EDHRECComboData
+		wantScore    float64
+		wantContains []string
+	}{
+		{
+			name:      "casual deck with a high curve",
+			deck:      powerLevelTestDeck(5, "Gray Merchant of Asphodel", "Sever the Bloodline"),
+			combos:    combos,
+			wantScore: 3,
+			wantContains: []string{
+				"**Estimated Power Level:** 3.0 / 10",
+				"- High curve: -1.0",
+				"- Average nonland CMC: 5.00",
+			},
+		},
+		{
+			name: "fast mana, tutors, and a combo",
+			deck: powerLevelTestDeck(1, "Sol Ring", "Mana Crypt", "Mana Vault", "Demonic Tutor",
+				"Force of Will", "Basalt Monolith"),
+			combos:    combos,
+			wantScore: 8.5,
+			wantContains: []string{
+				"- Fast mana: +1.5 (Mana Crypt, Mana Vault, Sol Ring)",
+				"- Tutors: +0.5 (Demonic Tutor)",
+				"- Free counterspells: +0.5 (Force of Will)",
+				"- Complete combos: +1.0 (Kinnan, Bonder Prodigy + Basalt Monolith)",
+				"- Low curve: +1.0",
+			},
+		},
+		{
+			name: "capped signals and clamped score",
+			deck: powerLevelTestDeck(1, "Sol Ring", "Mana Crypt", "Mana Vault", "Chrome Mox", "Mox Diamond",
+				"Demonic Tutor", "Vampiric Tutor", "Imperial Seal", "Mystical Tutor", "Enlightened Tutor",
+				"Force of Will", "Force of Negation", "Fierce Guardianship", "Basalt Monolith",
+				"Thassa's Oracle", "Demonic Consultation"),
+			combos:       combos,
+			wantScore:    10,
+			wantContains: []string{"- Fast mana: +2.0", "- Tutors: +2.0", "- Free counterspells: +1.0"},
+		},
+		{
+			name:         "combo data unavailable",
+			deck:         powerLevelTestDeck(3, "Basalt Monolith"),
+			wantScore:    4,
+			wantContains: []string{"Combo check skipped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := estimatePowerLevel(tt.deck.ToDeck(), tt.combos)
+			if estimate.score != tt.wantScore {
+				t.Errorf("estimatePowerLevel() score = %.1f, want %.1f", estimate.score, tt.wantScore)
+			}
+
+			got := FormatPowerLevelForDisplay(tt.deck.ToDeck(), estimate)
+			for _, want := range append(tt.wantContains, "## How This Is Scored") {
+				if !strings.Contains(got, want) {
+					t.Errorf("FormatPowerLevelForDisplay() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// defaultTopPricedCards is how many of the most expensive cards a price summary lists.
+const defaultTopPricedCards = 10
+
+// Deck price tiers: the upper USD bound of each tier below premium.
+const (
+	budgetDeckMaxUSD   = 150.0
+	midRangeDeckMaxUSD = 500.0
+	highEndDeckMaxUSD  = 1500.0
+)
+
+// PricedCard is a deck entry with its resolved USD price.
+type PricedCard struct {
+	Name     string
+	Quantity int
+	UnitUSD  float64
+}
+
+// TotalUSD returns the price of all copies of the card.
+func (p PricedCard) TotalUSD() float64 {
+	return p.UnitUSD * float64(p.Quantity)
+}
+
+// DeckPriceSummary aggregates the price of a list of cards.
+type DeckPriceSummary struct {
+	TotalUSD float64
+	Cards    []PricedCard // sorted by total price, most expensive first
+	Unpriced []string
+}
+
+// cardUSDPrice returns the cheapest available USD price for a card's default printing.
+func cardUSDPrice(card scryfall.Card) (float64, bool) {
+	best := 0.0
+	found := false
+	for _, priceStr := range []string{card.Prices.USD, card.Prices.USDFoil, card.Prices.USDEtched} {
+		if priceStr == "" {
+			continue
+		}
+		price, err := strconv.ParseFloat(priceStr, 64)
+		if err != nil {
+			continue
+		}
+		if !found || price < best {
+			best = price
+			found = true
+		}
+	}
+	return best, found
+}
+
+// summarizeDeckPrices prices decklist entries using batch-resolved Scryfall cards.
+func summarizeDeckPrices(entries []DecklistEntry, lookup CardLookupResult) DeckPriceSummary {
+	var summary DeckPriceSummary
+
+	for _, entry := range entries {
+		card, ok := lookup.Get(entry.Name)
+		if !ok {
+			summary.Unpriced = append(summary.Unpriced, entry.Name)
+			continue
+		}
+
+		price, hasPrice := cardUSDPrice(card)
+		if !hasPrice {
+			summary.Unpriced = append(summary.Unpriced, card.Name)
+			continue
+		}
+
+		priced := PricedCard{Name: card.Name, Quantity: entry.Quantity, UnitUSD: price}
+		summary.Cards = append(summary.Cards, priced)
+		summary.TotalUSD += priced.TotalUSD()
+	}
+
+	sort.SliceStable(summary.Cards, func(i, j int) bool {
+		return summary.Cards[i].TotalUSD() > summary.Cards[j].TotalUSD()
+	})
+
+	return summary
+}
+
+// FormatAverageDeckPriceForDisplay formats the price summary of a commander's average deck.
+func FormatAverageDeckPriceForDisplay(commander string, summary DeckPriceSummary, usdToBRL float64) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# Average Deck Price for %s\n\n", commander))
+	output.WriteString(fmt.Sprintf("**Total (USD):** $%.2f\n", summary.TotalUSD))
+	output.WriteString(fmt.Sprintf("**Total (BRL):** R$ %.2f (converted)\n", summary.TotalUSD*usdToBRL))
+	output.WriteString(fmt.Sprintf("**Priced Cards:** %d\n", len(summary.Cards)))
+
+	if len(summary.Cards) > 0 {
+		count := min(defaultTopPricedCards, len(summary.Cards))
+		output.WriteString(fmt.Sprintf("\n## Most Expensive Staples (Top %d)\n\n", count))
+		output.WriteString(formatTopPricedCards(summary.Cards[:count]))
+	}
+
+	if len(summary.Unpriced) > 0 {
+		output.WriteString(fmt.Sprintf("\n**No price data (%d):** %s\n", len(summary.Unpriced),
+			strings.Join(summary.Unpriced, ", ")))
+	}
+
+	output.WriteString(fmt.Sprintf("\n*Exchange rate: 1 USD = %.4f BRL*\n", usdToBRL))
+	output.WriteString("*Prices use the cheapest USD finish of each card's default Scryfall printing.*\n")
+
+	return output.String()
+}
+
+// formatTopPricedCards lists priced cards as a numbered list, with the total for
+// entries of more than one copy.
+func formatTopPricedCards(cards []PricedCard) string {
+	var output strings.Builder
+	for i, card := range cards {
+		output.WriteString(fmt.Sprintf("%d. **%s** - $%.2f", i+1, card.Name, card.UnitUSD))
+		if card.Quantity > 1 {
+			output.WriteString(fmt.Sprintf(" x%d ($%.2f)", card.Quantity, card.TotalUSD()))
+		}
+		output.WriteString("\n")
+	}
+	return output.String()
+}
+
+// deckPriceTier names the price bracket of a deck's total USD price.
+func deckPriceTier(totalUSD float64) string {
+	switch {
+	case totalUSD < budgetDeckMaxUSD:
+		return "Budget"
+	case totalUSD < midRangeDeckMaxUSD:
+		return "Mid-range"
+	case totalUSD < highEndDeckMaxUSD:
+		return "High-end"
+	default:
+		return "Premium"
+	}
+}
+
+// formatDeckPriceSection formats a deck's estimated price, its tier, and the most
+// expensive cards, so it's clear where the money in the deck goes.
+func formatDeckPriceSection(summary DeckPriceSummary) string {
+	var output strings.Builder
+	output.WriteString("\n## Deck Price\n\n")
+	output.WriteString(fmt.Sprintf("**Estimated Total (USD):** $%.2f (%s)\n", summary.TotalUSD,
+		deckPriceTier(summary.TotalUSD)))
+	output.WriteString(fmt.Sprintf("**Priced Cards:** %d\n", len(summary.Cards)))
+
+	if len(summary.Cards) > 0 {
+		count := min(defaultTopPricedCards, len(summary.Cards))
+		output.WriteString(fmt.Sprintf("\n### Most Expensive Cards (Top %d)\n\n", count))
+		output.WriteString(formatTopPricedCards(summary.Cards[:count]))
+	}
+
+	if len(summary.Unpriced) > 0 {
+		output.WriteString(fmt.Sprintf("\n**No price data (%d):** %s\n", len(summary.Unpriced),
+			strings.Join(summary.Unpriced, ", ")))
+	}
+
+	output.WriteString(fmt.Sprintf("\n*Tiers: Budget under $%.0f, Mid-range under $%.0f, High-end under $%.0f, "+
+		"Premium above. Prices use the cheapest USD finish of each mainboard card's default Scryfall printing.*\n",
+		budgetDeckMaxUSD, midRangeDeckMaxUSD, highEndDeckMaxUSD))
+
+	return output.String()
+}
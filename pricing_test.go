@@ -0,0 +1,147 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestCardUSDPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		prices    scryfall.Prices
+		wantPrice float64
+		wantOK    bool
+	}{
+		{name: "nonfoil", prices: scryfall.Prices{USD: "1.50", USDFoil: "4.00"}, wantPrice: 1.50, wantOK: true},
+		{name: "foil only", prices: scryfall.Prices{USDFoil: "4.00"}, wantPrice: 4.00, wantOK: true},
+		{name: "etched cheaper", prices: scryfall.Prices{USD: "9.00", USDEtched: "3.00"}, wantPrice: 3.00, wantOK: true},
+		{name: "no prices", prices: scryfall.Prices{EUR: "1.00"}, wantOK: false},
+		{name: "invalid price", prices: scryfall.Prices{USD: "n/a"}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cardUSDPrice(scryfall.Card{Prices: tt.prices})
+			if ok != tt.wantOK || got != tt.wantPrice {
+				t.Errorf("cardUSDPrice() = %v, %v, want %v, %v", got, ok, tt.wantPrice, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAverageDeckPrice(t *testing.T) {
+	// Synthetic EDHREC average deck with mocked Scryfall prices
+	avgDeck := &EDHRECAverageDeck{
+		Deck: []string{
+			"1 Mana Crypt",
+			"1 Sol Ring",
+			"1 Doubling Season",
+			"8 Forest",
+			"1 Unknown Card",
+			"1 Unpriced Promo",
+		},
+	}
+
+	known := map[string]scryfall.Card{
+		"mana crypt":      {Name: "Mana Crypt", Prices: scryfall.Prices{USD: "150.00"}},
+		"sol ring":        {Name: "Sol Ring", Prices: scryfall.Prices{USD: "1.50"}},
+		"doubling season": {Name: "Doubling Season", Prices: scryfall.Prices{USD: "40.00"}},
+		"forest":          {Name: "Forest", Prices: scryfall.Prices{USD: "0.25"}},
+		"unpriced promo":  {Name: "Unpriced Promo"},
+	}
+
+	s := newTestMTGServer(t, collectionHandler(t, known, nil))
+
+	entries := avgDeck.Entries()
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+
+	lookup, err := s.lookupCardsByName(t.Context(), names)
+	if err != nil {
+		t.Fatalf("lookupCardsByName() error = %v", err)
+	}
+
+	summary := summarizeDeckPrices(entries, lookup)
+
+	if want := 150.00 + 1.50 + 40.00 + 8*0.25; math.Abs(summary.TotalUSD-want) > 0.001 {
+		t.Errorf("summarizeDeckPrices() total = %.2f, want %.2f", summary.TotalUSD, want)
+	}
+
+	if summary.Cards[0].Name != "Mana Crypt" || summary.Cards[1].Name != "Doubling Season" {
+		t.Errorf("summarizeDeckPrices() not sorted by price: %+v", summary.Cards)
+	}
+
+	if len(summary.Unpriced) != 2 {
+		t.Errorf("summarizeDeckPrices() unpriced = %v, want 2 entries", summary.Unpriced)
+	}
+
+	got := FormatAverageDeckPriceForDisplay("Test Commander", summary, 5.0)
+	for _, want := range []string{
+		"Average Deck Price for Test Commander",
+		"$193.50",
+		"R$ 967.50",
+		"Most Expensive Staples",
+		"1. **Mana Crypt** - $150.00",
+		"**Forest** - $0.25 x8 ($2.00)",
+		"Unknown Card",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatAverageDeckPriceForDisplay() missing %q in output", want)
+		}
+	}
+}
+
+func TestDeckPriceTier(t *testing.T) {
+	tests := []struct {
+		total float64
+		want  string
+	}{
+		{total: 80, want: "Budget"},
+		{total: 150, want: "Mid-range"},
+		{total: 999.99, want: "High-end"},
+		{total: 4200, want: "Premium"},
+	}
+
+	for _, tt := range tests {
+		if got := deckPriceTier(tt.total); got != tt.want {
+			t.Errorf("deckPriceTier(%.2f) = %s, want %s", tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDeckForDisplayWithPrices(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Name:   "Priced Deck",
+		Format: "commander",
+		Mainboard: map[string]MoxfieldCardEntry{
+			"Mana Crypt": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Mana Crypt", TypeLine: "Artifact"}},
+			"Forest":     {Quantity: 8, Card: MoxfieldCardInfo{Name: "Forest", TypeLine: "Basic Land — Forest"}},
+		},
+	}
+	lookup := CardLookupResult{Cards: map[string]scryfall.Card{
+		"mana crypt": {Name: "Mana Crypt", Prices: scryfall.Prices{USD: "150.00"}},
+		"forest":     {Name: "Forest", Prices: scryfall.Prices{USD: "0.25"}},
+	}}
+	summary := summarizeDeckPrices(deckDecklist(deck.ToDeck()).Entries, lookup)
+
+	got := FormatDeckForDisplay(deck.ToDeck(), &summary)
+	for _, want := range []string{
+		"## Deck Price",
+		"**Estimated Total (USD):** $152.00 (Mid-range)",
+		"1. **Mana Crypt** - $150.00",
+		"2. **Forest** - $0.25 x8 ($2.00)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDeckForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+
+	if got := FormatDeckForDisplay(deck.ToDeck(), nil); strings.Contains(got, "## Deck Price") {
+		t.Error("FormatDeckForDisplay() without prices should not include a price section")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// defaultPrintingsLimit is how many printings get_card_printings lists by default.
+	defaultPrintingsLimit = 25
+	// maxPrintingsLimit is one page of Scryfall search results.
+	maxPrintingsLimit = 175
+	// maxPriceSpreadPrintings caps how many printings get_card_price_history compares:
+	// two pages of Scryfall search results, enough for all but the basic lands.
+	maxPriceSpreadPrintings = 2 * scryfallSearchPageSize
+)
+
+// sortPrintingsByRelease sorts printings newest first, breaking ties by set code
+// and collector number so the order is stable.
+func sortPrintingsByRelease(cards []scryfall.Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		if !cards[i].ReleasedAt.Equal(cards[j].ReleasedAt.Time) {
+			return cards[i].ReleasedAt.After(cards[j].ReleasedAt.Time)
+		}
+		if cards[i].Set != cards[j].Set {
+			return cards[i].Set < cards[j].Set
+		}
+		return cards[i].CollectorNumber < cards[j].CollectorNumber
+	})
+}
+
+// FormatPrintingsForDisplay formats a table of a card's printings.
+func FormatPrintingsForDisplay(cardName string, printings []scryfall.Card, totalPrintings int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Printings of %s\n\n", cardName))
+	output.WriteString(fmt.Sprintf("Showing %d of %d printings (newest first)\n\n", len(printings), totalPrintings))
+
+	output.WriteString("| Set | Code | # | Rarity | USD | Foil |\n")
+	output.WriteString("|-----|------|---|--------|-----|------|\n")
+	for _, card := range printings {
+		usd := "-"
+		if card.Prices.USD != "" {
+			usd = "$" + card.Prices.USD
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			card.SetName, strings.ToUpper(card.Set), card.CollectorNumber, card.Rarity, usd, yesNo(card.Foil)))
+	}
+
+	return output.String()
+}
+
+// printingPrice is a printing with its cheapest USD price across finishes.
+type printingPrice struct {
+	card scryfall.Card
+	usd  float64
+}
+
+// pricedPrintings returns the printings that have a USD price, cheapest first, and
+// how many have none. Ties are broken by set code and collector number.
+func pricedPrintings(printings []scryfall.Card) ([]printingPrice, int) {
+	var priced []printingPrice
+	unpriced := 0
+	for _, card := range printings {
+		usd, ok := cardUSDPrice(card)
+		if !ok {
+			unpriced++
+			continue
+		}
+		priced = append(priced, printingPrice{card: card, usd: usd})
+	}
+
+	sort.SliceStable(priced, func(i, j int) bool {
+		a, b := priced[i], priced[j]
+		if a.usd != b.usd {
+			return a.usd < b.usd
+		}
+		if a.card.Set != b.card.Set {
+			return a.card.Set < b.card.Set
+		}
+		return a.card.CollectorNumber < b.card.CollectorNumber
+	})
+	return priced, unpriced
+}
+
+// medianPrice returns the median of prices sorted cheapest first.
+func medianPrice(sorted []printingPrice) float64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid].usd
+	}
+	return (sorted[mid-1].usd + sorted[mid].usd) / 2
+}
+
+// FormatPriceSpreadForDisplay formats the spread of a card's current USD prices
+// across its printings, followed by every priced printing, cheapest first.
+// Scryfall keeps no price history, so this stands in for one.
+func FormatPriceSpreadForDisplay(cardName string, priced []printingPrice, unpriced, totalPrintings int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Price Spread for %s\n\n", cardName))
+	output.WriteString("*Scryfall keeps no price history, so this compares today's USD prices across printings " +
+		"(the cheapest finish of each).*\n\n")
+
+	compared := len(priced) + unpriced
+	if compared < totalPrintings {
+		output.WriteString(fmt.Sprintf("Compared %d of %d printings.\n\n", compared, totalPrintings))
+	}
+
+	if len(priced) == 0 {
+		output.WriteString("None of the printings has a USD price.\n")
+		return output.String()
+	}
+
+	cheapest, priciest := priced[0], priced[len(priced)-1]
+	output.WriteString(fmt.Sprintf("**Priced Printings:** %d", len(priced)))
+	if unpriced > 0 {
+		output.WriteString(fmt.Sprintf(" (%d without a USD price)", unpriced))
+	}
+	output.WriteString("\n")
+	output.WriteString(fmt.Sprintf("**Min:** $%.2f (%s)\n", cheapest.usd, cheapest.card.SetName))
+	output.WriteString(fmt.Sprintf("**Median:** $%.2f\n", medianPrice(priced)))
+	output.WriteString(fmt.Sprintf("**Max:** $%.2f (%s)\n", priciest.usd, priciest.card.SetName))
+
+	output.WriteString("\n| Set | Code | # | USD |\n")
+	output.WriteString("|-----|------|---|-----|\n")
+	for _, p := range priced {
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | $%.2f |\n",
+			p.card.SetName, strings.ToUpper(p.card.Set), p.card.CollectorNumber, p.usd))
+	}
+
+	return output.String()
+}
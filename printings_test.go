@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestSortPrintingsByRelease(t *testing.T) {
+	date := func(s string) scryfall.Date {
+		d, _ := time.Parse(time.DateOnly, s)
+		return scryfall.Date{Time: d}
+	}
+
+	printings := []scryfall.Card{
+		{Set: "lea", ReleasedAt: date("1993-08-05")},
+		{Set: "c21", CollectorNumber: "264", ReleasedAt: date("2021-04-23")},
+		{Set: "cmm", ReleasedAt: date("2023-08-04")},
+		{Set: "c21", CollectorNumber: "263", ReleasedAt: date("2021-04-23")},
+	}
+
+	sortPrintingsByRelease(printings)
+
+	var got []string
+	for _, p := range printings {
+		got = append(got, p.Set+p.CollectorNumber)
+	}
+	if want := "cmm,c21263,c21264,lea"; strings.Join(got, ",") != want {
+		t.Errorf("sortPrintingsByRelease() = %v, want %s", got, want)
+	}
+}
+
+func TestHandleGetCardPrintings(t *testing.T) {
+	var gotQuery string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/search" {
+			writeScryfallNotFound(w)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		switch r.URL.Query().Get("q") {
+		case `!"Sol Ring"`:
+		case `!"Broken Query"`:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusBadRequest, Details: "Bad query"})
+			return
+		case `!"Unavailable"`:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusServiceUnavailable, Details: "Down"})
+			return
+		default:
+			writeScryfallNotFound(w)
+			return
+		}
+
+		// Returned out of order to check the handler sorts newest first
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"total_cards": 3,
+			"has_more": false,
+			"data": [
+				{"name": "Sol Ring", "set": "lea", "set_name": "Limited Edition Alpha", "collector_number": "270",
+				 "rarity": "uncommon", "released_at": "1993-08-05", "foil": false, "prices": {"usd": null}},
+				{"name": "Sol Ring", "set": "cmm", "set_name": "Commander Masters", "collector_number": "410",
+				 "rarity": "uncommon", "released_at": "2023-08-04", "foil": true, "prices": {"usd": "1.25"}},
+				{"name": "Sol Ring", "set": "c21", "set_name": "Commander 2021", "collector_number": "263",
+				 "rarity": "uncommon", "released_at": "2021-04-23", "foil": false, "prices": {"usd": "2.00"}}
+			]
+		}`))
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name: "all printings",
+			args: map[string]any{"name": "Sol Ring"},
+			wantContains: []string{
+				"Showing 3 of 3 printings",
+				"| Commander Masters | CMM | 410 | uncommon | $1.25 | yes |",
+				"| Limited Edition Alpha | LEA | 270 | uncommon | - | no |",
+			},
+		},
+		{
+			name:         "limited",
+			args:         map[string]any{"name": "Sol Ring", "limit": float64(1)},
+			wantContains: []string{"Showing 1 of 3 printings", "Commander Masters"},
+			wantMissing:  []string{"Commander 2021", "Limited Edition Alpha"},
+		},
+		{
+			name:         "unknown card",
+			args:         map[string]any{"name": "Not A Card"},
+			wantErr:      true,
+			wantContains: []string{"No printings found for Not A Card."},
+		},
+		{
+			name:         "rejected query",
+			args:         map[string]any{"name": "Broken Query"},
+			wantErr:      true,
+			wantContains: []string{"Scryfall rejected the query"},
+		},
+		{
+			name:         "upstream failure",
+			args:         map[string]any{"name": "Unavailable"},
+			wantErr:      true,
+			wantContains: []string{"Printings lookup failed:"},
+			wantMissing:  []string{"No printings found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetCardPrintings, tt.args)
+
+			if isErr != tt.wantErr {
+				t.Errorf("handleGetCardPrintings() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetCardPrintings() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleGetCardPrintings() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	if !strings.Contains(gotQuery, "unique=prints") {
+		t.Errorf("handleGetCardPrintings() query = %q, want unique=prints", gotQuery)
+	}
+
+	callTool(t, s.handleGetCardPrintings, map[string]any{"name": `Kongming, "Sleeping Dragon"`})
+	if values, _ := url.ParseQuery(gotQuery); values.Get("q") != `!"Kongming, \"Sleeping Dragon\""` {
+		t.Errorf("handleGetCardPrintings() didn't escape quotes in the name: %q", values.Get("q"))
+	}
+}
+
+func TestPricedPrintings(t *testing.T) {
+	printings := []scryfall.Card{
+		{Set: "lea", Prices: scryfall.Prices{USD: "450.00"}},
+		{Set: "cmm", Prices: scryfall.Prices{USD: "1.25", USDFoil: "0.90"}},
+		{Set: "sld"},
+		{Set: "c21", Prices: scryfall.Prices{USD: "2.00"}},
+		{Set: "c20", Prices: scryfall.Prices{USD: "2.00"}},
+	}
+
+	priced, unpriced := pricedPrintings(printings)
+	if unpriced != 1 {
+		t.Errorf("pricedPrintings() unpriced = %d, want 1", unpriced)
+	}
+
+	var got []string
+	for _, p := range priced {
+		got = append(got, p.card.Set)
+	}
+	if want := "cmm,c20,c21,lea"; strings.Join(got, ",") != want {
+		t.Errorf("pricedPrintings() order = %v, want %s", got, want)
+	}
+	if priced[0].usd != 0.90 {
+		t.Errorf("pricedPrintings() cheapest = %v, want the foil price 0.90", priced[0].usd)
+	}
+
+	if got := medianPrice(priced); got != 2.00 {
+		t.Errorf("medianPrice() = %v, want 2.00", got)
+	}
+	if got := medianPrice(priced[:3]); got != 2.00 {
+		t.Errorf("medianPrice() of three = %v, want 2.00", got)
+	}
+	if got := medianPrice(priced[:2]); got != 1.45 {
+		t.Errorf("medianPrice() of two = %v, want 1.45", got)
+	}
+}
+
+func TestHandleGetCardPriceHistory(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case `!"Sol Ring"`:
+		case `!"Broken Query"`:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusBadRequest, Details: "Bad query"})
+			return
+		case `!"Unavailable"`:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusServiceUnavailable, Details: "Down"})
+			return
+		default:
+			writeScryfallNotFound(w)
+			return
+		}
+		if r.URL.Query().Get("unique") != "prints" {
+			t.Errorf("unique = %q, want prints", r.URL.Query().Get("unique"))
+		}
+
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"total_cards": 4,
+			"has_more": false,
+			"data": [
+				{"name": "Sol Ring", "set": "lea", "set_name": "Limited Edition Alpha", "collector_number": "270",
+				 "prices": {"usd": "3000.00"}},
+				{"name": "Sol Ring", "set": "cmm", "set_name": "Commander Masters", "collector_number": "410",
+				 "prices": {"usd": "1.25"}},
+				{"name": "Sol Ring", "set": "c21", "set_name": "Commander 2021", "collector_number": "263",
+				 "prices": {"usd": "2.00"}},
+				{"name": "Sol Ring", "set": "pcel", "set_name": "Celebration Cards", "collector_number": "1",
+				 "prices": {"usd": null}}
+			]
+		}`))
+	}))
+
+	got, isErr := callTool(t, s.handleGetCardPriceHistory, map[string]any{"name": "Sol Ring"})
+	if isErr {
+		t.Fatalf("handleGetCardPriceHistory() returned error: %s", got)
+	}
+	for _, want := range []string{
+		"**Priced Printings:** 3 (1 without a USD price)",
+		"**Min:** $1.25 (Commander Masters)",
+		"**Median:** $2.00",
+		"**Max:** $3000.00 (Limited Edition Alpha)",
+		"| Commander Masters | CMM | 410 | $1.25 |\n| Commander 2021 | C21 | 263 | $2.00 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleGetCardPriceHistory() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "Compared") {
+		t.Errorf("handleGetCardPriceHistory() compared every printing but says otherwise: %s", got)
+	}
+
+	for name, want := range map[string]string{
+		"Not A Card":   "No printings found for Not A Card.",
+		"Broken Query": "Scryfall rejected the query",
+		"Unavailable":  "Price lookup failed:",
+	} {
+		got, isErr := callTool(t, s.handleGetCardPriceHistory, map[string]any{"name": name})
+		if !isErr || !strings.Contains(got, want) {
+			t.Errorf("handleGetCardPriceHistory(%q) = %q, isError %v, want %q", name, got, isErr, want)
+		}
+	}
+}
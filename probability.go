@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// openingHandSize is the number of cards in an opening hand.
+	openingHandSize = 7
+	// defaultProbabilityDeckSize is the library size draw_probability assumes without
+	// a deck: a 100-card Commander deck minus the commander in the command zone.
+	defaultProbabilityDeckSize = deckValidationCommanderCount - 1
+	// turnOneRampMaxCMC is the mana value below which a mana rock counts as a turn-1
+	// ramp piece.
+	turnOneRampMaxCMC = 2
+)
+
+// drawProbability returns the hypergeometric probability of drawing at least one of
+// copies cards when drawing drawn cards from a deck of deckSize cards.
+func drawProbability(deckSize, copies, drawn int) float64 {
+	if copies <= 0 || drawn <= 0 {
+		return 0
+	}
+	if drawn > deckSize-copies {
+		return 1
+	}
+
+	// The chance of missing every copy, multiplied out one draw at a time
+	miss := 1.0
+	for i := range drawn {
+		miss *= float64(deckSize-copies-i) / float64(deckSize-i)
+	}
+	return 1 - miss
+}
+
+// isTurnOneManaRock reports whether a card is a noncreature mana rock cheap enough to
+// cast on turn 1 (mana value below turnOneRampMaxCMC).
+func isTurnOneManaRock(card scryfall.Card) bool {
+	typeLine := card.TypeLine
+	return strings.Contains(typeLine, "Artifact") &&
+		!strings.Contains(typeLine, "Creature") &&
+		!strings.Contains(typeLine, "Land") &&
+		card.CMC < turnOneRampMaxCMC &&
+		len(card.ProducedMana) > 0
+}
+
+// cardsSeenLabel describes how many cards are seen: the opening hand alone, or the
+// opening hand plus a draw per turn.
+func cardsSeenLabel(drawn int) string {
+	switch {
+	case drawn == openingHandSize:
+		return fmt.Sprintf("%d (opening hand)", drawn)
+	case drawn > openingHandSize:
+		turns := drawn - openingHandSize
+		return fmt.Sprintf("%d (opening hand + %d draws, by turn %d on the draw)", drawn, turns, turns)
+	default:
+		return fmt.Sprintf("%d", drawn)
+	}
+}
+
+// FormatDrawProbabilityForDisplay formats the chance of drawing at least one of
+// copies cards among drawn cards from a deck of deckSize cards. label names what is
+// being counted, e.g. "copies" or "turn-1 mana rocks". When the count came from a
+// deck, deckName titles the output and counted lists the cards behind the count.
+func FormatDrawProbabilityForDisplay(
+	deckName string,
+	deckSize, copies, drawn int,
+	label string,
+	counted []string,
+) string {
+	var output strings.Builder
+	if deckName != "" {
+		output.WriteString(fmt.Sprintf("# Draw Probability: %s\n\n", deckName))
+	} else {
+		output.WriteString("# Draw Probability\n\n")
+	}
+	output.WriteString(fmt.Sprintf("**Deck:** %d cards, %d %s\n", deckSize, copies, label))
+	if len(counted) > 0 {
+		output.WriteString(fmt.Sprintf("**Counted:** %s\n", strings.Join(counted, ", ")))
+	}
+	output.WriteString(fmt.Sprintf("**Cards Seen:** %s\n", cardsSeenLabel(drawn)))
+	output.WriteString(fmt.Sprintf("**Chance of at least one:** %.2f%%\n",
+		drawProbability(deckSize, copies, drawn)*percentageMultiplier))
+	return output.String()
+}
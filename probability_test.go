@@ -0,0 +1,122 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestDrawProbability(t *testing.T) {
+	tests := []struct {
+		name     string
+		deckSize int
+		copies   int
+		drawn    int
+		want     float64
+	}{
+		{name: "singleton in opening hand", deckSize: 99, copies: 1, drawn: 7, want: 7.0 / 99},
+		{name: "playset in 60 cards", deckSize: 60, copies: 4, drawn: 7, want: 0.3994996257446656},
+		{name: "ten ramp pieces", deckSize: 99, copies: 10, drawn: 7, want: 0.5371630300080192},
+		{name: "by turn three", deckSize: 99, copies: 8, drawn: 10, want: 0.5874713963114954},
+		{name: "lands in limited", deckSize: 40, copies: 17, drawn: 7, want: 0.9868503118503118},
+		{name: "no copies", deckSize: 99, copies: 0, drawn: 7, want: 0},
+		{name: "more draws than misses", deckSize: 10, copies: 4, drawn: 7, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := drawProbability(tt.deckSize, tt.copies, tt.drawn)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("drawProbability(%d, %d, %d) = %v, want %v", tt.deckSize, tt.copies, tt.drawn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTurnOneManaRock(t *testing.T) {
+	tests := []struct {
+		card scryfall.Card
+		want bool
+	}{
+		{
+			card: scryfall.Card{Name: "Sol Ring", TypeLine: "Artifact", CMC: 1, ProducedMana: []scryfall.Color{"C"}},
+			want: true,
+		},
+		{
+			card: scryfall.Card{Name: "Mana Crypt", TypeLine: "Artifact", CMC: 0, ProducedMana: []scryfall.Color{"C"}},
+			want: true,
+		},
+		{card: scryfall.Card{Name: "Arcane Signet", TypeLine: "Artifact", CMC: 2, ProducedMana: []scryfall.Color{"W"}}},
+		{card: scryfall.Card{Name: "Sensei's Divining Top", TypeLine: "Artifact", CMC: 1}},
+		{card: scryfall.Card{
+			Name: "Ornithopter of Paradise", TypeLine: "Artifact Creature — Thopter", CMC: 2,
+			ProducedMana: []scryfall.Color{"G"},
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := isTurnOneManaRock(tt.card); got != tt.want {
+			t.Errorf("isTurnOneManaRock(%s) = %v, want %v", tt.card.Name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleDrawProbability(t *testing.T) {
+	s := &MTGCommanderServer{}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains string
+	}{
+		{
+			name:         "defaults to a Commander opening hand",
+			args:         map[string]any{"copies": 1.0},
+			wantContains: "**Chance of at least one:** 7.07%",
+		},
+		{
+			name:         "by turn",
+			args:         map[string]any{"copies": 8.0, "turn": 3.0},
+			wantContains: "10 (opening hand + 3 draws, by turn 3 on the draw)",
+		},
+		{
+			name:         "sixty card deck",
+			args:         map[string]any{"copies": 4.0, "deck_size": 60.0},
+			wantContains: "**Chance of at least one:** 39.95%",
+		},
+		{name: "missing copies", args: map[string]any{}, wantErr: true, wantContains: "copies is required"},
+		{
+			name:         "drawn and turn",
+			args:         map[string]any{"copies": 4.0, "drawn": 7.0, "turn": 1.0},
+			wantErr:      true,
+			wantContains: "either drawn or turn",
+		},
+		{
+			name:         "too many copies",
+			args:         map[string]any{"copies": 61.0, "deck_size": 60.0},
+			wantErr:      true,
+			wantContains: "copies must be between 0 and deck_size (60)",
+		},
+		{
+			name:         "deck_id with copies",
+			args:         map[string]any{"copies": 4.0, "deck_id": "abc123"},
+			wantErr:      true,
+			wantContains: "come from the deck",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleDrawProbability, tt.args)
+			if isErr != tt.wantErr {
+				t.Errorf("handleDrawProbability() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			if !strings.Contains(got, tt.wantContains) {
+				t.Errorf("handleDrawProbability() missing %q in output: %s", tt.wantContains, got)
+			}
+		})
+	}
+}
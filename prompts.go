@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleBuildCommanderDeckPrompt returns step-by-step deckbuilding instructions
+// that walk the model through the legality, EDHREC, and pricing tools.
+func (s *MTGCommanderServer) handleBuildCommanderDeckPrompt(
+	_ context.Context,
+	request mcp.GetPromptRequest,
+) (*mcp.GetPromptResult, error) {
+	commander := strings.TrimSpace(request.Params.Arguments["commander"])
+	if commander == "" {
+		return nil, errors.New("commander argument is required")
+	}
+
+	instructions := fmt.Sprintf(`Help me build a Commander deck with %[1]s as the commander.
+Work through these steps in order:
+
+1. Call check_commander_legality for "%[1]s" to confirm it is legal in Commander. If it is banned or can't be a
+   commander, stop and tell me why.
+2. Call get_edhrec_recommendations for "%[1]s" to get the most-played and highest-synergy cards for it.
+3. From those recommendations, pick a 99-card list: about 36-38 lands, 10 ramp pieces, 10 card draw sources,
+   8-10 removal or interaction spells, and the rest supporting the commander's main strategy. Respect the
+   commander's color identity and the singleton rule.
+4. Call check_commander_legality for any pick you are unsure about, and drop anything banned.
+5. Call get_card_price for the most expensive-looking picks (at least the top 10 non-land cards) and report
+   each price.
+
+Finish with the decklist grouped by category, the estimated total cost, and a short summary of how the deck
+plans to win.`, commander)
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Build a Commander deck around %s", commander),
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions)),
+		},
+	), nil
+}
+
+// handleAnalyzeMoxfieldDeckPrompt returns instructions for reviewing an existing
+// Moxfield deck with the Moxfield, validation, and EDHREC tools.
+func (s *MTGCommanderServer) handleAnalyzeMoxfieldDeckPrompt(
+	_ context.Context,
+	request mcp.GetPromptRequest,
+) (*mcp.GetPromptResult, error) {
+	deckURL := strings.TrimSpace(request.Params.Arguments["deck_url"])
+	if deckURL == "" {
+		return nil, errors.New("deck_url argument is required")
+	}
+
+	instructions := fmt.Sprintf(`Analyze my Moxfield Commander deck at %[1]s.
+Work through these steps in order:
+
+1. Call get_moxfield_deck with deck_id "%[1]s" to load the full decklist and find the commander.
+2. Call get_deck_stats with deck_id "%[1]s" for the card count, land count, average mana value, and card types.
+3. Call validate_moxfield_deck with deck_id "%[1]s" and list every problem it reports (deck size, duplicates,
+   color identity, banned cards).
+4. Call get_edhrec_recommendations for the deck's commander with sort "synergy_asc" to see which cards in the
+   deck are low synergy or commonly cut, and again with the default sort to find high-synergy staples the deck
+   is missing.
+
+Finish with: a short summary of the deck's stats and strategy, the legality problems to fix, up to 10 suggested
+cuts with a reason for each, and matching additions from EDHREC so the deck stays at 100 cards.`, deckURL)
+
+	return mcp.NewGetPromptResult(
+		"Analyze a Moxfield deck",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions)),
+		},
+	), nil
+}
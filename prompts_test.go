@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestHandleBuildCommanderDeckPrompt(t *testing.T) {
+	s := &MTGCommanderServer{}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "build_commander_deck"
+	request.Params.Arguments = map[string]string{"commander": "Atraxa, Praetors' Voice"}
+
+	result, err := s.handleBuildCommanderDeckPrompt(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handleBuildCommanderDeckPrompt() error = %v", err)
+	}
+	if len(result.Messages) != 1 || result.Messages[0].Role != mcp.RoleUser {
+		t.Fatalf("handleBuildCommanderDeckPrompt() messages = %+v, want one user message", result.Messages)
+	}
+
+	text, ok := result.Messages[0].Content.(mcp.TextContent)
+	if !ok {
+		t.Fatalf("handleBuildCommanderDeckPrompt() content is %T, want text", result.Messages[0].Content)
+	}
+
+	// The tools must be called in this order
+	last := -1
+	for _, want := range []string{"check_commander_legality", "get_edhrec_recommendations", "get_card_price"} {
+		index := strings.Index(text.Text, want)
+		if index <= last {
+			t.Errorf("handleBuildCommanderDeckPrompt() should mention %s after the previous step: %s", want, text.Text)
+		}
+		last = index
+	}
+	if !strings.Contains(text.Text, `"Atraxa, Praetors' Voice"`) {
+		t.Errorf("handleBuildCommanderDeckPrompt() missing the commander name: %s", text.Text)
+	}
+
+	request.Params.Arguments = map[string]string{"commander": "  "}
+	if _, err := s.handleBuildCommanderDeckPrompt(t.Context(), request); err == nil {
+		t.Error("handleBuildCommanderDeckPrompt() without a commander should fail")
+	}
+}
+
+func TestHandleAnalyzeMoxfieldDeckPrompt(t *testing.T) {
+	s := &MTGCommanderServer{}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = "analyze_moxfield_deck"
+	request.Params.Arguments = map[string]string{"deck_url": "https://www.moxfield.com/decks/abc123"}
+
+	result, err := s.handleAnalyzeMoxfieldDeckPrompt(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handleAnalyzeMoxfieldDeckPrompt() error = %v", err)
+	}
+	if len(result.Messages) != 1 {
+		t.Fatalf("handleAnalyzeMoxfieldDeckPrompt() returned %d messages, want 1", len(result.Messages))
+	}
+	text, ok := result.Messages[0].Content.(mcp.TextContent)
+	if !ok {
+		t.Fatalf("handleAnalyzeMoxfieldDeckPrompt() content is %T, want text", result.Messages[0].Content)
+	}
+
+	// Each referenced tool must exist, so the model can chain them as written
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	s.registerTools(mcpServer)
+	for _, tool := range []string{
+		"get_moxfield_deck", "get_deck_stats", "validate_moxfield_deck", "get_edhrec_recommendations",
+	} {
+		if !strings.Contains(text.Text, tool) {
+			t.Errorf("handleAnalyzeMoxfieldDeckPrompt() doesn't mention %s: %s", tool, text.Text)
+		}
+		if mcpServer.GetTool(tool) == nil {
+			t.Errorf("handleAnalyzeMoxfieldDeckPrompt() references unregistered tool %s", tool)
+		}
+	}
+	if !strings.Contains(text.Text, `deck_id "https://www.moxfield.com/decks/abc123"`) {
+		t.Errorf("handleAnalyzeMoxfieldDeckPrompt() missing the deck URL: %s", text.Text)
+	}
+
+	request.Params.Arguments = map[string]string{}
+	if _, err := s.handleAnalyzeMoxfieldDeckPrompt(t.Context(), request); err == nil {
+		t.Error("handleAnalyzeMoxfieldDeckPrompt() without a deck_url should fail")
+	}
+}
+
+func TestRegisterPrompts(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	(&MTGCommanderServer{}).registerPrompts(mcpServer)
+
+	response := mcpServer.HandleMessage(t.Context(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "prompts/list"}`))
+	rpcResponse, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("prompts/list returned %T: %+v", response, response)
+	}
+	result, ok := rpcResponse.Result.(mcp.ListPromptsResult)
+	if !ok {
+		t.Fatalf("prompts/list result is %T", rpcResponse.Result)
+	}
+
+	if len(result.Prompts) != totalPromptCount {
+		t.Errorf("registerPrompts() registered %d prompts, want %d", len(result.Prompts), totalPromptCount)
+	}
+	names := make([]string, 0, len(result.Prompts))
+	for _, prompt := range result.Prompts {
+		names = append(names, prompt.Name)
+	}
+	if got := strings.Join(names, ","); got != "analyze_moxfield_deck,build_commander_deck" {
+		t.Errorf("registerPrompts() registered %s", got)
+	}
+}
@@ -0,0 +1,582 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/sync/errgroup"
+)
+
+// scryfallCollectionBatchSize is the maximum number of identifiers Scryfall's
+// /cards/collection endpoint accepts per request.
+const scryfallCollectionBatchSize = 75
+
+// maxNameSuggestions is how many autocomplete suggestions a "Did you mean" hint lists.
+const maxNameSuggestions = 5
+
+// maxConcurrentCollectionRequests caps how many collection batches are fetched at once.
+const maxConcurrentCollectionRequests = 4
+
+// maxBatchCards caps how many distinct names get_cards_batch resolves in one call.
+const maxBatchCards = 300
+
+// scryfallSearchPageSize is how many cards Scryfall returns per search results page.
+const scryfallSearchPageSize = 175
+
+// maxSearchCardsLimit caps search_cards' limit at one Scryfall page of results, so
+// one call makes at most two Scryfall requests even when its window straddles pages.
+const maxSearchCardsLimit = scryfallSearchPageSize
+
+// scryfallIDPattern matches a Scryfall card ID, which is a UUID.
+var scryfallIDPattern = regexp.MustCompile( //nolint:gochecknoglobals // compiled once
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+)
+
+// rarityCode abbreviates a Scryfall rarity to its first letter (common -> C,
+// mythic -> M).
+func rarityCode(rarity string) string {
+	if rarity == "" {
+		return "?"
+	}
+	return strings.ToUpper(rarity[:1])
+}
+
+// colorClausePattern matches the keyword of a Scryfall color clause (c:, color=, -c>=,
+// ...) at the start of a search term.
+var colorClausePattern = regexp.MustCompile( //nolint:gochecknoglobals // compiled once
+	`(?i)(^|[\s(-])(?:c|color|colors)(:|!=|<=|>=|=|<|>)`,
+)
+
+// colorClausesToIdentity rewrites the color clauses of a Scryfall query into color
+// identity clauses (c:blue -> id:blue), leaving quoted text alone. It reports
+// whether anything was rewritten.
+func colorClausesToIdentity(query string) (string, bool) {
+	parts := strings.Split(query, `"`)
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = colorClausePattern.ReplaceAllString(parts[i], "${1}id${2}")
+	}
+	rewritten := strings.Join(parts, `"`)
+	return rewritten, rewritten != query
+}
+
+// searchCardOrders returns the sort orders search_cards accepts. Scryfall also
+// supports "released", "review", and "spoiled", which go-scryfall has no constants for.
+func searchCardOrders() []string {
+	return []string{
+		string(scryfall.OrderName), "released", string(scryfall.OrderSet), string(scryfall.OrderRarity),
+		string(scryfall.OrderColor), string(scryfall.OrderUSD), string(scryfall.OrderTix), string(scryfall.OrderEUR),
+		string(scryfall.OrderCMC), string(scryfall.OrderPower), string(scryfall.OrderToughness),
+		string(scryfall.OrderEDHREC), string(scryfall.OrderArtist), "review", "spoiled",
+	}
+}
+
+// searchCardDirs returns the sort directions search_cards accepts.
+func searchCardDirs() []string {
+	return []string{string(scryfall.DirAuto), string(scryfall.DirAsc), string(scryfall.DirDesc)}
+}
+
+// CardLookupResult holds the outcome of a batched card lookup.
+type CardLookupResult struct {
+	// Cards maps the normalized requested name to the resolved card.
+	Cards map[string]scryfall.Card
+	// NotFound lists the requested names Scryfall could not resolve.
+	NotFound []string
+}
+
+// Get returns the resolved card for a requested name.
+func (r CardLookupResult) Get(name string) (scryfall.Card, bool) {
+	card, ok := r.Cards[normalizeCardName(name)]
+	return card, ok
+}
+
+// normalizeCardName normalizes a card name for use as a lookup key.
+func normalizeCardName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// cardNotFoundResult builds the error result for a failed name lookup. When
+// Scryfall's autocomplete knows close matches, it suggests them instead of just
+// reporting the lookup error.
+func (s *MTGCommanderServer) cardNotFoundResult(
+	ctx context.Context,
+	name string,
+	lookupErr error,
+) *mcp.CallToolResult {
+	// Offline mode never calls Scryfall, so there are no suggestions to offer
+	if s.offlineIndex != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", lookupErr))
+	}
+
+	suggestions, err := s.scryfallClient.AutocompleteCard(ctx, name)
+	if err != nil {
+		GetLogger().Warn().Err(err).Str("card", name).Msg("Autocomplete fallback failed")
+	}
+	if len(suggestions) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Card not found: %v", lookupErr))
+	}
+
+	if len(suggestions) > maxNameSuggestions {
+		suggestions = suggestions[:maxNameSuggestions]
+	}
+	return mcp.NewToolResultError(
+		fmt.Sprintf("Card not found: %s. Did you mean: %s?", name, strings.Join(suggestions, ", ")),
+	)
+}
+
+// scryfallQueryError describes a search Scryfall rejected as malformed (HTTP 400),
+// passing along its details and per-term warnings verbatim so the query can be
+// corrected. It returns false for any other error.
+func scryfallQueryError(query string, err error) (string, bool) {
+	var scryfallErr *scryfall.Error
+	if !errors.As(err, &scryfallErr) || scryfallErr.Status != http.StatusBadRequest {
+		return "", false
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Scryfall rejected the query %q: %s\n", query, scryfallErr.Details))
+	if len(scryfallErr.Warnings) > 0 {
+		output.WriteString("\nWarnings:\n")
+		for _, warning := range scryfallErr.Warnings {
+			output.WriteString(fmt.Sprintf("- %s\n", warning))
+		}
+	}
+	output.WriteString("\nSee https://scryfall.com/docs/syntax for the query syntax.")
+	return output.String(), true
+}
+
+// exactNameQuery builds a Scryfall query matching a card by its exact name. Quotes and
+// backslashes in the name are escaped so they can't end the quoted name early.
+func exactNameQuery(name string) string {
+	return fmt.Sprintf(`!"%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name))
+}
+
+// searchCardsWindow returns up to limit search results starting at offset, along
+// with the total match count. Scryfall serves results in pages of
+// scryfallSearchPageSize, so it starts at the page holding offset and fetches the
+// next page only when the window runs past the end of the current one.
+func (s *MTGCommanderServer) searchCardsWindow(
+	ctx context.Context,
+	query string,
+	opts scryfall.SearchCardsOptions,
+	offset, limit int,
+) ([]scryfall.Card, int, error) {
+	opts.Page = offset/scryfallSearchPageSize + 1
+	skip := offset % scryfallSearchPageSize
+
+	var cards []scryfall.Card
+	for {
+		result, err := s.scryfallClient.SearchCards(ctx, query, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if skip < len(result.Cards) {
+			cards = append(cards, result.Cards[skip:]...)
+		}
+		skip = 0
+
+		if len(cards) >= limit {
+			return cards[:limit], result.TotalCards, nil
+		}
+		if !result.HasMore {
+			return cards, result.TotalCards, nil
+		}
+		opts.Page++
+	}
+}
+
+// lookupCardsByName resolves card names through Scryfall's collection endpoint,
+// batching requests so a full decklist needs only a couple of HTTP calls.
+func (s *MTGCommanderServer) lookupCardsByName(ctx context.Context, names []string) (CardLookupResult, error) {
+	return s.lookupCards(ctx, names, "")
+}
+
+// lookupCardsInSet resolves card names to their printing in a specific set.
+// Names without a printing in that set are reported in NotFound.
+func (s *MTGCommanderServer) lookupCardsInSet(
+	ctx context.Context,
+	names []string,
+	setCode string,
+) (CardLookupResult, error) {
+	return s.lookupCards(ctx, names, strings.ToLower(setCode))
+}
+
+// lookupCards performs batched collection lookups, optionally scoped to a set.
+func (s *MTGCommanderServer) lookupCards(
+	ctx context.Context,
+	names []string,
+	setCode string,
+) (CardLookupResult, error) {
+	result := CardLookupResult{Cards: make(map[string]scryfall.Card)}
+
+	// Deduplicate while preserving order
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		key := normalizeCardName(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, strings.TrimSpace(name))
+	}
+
+	var batches [][]string
+	for start := 0; start < len(unique); start += scryfallCollectionBatchSize {
+		batches = append(batches, unique[start:min(start+scryfallCollectionBatchSize, len(unique))])
+	}
+
+	// Batches are fetched concurrently, each writing only its own slot, and merged
+	// in order afterwards so the result doesn't depend on which request finishes first
+	batchCards := make([][]scryfall.Card, len(batches))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxConcurrentCollectionRequests)
+	for i, batch := range batches {
+		group.Go(func() error {
+			identifiers := make([]scryfall.CardIdentifier, len(batch))
+			for j, name := range batch {
+				identifiers[j] = scryfall.CardIdentifier{Name: name, Set: setCode}
+			}
+
+			resp, err := s.scryfallClient.GetCardsByIdentifiers(groupCtx, identifiers)
+			if err != nil {
+				return fmt.Errorf("scryfall collection lookup failed: %w", err)
+			}
+			batchCards[i] = resp.Data
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return CardLookupResult{}, err
+	}
+
+	for i, batch := range batches {
+		// Index returned cards by full name and by each face name so that
+		// "Brazen Borrower" resolves to "Brazen Borrower // Petty Theft".
+		returned := make(map[string]scryfall.Card, len(batchCards[i]))
+		for _, card := range batchCards[i] {
+			returned[normalizeCardName(card.Name)] = card
+			for _, face := range strings.Split(card.Name, " // ") {
+				if _, exists := returned[normalizeCardName(face)]; !exists {
+					returned[normalizeCardName(face)] = card
+				}
+			}
+		}
+
+		for _, name := range batch {
+			if card, ok := returned[normalizeCardName(name)]; ok {
+				result.Cards[normalizeCardName(name)] = card
+			} else {
+				result.NotFound = append(result.NotFound, name)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getRandomCardWithQuery fetches a random card matching a Scryfall query. go-scryfall's
+// GetRandomCard doesn't accept a query, so the endpoint is called directly.
+func getRandomCardWithQuery(ctx context.Context, baseURL, query string) (scryfall.Card, error) {
+	resp, err := HTTPGet(ctx, fmt.Sprintf("%s/cards/random?q=%s", baseURL, url.QueryEscape(query)))
+	if err != nil {
+		return scryfall.Card{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var scryfallErr scryfall.Error
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&scryfallErr); decodeErr != nil || scryfallErr.Details == "" {
+			return scryfall.Card{}, fmt.Errorf("scryfall API returned status %d", resp.StatusCode)
+		}
+		return scryfall.Card{}, &scryfallErr
+	}
+
+	var card scryfall.Card
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&card); decodeErr != nil {
+		return scryfall.Card{}, fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+
+	return card, nil
+}
+
+// FormatCardDetailsForDisplay formats the full detail block for a card, rendering
+// mana costs in manaFormat (see FormatManaCost).
+func FormatCardDetailsForDisplay(card scryfall.Card, manaFormat string) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# %s %s\n\n", card.Name, FormatManaCost(card.ManaCost, manaFormat)))
+	output.WriteString(fmt.Sprintf("**Type:** %s\n", card.TypeLine))
+	output.WriteString(
+		fmt.Sprintf("**Set:** %s (%s) #%s\n", card.SetName, strings.ToUpper(card.Set), card.CollectorNumber),
+	)
+	output.WriteString(fmt.Sprintf("**Rarity:** %s\n\n", card.Rarity))
+
+	// Double-faced, split, and adventure cards keep their rules text on each face
+	if len(card.CardFaces) > 0 {
+		for _, face := range card.CardFaces {
+			output.WriteString(formatCardFace(face, manaFormat))
+		}
+	} else {
+		if card.OracleText != "" {
+			output.WriteString(fmt.Sprintf("**Oracle Text:**\n%s\n\n", card.OracleText))
+		}
+
+		if card.Power != nil && card.Toughness != nil {
+			output.WriteString(fmt.Sprintf("**Power/Toughness:** %s/%s\n", *card.Power, *card.Toughness))
+		}
+
+		if card.Loyalty != nil {
+			output.WriteString(fmt.Sprintf("**Loyalty:** %s\n", *card.Loyalty))
+		}
+
+		if card.FlavorText != nil && *card.FlavorText != "" {
+			output.WriteString(fmt.Sprintf("**Flavor Text:** *%s*\n", *card.FlavorText))
+		}
+	}
+
+	output.WriteString(formatKeywords(card.Keywords))
+
+	// Color Identity
+	if len(card.ColorIdentity) > 0 {
+		colors := make([]string, len(card.ColorIdentity))
+		for i, c := range card.ColorIdentity {
+			colors[i] = string(c)
+		}
+		output.WriteString(fmt.Sprintf("**Color Identity:** %s\n", strings.Join(colors, ", ")))
+	}
+
+	// Legalities
+	output.WriteString("\n**Format Legalities:**\n")
+	output.WriteString(fmt.Sprintf("- Commander: %s\n", card.Legalities.Commander))
+	output.WriteString(fmt.Sprintf("- Legacy: %s\n", card.Legalities.Legacy))
+	output.WriteString(fmt.Sprintf("- Vintage: %s\n", card.Legalities.Vintage))
+	output.WriteString(fmt.Sprintf("- Modern: %s\n", card.Legalities.Modern))
+	output.WriteString(fmt.Sprintf("- Standard: %s\n", card.Legalities.Standard))
+
+	output.WriteString(formatRelatedCards(card))
+
+	// Additional info
+	if card.Artist != nil {
+		output.WriteString(fmt.Sprintf("\n**Artist:** %s\n", *card.Artist))
+	}
+	output.WriteString(formatCardArt(card))
+
+	output.WriteString(fmt.Sprintf("\n**Scryfall Link:** %s\n", card.ScryfallURI))
+
+	return output.String()
+}
+
+// formatCardFace formats the name, cost, type, rules text, and stats of a single card face.
+func formatCardFace(face scryfall.CardFace, manaFormat string) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("## %s %s\n\n", face.Name, FormatManaCost(face.ManaCost, manaFormat)))
+	output.WriteString(fmt.Sprintf("**Type:** %s\n", face.TypeLine))
+
+	if face.OracleText != nil && *face.OracleText != "" {
+		output.WriteString(fmt.Sprintf("**Oracle Text:**\n%s\n", *face.OracleText))
+	}
+
+	if face.Power != nil && face.Toughness != nil {
+		output.WriteString(fmt.Sprintf("**Power/Toughness:** %s/%s\n", *face.Power, *face.Toughness))
+	}
+
+	if face.Loyalty != nil {
+		output.WriteString(fmt.Sprintf("**Loyalty:** %s\n", *face.Loyalty))
+	}
+
+	if face.FlavorText != nil && *face.FlavorText != "" {
+		output.WriteString(fmt.Sprintf("**Flavor Text:** *%s*\n", *face.FlavorText))
+	}
+
+	output.WriteString("\n")
+	return output.String()
+}
+
+// formatRelatedCards lists the cards related to a card, such as the tokens it makes,
+// its meld partner and result, or the pieces of a combo it belongs to. The card's own
+// entry is skipped, and cards without related parts get nothing.
+func formatRelatedCards(card scryfall.Card) string {
+	var output strings.Builder
+	for _, part := range card.AllParts {
+		if part.ID == card.ID {
+			continue
+		}
+		output.WriteString(fmt.Sprintf("- %s (%s): %s\n", part.Name, part.Component, part.TypeLine))
+	}
+
+	if output.Len() == 0 {
+		return ""
+	}
+	return "\n**Related Cards:**\n" + output.String()
+}
+
+// formatCardArt lists the art crop URL and illustration ID of a card, or of each
+// face for double-faced cards, which carry their images per face. Cards without
+// images, such as some tokens, get nothing.
+func formatCardArt(card scryfall.Card) string {
+	var lines []string
+	if card.ImageURIs != nil {
+		if card.ImageURIs.ArtCrop != "" {
+			lines = append(lines, fmt.Sprintf("**Art Crop:** %s", card.ImageURIs.ArtCrop))
+		}
+		if card.IllustrationID != nil && *card.IllustrationID != "" {
+			lines = append(lines, fmt.Sprintf("**Illustration ID:** %s", *card.IllustrationID))
+		}
+	} else {
+		for _, face := range card.CardFaces {
+			if face.ImageURIs.ArtCrop == "" {
+				continue
+			}
+			line := fmt.Sprintf("**Art Crop (%s):** %s", face.Name, face.ImageURIs.ArtCrop)
+			if face.IllustrationID != nil && *face.IllustrationID != "" {
+				line += fmt.Sprintf(" (illustration ID %s)", *face.IllustrationID)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// FormatPrintingDetailsForDisplay formats printing-specific information such as
+// the set icon and the finishes a printing was produced in.
+func FormatPrintingDetailsForDisplay(card scryfall.Card, set *scryfall.Set) string {
+	var output strings.Builder
+
+	output.WriteString("\n**Printing Details:**\n")
+	if set != nil {
+		output.WriteString(fmt.Sprintf("- Set: %s (%s, %s)\n", set.Name, strings.ToUpper(set.Code), set.SetType))
+		if set.IconSVGURI != "" {
+			output.WriteString(fmt.Sprintf("- Set Icon: %s\n", set.IconSVGURI))
+		}
+	}
+
+	if len(card.Finishes) > 0 {
+		finishes := make([]string, len(card.Finishes))
+		for i, finish := range card.Finishes {
+			finishes[i] = string(finish)
+		}
+		output.WriteString(fmt.Sprintf("- Finishes: %s\n", strings.Join(finishes, ", ")))
+	} else {
+		output.WriteString(fmt.Sprintf("- Finishes: nonfoil %s, foil %s\n", yesNo(card.NonFoil), yesNo(card.Foil)))
+	}
+
+	if !card.ReleasedAt.IsZero() {
+		output.WriteString(fmt.Sprintf("- Released: %s\n", card.ReleasedAt.Format(time.DateOnly)))
+	}
+
+	return output.String()
+}
+
+// FormatCardLinksForDisplay lists where a card can be bought and looked up. Links
+// Scryfall doesn't provide are skipped, and a section with no links is omitted.
+func FormatCardLinksForDisplay(card scryfall.Card) string {
+	var output strings.Builder
+
+	writeSection := func(title string, links [][2]string) {
+		var section strings.Builder
+		for _, link := range links {
+			if link[1] != "" {
+				section.WriteString(fmt.Sprintf("- %s: %s\n", link[0], link[1]))
+			}
+		}
+		if section.Len() > 0 {
+			output.WriteString(fmt.Sprintf("\n**%s:**\n%s", title, section.String()))
+		}
+	}
+
+	writeSection("Buy Links", [][2]string{
+		{"TCGplayer", card.PurchaseURIs.TCGPlayer},
+		{"Cardmarket", card.PurchaseURIs.CardMarket},
+		{"Cardhoarder", card.PurchaseURIs.CardHoarder},
+	})
+	writeSection("More Info", [][2]string{
+		{"Gatherer", card.RelatedURIs.Gatherer},
+		{"EDHREC", card.RelatedURIs.EDHREC},
+	})
+
+	return output.String()
+}
+
+// cardManaCost returns a card's mana cost, joining the face costs of multi-faced
+// cards (which have no top-level cost) as "{1}{U} // {U}".
+func cardManaCost(card scryfall.Card) string {
+	if card.ManaCost != "" || len(card.CardFaces) == 0 {
+		return card.ManaCost
+	}
+
+	costs := make([]string, 0, len(card.CardFaces))
+	for _, face := range card.CardFaces {
+		if face.ManaCost != "" {
+			costs = append(costs, face.ManaCost)
+		}
+	}
+	return strings.Join(costs, " // ")
+}
+
+// FormatCardBatchForDisplay lists one compact line per resolved card, in the order
+// the names were requested, followed by the names Scryfall could not resolve.
+func FormatCardBatchForDisplay(names []string, lookup CardLookupResult) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Card Lookup (%d of %d found)\n\n", len(names)-len(lookup.NotFound), len(names)))
+
+	for _, name := range names {
+		card, ok := lookup.Get(name)
+		if !ok {
+			continue
+		}
+
+		line := fmt.Sprintf("- **%s**", card.Name)
+		if cost := cardManaCost(card); cost != "" {
+			line += " " + cost
+		}
+		line += fmt.Sprintf(" | %s | Commander: %s", card.TypeLine, card.Legalities.Commander)
+		if card.Prices.USD != "" {
+			line += " | $" + card.Prices.USD
+		}
+		output.WriteString(line + "\n")
+	}
+
+	if len(lookup.NotFound) > 0 {
+		output.WriteString(fmt.Sprintf("\n**Not Found (%d):**\n", len(lookup.NotFound)))
+		for _, name := range lookup.NotFound {
+			output.WriteString(fmt.Sprintf("- %s\n", name))
+		}
+	}
+
+	return output.String()
+}
+
+// yesNo renders a boolean as "yes" or "no".
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// cardNameMatches reports whether name refers to card, matching the full name or any face name.
+func cardNameMatches(card scryfall.Card, name string) bool {
+	want := normalizeCardName(name)
+	if normalizeCardName(card.Name) == want {
+		return true
+	}
+	for _, face := range strings.Split(card.Name, " // ") {
+		if normalizeCardName(face) == want {
+			return true
+		}
+	}
+	return false
+}
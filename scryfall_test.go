@@ -0,0 +1,1150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// newTestMTGServer returns an MTGCommanderServer whose Scryfall client talks to handler.
+func newTestMTGServer(t *testing.T, handler http.Handler) *MTGCommanderServer {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := scryfall.NewClient(
+		scryfall.WithBaseURL(server.URL+"/"),
+		scryfall.WithLimiter(nil),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create Scryfall client: %v", err)
+	}
+
+	return &MTGCommanderServer{scryfallClient: client, scryfallBaseURL: server.URL}
+}
+
+// callTool invokes a tool handler with the given arguments and returns its text output.
+func callTool(
+	t *testing.T,
+	handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error),
+	args map[string]any,
+) (string, bool) {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+
+	result, err := handler(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	var text strings.Builder
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			text.WriteString(textContent.Text)
+		}
+	}
+
+	return text.String(), result.IsError
+}
+
+// writeScryfallNotFound writes a Scryfall-style 404 error response.
+func writeScryfallNotFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusNotFound, Code: "not_found", Details: "No card found"})
+}
+
+// collectionHandler serves /cards/collection from a fixed set of known cards.
+func collectionHandler(t *testing.T, known map[string]scryfall.Card, requests *int) http.HandlerFunc {
+	t.Helper()
+
+	// Batches may be requested concurrently
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/collection" || r.Method != http.MethodPost {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if requests != nil {
+			mu.Lock()
+			*requests++
+			mu.Unlock()
+		}
+
+		var body scryfall.GetCardsByIdentifiersRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode collection request: %v", err)
+		}
+		if len(body.Identifiers) > scryfallCollectionBatchSize {
+			t.Errorf("Collection request has %d identifiers, max %d", len(body.Identifiers), scryfallCollectionBatchSize)
+		}
+
+		resp := scryfall.GetCardsByIdentifiersResponse{Data: []scryfall.Card{}, NotFound: []scryfall.CardIdentifier{}}
+		for _, id := range body.Identifiers {
+			if card, ok := known[normalizeCardName(id.Name)]; ok {
+				resp.Data = append(resp.Data, card)
+			} else {
+				resp.NotFound = append(resp.NotFound, id)
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func TestLookupCardsByName(t *testing.T) {
+	known := map[string]scryfall.Card{
+		"sol ring":        {Name: "Sol Ring"},
+		"brazen borrower": {Name: "Brazen Borrower // Petty Theft"},
+	}
+	for i := range 100 {
+		name := fmt.Sprintf("Card %d", i)
+		known[normalizeCardName(name)] = scryfall.Card{Name: name}
+	}
+
+	requests := 0
+	s := newTestMTGServer(t, collectionHandler(t, known, &requests))
+
+	names := []string{"Sol Ring", "sol ring", "Brazen Borrower", "Not A Card"}
+	for i := range 100 {
+		names = append(names, fmt.Sprintf("Card %d", i))
+	}
+
+	result, err := s.lookupCardsByName(t.Context(), names)
+	if err != nil {
+		t.Fatalf("lookupCardsByName() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("lookupCardsByName() made %d requests, want 2", requests)
+	}
+
+	if card, ok := result.Get("SOL RING"); !ok || card.Name != "Sol Ring" {
+		t.Errorf("lookupCardsByName() Sol Ring = %v, %v", card.Name, ok)
+	}
+
+	if card, ok := result.Get("Brazen Borrower"); !ok || card.Name != "Brazen Borrower // Petty Theft" {
+		t.Errorf("lookupCardsByName() Brazen Borrower = %v, %v", card.Name, ok)
+	}
+
+	if len(result.NotFound) != 1 || result.NotFound[0] != "Not A Card" {
+		t.Errorf("lookupCardsByName() NotFound = %v, want [Not A Card]", result.NotFound)
+	}
+
+	if len(result.Cards) != 102 {
+		t.Errorf("lookupCardsByName() resolved %d cards, want 102", len(result.Cards))
+	}
+}
+
+func TestLookupCardsByNameConcurrent(t *testing.T) {
+	const cardCount = 10 * scryfallCollectionBatchSize
+
+	known := make(map[string]scryfall.Card, cardCount)
+	names := make([]string, 0, cardCount)
+	for i := range cardCount {
+		name := fmt.Sprintf("Card %d", i)
+		known[normalizeCardName(name)] = scryfall.Card{Name: name, ID: fmt.Sprintf("id-%d", i)}
+		names = append(names, name)
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	collection := collectionHandler(t, known, nil)
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+
+		// Hold each request long enough for the others to overlap it
+		time.Sleep(20 * time.Millisecond)
+		collection(w, r)
+	}))
+
+	result, err := s.lookupCardsByName(t.Context(), append(names, "Not A Card"))
+	if err != nil {
+		t.Fatalf("lookupCardsByName() error = %v", err)
+	}
+
+	if peak := maxInFlight.Load(); peak < 2 || peak > maxConcurrentCollectionRequests {
+		t.Errorf("lookupCardsByName() peak concurrent requests = %d, want 2..%d", peak, maxConcurrentCollectionRequests)
+	}
+	if len(result.Cards) != cardCount {
+		t.Errorf("lookupCardsByName() resolved %d cards, want %d", len(result.Cards), cardCount)
+	}
+	for i, name := range names {
+		if card, ok := result.Get(name); !ok || card.ID != fmt.Sprintf("id-%d", i) {
+			t.Fatalf("lookupCardsByName() %s = %+v, %v", name, card, ok)
+		}
+	}
+	if len(result.NotFound) != 1 || result.NotFound[0] != "Not A Card" {
+		t.Errorf("lookupCardsByName() NotFound = %v, want [Not A Card]", result.NotFound)
+	}
+}
+
+func TestLookupCardsByNameCanceled(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		// The server only notices the client going away once the body has been read
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+
+	names := make([]string, 0, 3*scryfallCollectionBatchSize)
+	for i := range cap(names) {
+		names = append(names, fmt.Sprintf("Card %d", i))
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := s.lookupCardsByName(ctx, names); err == nil {
+		t.Fatal("lookupCardsByName() with a canceled context should fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("lookupCardsByName() took %v after cancellation, want it to abort promptly", elapsed)
+	}
+}
+
+func TestHandleGetCardDetailsDoubleFaced(t *testing.T) {
+	// Trimmed Scryfall payload for a transforming double-faced card
+	const delverJSON = `{
+		"object": "card",
+		"name": "Delver of Secrets // Insectile Aberration",
+		"layout": "transform",
+		"type_line": "Creature — Human Wizard // Creature — Human Insect",
+		"set": "isd",
+		"set_name": "Innistrad",
+		"collector_number": "51",
+		"rarity": "common",
+		"color_identity": ["U"],
+		"legalities": {"commander": "legal"},
+		"card_faces": [
+			{
+				"object": "card_face",
+				"name": "Delver of Secrets",
+				"mana_cost": "{U}",
+				"type_line": "Creature — Human Wizard",
+				"oracle_text": "At the beginning of your upkeep, look at the top card of your library.",
+				"power": "1",
+				"toughness": "1"
+			},
+			{
+				"object": "card_face",
+				"name": "Insectile Aberration",
+				"mana_cost": "",
+				"type_line": "Creature — Human Insect",
+				"oracle_text": "Flying",
+				"power": "3",
+				"toughness": "2"
+			}
+		]
+	}`
+
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/named" {
+			writeScryfallNotFound(w)
+			return
+		}
+		_, _ = w.Write([]byte(delverJSON))
+	}))
+
+	got, isErr := callTool(t, s.handleGetCardDetails, map[string]any{"name": "Delver of Secrets"})
+	if isErr {
+		t.Fatalf("handleGetCardDetails() returned error: %s", got)
+	}
+
+	for _, want := range []string{
+		"## Delver of Secrets {U}",
+		"**Type:** Creature — Human Wizard",
+		"look at the top card of your library",
+		"**Power/Toughness:** 1/1",
+		"## Insectile Aberration",
+		"Flying",
+		"**Power/Toughness:** 3/2",
+		"**Color Identity:** U",
+		"- Commander: legal",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleGetCardDetails() missing %q in output: %s", want, got)
+		}
+	}
+
+	if strings.Count(got, "**Color Identity:**") != 1 || strings.Count(got, "**Format Legalities:**") != 1 {
+		t.Errorf("handleGetCardDetails() should print color identity and legalities once: %s", got)
+	}
+
+	got, _ = callTool(t, s.handleGetCardDetails, map[string]any{"name": "Delver of Secrets", "mana_format": "compact"})
+	if !strings.Contains(got, "## Delver of Secrets U\n") {
+		t.Errorf("handleGetCardDetails() with compact mana_format: %s", got)
+	}
+
+	got, isErr = callTool(t, s.handleGetCardDetails, map[string]any{"name": "Delver of Secrets", "mana_format": "bad"})
+	if !isErr {
+		t.Errorf("handleGetCardDetails() accepted an invalid mana_format: %s", got)
+	}
+}
+
+func TestFormatCardDetailsArt(t *testing.T) {
+	tests := []struct {
+		name         string
+		cardJSON     string
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name: "single face",
+			cardJSON: `{"name": "Sol Ring", "layout": "normal", "type_line": "Artifact",
+				"flavor_text": "Lost to time is the artificer's art.",
+				"illustration_id": "3fbb1b8f-8fb2-4bd0-9a47-d2b0c7c61d64",
+				"image_uris": {"art_crop": "https://cards.scryfall.io/art_crop/sol-ring.jpg"}}`,
+			wantContains: []string{
+				"**Flavor Text:** *Lost to time is the artificer's art.*",
+				"**Art Crop:** https://cards.scryfall.io/art_crop/sol-ring.jpg",
+				"**Illustration ID:** 3fbb1b8f-8fb2-4bd0-9a47-d2b0c7c61d64",
+			},
+		},
+		{
+			name: "double faced",
+			cardJSON: `{"name": "Delver of Secrets // Insectile Aberration", "layout": "transform",
+				"card_faces": [
+					{"name": "Delver of Secrets", "flavor_text": "If my hypothesis is correct...",
+					 "illustration_id": "11111111-1111-1111-1111-111111111111",
+					 "image_uris": {"art_crop": "https://cards.scryfall.io/art_crop/front.jpg"}},
+					{"name": "Insectile Aberration",
+					 "illustration_id": "22222222-2222-2222-2222-222222222222",
+					 "image_uris": {"art_crop": "https://cards.scryfall.io/art_crop/back.jpg"}}
+				]}`,
+			wantContains: []string{
+				"**Flavor Text:** *If my hypothesis is correct...*",
+				"**Art Crop (Delver of Secrets):** https://cards.scryfall.io/art_crop/front.jpg " +
+					"(illustration ID 11111111-1111-1111-1111-111111111111)",
+				"**Art Crop (Insectile Aberration):** https://cards.scryfall.io/art_crop/back.jpg " +
+					"(illustration ID 22222222-2222-2222-2222-222222222222)",
+			},
+		},
+		{
+			name:        "no images",
+			cardJSON:    `{"name": "Treasure", "layout": "token", "type_line": "Token Artifact — Treasure"}`,
+			wantMissing: []string{"Art Crop", "Illustration ID", "Flavor Text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var card scryfall.Card
+			if err := json.Unmarshal([]byte(tt.cardJSON), &card); err != nil {
+				t.Fatalf("failed to decode card: %v", err)
+			}
+
+			got := FormatCardDetailsForDisplay(card, manaFormatSymbols)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("FormatCardDetailsForDisplay() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("FormatCardDetailsForDisplay() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatCardDetailsRelatedCards(t *testing.T) {
+	const urzaJSON = `{"id": "b2a6bd0c-1d4f-4d0e-9d61-0e6b4c1bfd0b", "name": "Urza, Lord Protector", "layout": "meld",
+		"all_parts": [
+			{"id": "b2a6bd0c-1d4f-4d0e-9d61-0e6b4c1bfd0b", "component": "meld_part",
+			 "name": "Urza, Lord Protector", "type_line": "Legendary Creature — Human Artificer"},
+			{"id": "2d9a9b8c-7d3b-4b5c-9a4e-2c5f1c7f0e11", "component": "meld_part",
+			 "name": "The Mightstone and Weakstone", "type_line": "Legendary Artifact — Powerstone"},
+			{"id": "5c3f8d2e-3e0b-4c8e-8b7c-6c0b9f1e4a22", "component": "meld_result",
+			 "name": "Urza, Planeswalker", "type_line": "Legendary Planeswalker — Urza"}
+		]}`
+
+	var card scryfall.Card
+	if err := json.Unmarshal([]byte(urzaJSON), &card); err != nil {
+		t.Fatalf("failed to decode card: %v", err)
+	}
+
+	got := FormatCardDetailsForDisplay(card, manaFormatSymbols)
+	want := "**Related Cards:**\n" +
+		"- The Mightstone and Weakstone (meld_part): Legendary Artifact — Powerstone\n" +
+		"- Urza, Planeswalker (meld_result): Legendary Planeswalker — Urza\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("FormatCardDetailsForDisplay() missing related cards %q in output: %s", want, got)
+	}
+
+	got = FormatCardDetailsForDisplay(scryfall.Card{Name: "Sol Ring"}, manaFormatSymbols)
+	if strings.Contains(got, "Related Cards") {
+		t.Errorf("FormatCardDetailsForDisplay() shows related cards for a card without any: %s", got)
+	}
+}
+
+func TestHandleGetCardBySet(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/c21/263":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{
+				Name:            "Sol Ring",
+				ManaCost:        "{1}",
+				TypeLine:        "Artifact",
+				Set:             "c21",
+				SetName:         "Commander 2021",
+				CollectorNumber: "263",
+				Finishes:        []scryfall.Finish{scryfall.FinishNonFoil},
+			})
+		case "/sets/c21":
+			_ = json.NewEncoder(w).Encode(scryfall.Set{
+				Code:       "c21",
+				Name:       "Commander 2021",
+				SetType:    scryfall.SetTypeCommander,
+				IconSVGURI: "https://svgs.scryfall.io/sets/c21.svg",
+			})
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains []string
+	}{
+		{
+			name: "exact printing",
+			args: map[string]any{"name": "Sol Ring", "set": "C21", "collector_number": "263"},
+			wantContains: []string{
+				"# Sol Ring {1}",
+				"Commander 2021 (C21) #263",
+				"Set Icon: https://svgs.scryfall.io/sets/c21.svg",
+				"Finishes: nonfoil",
+			},
+		},
+		{
+			name:         "unknown printing",
+			args:         map[string]any{"name": "Sol Ring", "set": "C21", "collector_number": "999"},
+			wantErr:      true,
+			wantContains: []string{"set C21 with collector number 999"},
+		},
+		{
+			name:         "name mismatch",
+			args:         map[string]any{"name": "Mana Crypt", "set": "c21", "collector_number": "263"},
+			wantErr:      true,
+			wantContains: []string{"is Sol Ring, not Mana Crypt"},
+		},
+		{
+			name:    "missing collector number",
+			args:    map[string]any{"name": "Sol Ring", "set": "c21"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetCardBySet, tt.args)
+
+			if isErr != tt.wantErr {
+				t.Errorf("handleGetCardBySet() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetCardBySet() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleGetRandomCard(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/random" {
+			writeScryfallNotFound(w)
+			return
+		}
+
+		switch r.URL.Query().Get("q") {
+		case "":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{Name: "Grizzly Bears", TypeLine: "Creature — Bear"})
+		case "is:commander":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{Name: "Atraxa, Praetors' Voice", TypeLine: "Legendary Creature"})
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains string
+	}{
+		{name: "no query", args: map[string]any{}, wantContains: "# Grizzly Bears"},
+		{name: "with query", args: map[string]any{"query": "is:commander"}, wantContains: "# Atraxa, Praetors' Voice"},
+		{name: "no matches", args: map[string]any{"query": "is:nothing"}, wantErr: true, wantContains: "No card found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetRandomCard, tt.args)
+
+			if isErr != tt.wantErr {
+				t.Errorf("handleGetRandomCard() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			if !strings.Contains(got, tt.wantContains) {
+				t.Errorf("handleGetRandomCard() missing %q in output: %s", tt.wantContains, got)
+			}
+		})
+	}
+}
+
+func TestHandleGetPriceLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     string
+		want     []string
+		unwanted []string
+	}{
+		{
+			name: "purchase and related links",
+			card: `{"object": "card", "name": "Sol Ring", "set": "c21", "prices": {"usd": "1.50"},
+				"purchase_uris": {"tcgplayer": "https://tcg.example/sol-ring", "cardmarket": "https://mkm.example/sol-ring"},
+				"related_uris": {"gatherer": "https://gatherer.example/sol-ring", "edhrec": "https://edhrec.example/sol-ring"}}`,
+			want: []string{
+				"**Buy Links:**",
+				"- TCGplayer: https://tcg.example/sol-ring",
+				"- Cardmarket: https://mkm.example/sol-ring",
+				"**More Info:**",
+				"- Gatherer: https://gatherer.example/sol-ring",
+				"- EDHREC: https://edhrec.example/sol-ring",
+			},
+			unwanted: []string{"Cardhoarder"},
+		},
+		{
+			name:     "no links",
+			card:     `{"object": "card", "name": "Sol Ring", "set": "c21", "prices": {"usd": "1.50"}}`,
+			want:     []string{"**USD:** $1.50"},
+			unwanted: []string{"**Buy Links:**", "**More Info:**"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/cards/named" {
+					writeScryfallNotFound(w)
+					return
+				}
+				_, _ = w.Write([]byte(tt.card))
+			}))
+
+			got, isErr := callTool(t, s.handleGetPrice, map[string]any{"name": "Sol Ring", "currency": "USD"})
+			if isErr {
+				t.Fatalf("handleGetPrice() returned error: %s", got)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetPrice() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleGetPrice() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleAutocompleteCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		response string
+		want     []string
+	}{
+		{
+			name:     "suggestions",
+			query:    "teferis pro",
+			response: `{"object": "catalog", "total_values": 2, "data": ["Teferi's Protection", "Teferi's Protege"]}`,
+			want:     []string{"1. Teferi's Protection", "2. Teferi's Protege"},
+		},
+		{
+			name:     "no suggestions",
+			query:    "zzzz",
+			response: `{"object": "catalog", "total_values": 0, "data": []}`,
+			want:     []string{`No card names match "zzzz".`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/cards/autocomplete" || r.URL.Query().Get("q") != tt.query {
+					t.Errorf("Unexpected request %s", r.URL)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+
+			got, isErr := callTool(t, s.handleAutocompleteCard, map[string]any{"query": tt.query})
+			if isErr {
+				t.Fatalf("handleAutocompleteCard() returned error: %s", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleAutocompleteCard() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("upstream failure", func(t *testing.T) {
+		s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(scryfall.Error{Status: http.StatusServiceUnavailable, Code: "unavailable"})
+		}))
+
+		got, isErr := callTool(t, s.handleAutocompleteCard, map[string]any{"query": "sol"})
+		if !isErr || !strings.Contains(got, "Autocomplete failed") {
+			t.Errorf("handleAutocompleteCard() = %q, isError %v, want a tool error", got, isErr)
+		}
+	})
+}
+
+func TestHandleGetCardDetailsSuggestions(t *testing.T) {
+	tests := []struct {
+		name         string
+		autocomplete string
+		want         string
+		unwanted     string
+	}{
+		{
+			name: "suggests close matches",
+			autocomplete: `{"object": "catalog", "data": ["Teferi's Protection", "Teferi's Protege", "Teferi's Puzzle Box",
+				"Teferi's Realm", "Teferi's Response", "Teferi's Sentinel"]}`,
+			want: "Did you mean: Teferi's Protection, Teferi's Protege, " +
+				"Teferi's Puzzle Box, Teferi's Realm, Teferi's Response?",
+			unwanted: "Teferi's Sentinel",
+		},
+		{
+			name:         "no close matches",
+			autocomplete: `{"object": "catalog", "data": []}`,
+			want:         "Card not found:",
+			unwanted:     "Did you mean",
+		},
+		{
+			name:     "autocomplete unavailable",
+			want:     "Card not found:",
+			unwanted: "Did you mean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/cards/autocomplete" && tt.autocomplete != "" {
+					_, _ = w.Write([]byte(tt.autocomplete))
+					return
+				}
+				writeScryfallNotFound(w)
+			}))
+
+			got, isErr := callTool(t, s.handleGetCardDetails, map[string]any{"name": "Teferys Protection"})
+			if !isErr {
+				t.Fatalf("handleGetCardDetails() should return an error result, got: %s", got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("handleGetCardDetails() = %q, want it to contain %q", got, tt.want)
+			}
+			if strings.Contains(got, tt.unwanted) {
+				t.Errorf("handleGetCardDetails() = %q, should not contain %q", got, tt.unwanted)
+			}
+		})
+	}
+}
+
+func TestHandleSearchCardsOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantOrder string
+		wantDir   string
+		wantErr   bool
+	}{
+		{
+			name:      "defaults",
+			args:      map[string]any{"query": "c:u type:instant"},
+			wantOrder: "name",
+			wantDir:   "auto",
+		},
+		{
+			name:      "edhrec descending",
+			args:      map[string]any{"query": "c:u type:instant", "order": "EDHREC", "dir": "desc"},
+			wantOrder: "edhrec",
+			wantDir:   "desc",
+		},
+		{
+			name:    "invalid order",
+			args:    map[string]any{"query": "c:u", "order": "popularity"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid dir",
+			args:    map[string]any{"query": "c:u", "dir": "up"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOrder, gotDir string
+			s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotOrder = r.URL.Query().Get("order")
+				gotDir = r.URL.Query().Get("dir")
+				_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [
+					{"name": "Counterspell", "mana_cost": "{U}{U}", "type_line": "Instant"}
+				]}`))
+			}))
+
+			got, isErr := callTool(t, s.handleSearchCards, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("handleSearchCards() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotOrder != tt.wantOrder || gotDir != tt.wantDir {
+				t.Errorf("handleSearchCards() sent order=%q dir=%q, want order=%q dir=%q",
+					gotOrder, gotDir, tt.wantOrder, tt.wantDir)
+			}
+			if !strings.Contains(got, "Counterspell") {
+				t.Errorf("handleSearchCards() missing result in output: %s", got)
+			}
+		})
+	}
+}
+
+func TestHandleSearchCardsPagination(t *testing.T) {
+	const totalCards = 180
+
+	var requestedPages []string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		// Scryfall page 1 holds cards 1-175 and page 2 holds cards 176-180
+		first, last, hasMore := 1, scryfallSearchPageSize, true
+		if page == "2" {
+			first, last, hasMore = scryfallSearchPageSize+1, totalCards, false
+		}
+		cards := make([]map[string]any, 0, last-first+1)
+		for i := first; i <= last; i++ {
+			cards = append(cards, map[string]any{"name": fmt.Sprintf("Card %03d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"object": "list", "total_cards": totalCards, "has_more": hasMore, "data": cards,
+		})
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantPages    string
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:         "first page",
+			args:         map[string]any{"query": "t:creature"},
+			wantPages:    "1",
+			wantContains: []string{"Page 1, showing 10 of 180 cards", "1. **Card 001**", "10. **Card 010**", "use page 2"},
+			wantMissing:  []string{"Card 011"},
+		},
+		{
+			name:      "window spanning two Scryfall pages",
+			args:      map[string]any{"query": "t:creature", "limit": float64(50), "page": float64(4)},
+			wantPages: "1,2",
+			wantContains: []string{
+				"Page 4, showing 30 of 180 cards", "151. **Card 151**", "180. **Card 180**",
+			},
+			wantMissing: []string{"Card 150", "More results available"},
+		},
+		{
+			name:      "limit above one Scryfall page is capped",
+			args:      map[string]any{"query": "t:creature", "limit": float64(500)},
+			wantPages: "1",
+			wantContains: []string{
+				"Page 1, showing 175 of 180 cards", "175. **Card 175**",
+				"Returned 175 of 180 matching cards (results 1-175).", "use page 2",
+			},
+			wantMissing: []string{"Card 176"},
+		},
+		{
+			name:      "large limit fetches the next Scryfall page",
+			args:      map[string]any{"query": "t:creature", "limit": float64(100), "page": float64(2)},
+			wantPages: "1,2",
+			wantContains: []string{
+				"Page 2, showing 80 of 180 cards", "101. **Card 101**", "180. **Card 180**",
+				"Returned 80 of 180 matching cards (results 101-180).",
+			},
+			wantMissing: []string{"More results available"},
+		},
+		{
+			name:         "page within second Scryfall page",
+			args:         map[string]any{"query": "t:creature", "limit": float64(5), "page": float64(36)},
+			wantPages:    "2",
+			wantContains: []string{"Page 36, showing 5 of 180 cards", "176. **Card 176**"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestedPages = nil
+			got, isErr := callTool(t, s.handleSearchCards, tt.args)
+			if isErr {
+				t.Fatalf("handleSearchCards() returned error: %s", got)
+			}
+
+			// go-scryfall omits page=1 from the query string
+			for i, page := range requestedPages {
+				if page == "" {
+					requestedPages[i] = "1"
+				}
+			}
+			if pages := strings.Join(requestedPages, ","); pages != tt.wantPages {
+				t.Errorf("handleSearchCards() fetched Scryfall pages %s, want %s", pages, tt.wantPages)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleSearchCards() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleSearchCards() unexpectedly contains %q", unwanted)
+				}
+			}
+		})
+	}
+
+	if got, isErr := callTool(t, s.handleSearchCards, map[string]any{"query": "t:creature", "page": float64(0)}); !isErr {
+		t.Errorf("handleSearchCards() with page 0 should fail, got: %s", got)
+	}
+}
+
+func TestColorClausesToIdentity(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		want        string
+		wantChanged bool
+	}{
+		{name: "short form", query: "c:ub t:instant", want: "id:ub t:instant", wantChanged: true},
+		{name: "comparison", query: "t:creature c<=gw", want: "t:creature id<=gw", wantChanged: true},
+		{name: "long form", query: "color=bant OR colors>=r", want: "id=bant OR id>=r", wantChanged: true},
+		{name: "negated and grouped", query: "-c:r (C:u or c:b)", want: "-id:r (id:u or id:b)", wantChanged: true},
+		{name: "quoted text untouched", query: `o:"c:u" c:u`, want: `o:"c:u" id:u`, wantChanged: true},
+		{name: "similar keywords untouched", query: "cmc:3 id:g mc:x", want: "cmc:3 id:g mc:x"},
+		{name: "no color clause", query: "t:dragon", want: "t:dragon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := colorClausesToIdentity(tt.query)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("colorClausesToIdentity(%q) = %q, %v, want %q, %v",
+					tt.query, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestHandleSearchCardsIdentity(t *testing.T) {
+	var gotQuery string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [
+			{"name": "Counterspell", "mana_cost": "{U}{U}", "type_line": "Instant"}
+		]}`))
+	}))
+
+	got, isErr := callTool(t, s.handleSearchCards, map[string]any{"query": "c:ub t:instant", "identity": true})
+	if isErr {
+		t.Fatalf("handleSearchCards() returned error: %s", got)
+	}
+	if gotQuery != "id:ub t:instant" {
+		t.Errorf("handleSearchCards() sent q=%q, want the color identity query", gotQuery)
+	}
+	if !strings.Contains(got, "Note: searched color identity instead of color, as `id:ub t:instant`") {
+		t.Errorf("handleSearchCards() missing the identity note in output: %s", got)
+	}
+
+	got, isErr = callTool(t, s.handleSearchCards, map[string]any{"query": "c:ub t:instant"})
+	if isErr {
+		t.Fatalf("handleSearchCards() returned error: %s", got)
+	}
+	if gotQuery != "c:ub t:instant" || strings.Contains(got, "Note:") {
+		t.Errorf("handleSearchCards() without identity sent q=%q, want the query unchanged", gotQuery)
+	}
+}
+
+func TestHandleSearchCardsVerbose(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [
+			{"name": "Absorb", "mana_cost": "{W}{U}{U}", "cmc": 3, "type_line": "Instant",
+			 "color_identity": ["W", "U"], "set": "rna", "set_name": "Ravnica Allegiance",
+			 "rarity": "rare", "released_at": "2019-01-25"}
+		]}`))
+	}))
+
+	got, isErr := callTool(t, s.handleSearchCards, map[string]any{"query": "absorb", "verbose": true})
+	if isErr {
+		t.Fatalf("handleSearchCards() returned error: %s", got)
+	}
+	for _, want := range []string{"Set: Ravnica Allegiance (RNA) [R, 2019]", "CMC: 3 | Color Identity: W, U"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleSearchCards() missing %q in verbose output: %s", want, got)
+		}
+	}
+
+	got, isErr = callTool(t, s.handleSearchCards, map[string]any{"query": "absorb"})
+	if isErr {
+		t.Fatalf("handleSearchCards() returned error: %s", got)
+	}
+	if !strings.Contains(got, "Set: Ravnica Allegiance (RNA)\n") || strings.Contains(got, "CMC:") {
+		t.Errorf("handleSearchCards() default output should stay compact: %s", got)
+	}
+}
+
+func TestHandleSearchCardsBadQuery(t *testing.T) {
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"object": "error", "code": "bad_request", "status": 400,
+			"warnings": ["Invalid expression \u201ctype::creature\u201d was ignored."],
+			"details": "All of your terms were ignored."}`))
+	}))
+
+	got, isErr := callTool(t, s.handleSearchCards, map[string]any{"query": "type::creature"})
+	if !isErr {
+		t.Fatalf("handleSearchCards() should fail for a rejected query: %s", got)
+	}
+	for _, want := range []string{
+		`Scryfall rejected the query "type::creature": All of your terms were ignored.`,
+		"- Invalid expression \u201ctype::creature\u201d was ignored.",
+		"https://scryfall.com/docs/syntax",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleSearchCards() missing %q in output: %s", want, got)
+		}
+	}
+}
+
+func TestScryfallQueryError(t *testing.T) {
+	if _, ok := scryfallQueryError("sol ring", &scryfall.Error{Status: http.StatusNotFound, Details: "No cards"}); ok {
+		t.Error("scryfallQueryError() handled a 404")
+	}
+	if _, ok := scryfallQueryError("sol ring", errors.New("connection refused")); ok {
+		t.Error("scryfallQueryError() handled a transport error")
+	}
+
+	got, ok := scryfallQueryError("t:", &scryfall.Error{Status: http.StatusBadRequest, Details: "Bad query"})
+	if !ok || strings.Contains(got, "Warnings:") || !strings.Contains(got, "Bad query") {
+		t.Errorf("scryfallQueryError() without warnings = %q, %v", got, ok)
+	}
+}
+
+func TestExactNameQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Sol Ring", want: `!"Sol Ring"`},
+		{name: `Kongming, "Sleeping Dragon"`, want: `!"Kongming, \"Sleeping Dragon\""`},
+		{name: `Back\slash`, want: `!"Back\\slash"`},
+	}
+
+	for _, tt := range tests {
+		if got := exactNameQuery(tt.name); got != tt.want {
+			t.Errorf("exactNameQuery(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetRulings(t *testing.T) {
+	const solRingID = "4cbc6901-6a4a-4d0a-83ea-7eefa3b35021"
+
+	var paths []string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/cards/named":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{ID: solRingID, Name: "Sol Ring"})
+		case "/cards/" + solRingID + "/rulings":
+			_, _ = w.Write([]byte(`{"object": "list", "has_more": false, "data": [
+				{"source": "wotc", "published_at": "2004-10-04", "comment": "Sol Ring's ability is a mana ability."}
+			]}`))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantErr   bool
+		wantTitle string
+		wantPaths string
+	}{
+		{
+			name:      "by name",
+			args:      map[string]any{"name": "Sol Ring"},
+			wantTitle: "# Rulings for Sol Ring",
+			wantPaths: "/cards/named,/cards/" + solRingID + "/rulings",
+		},
+		{
+			name:      "by scryfall ID skips the name lookup",
+			args:      map[string]any{"scryfall_id": solRingID},
+			wantTitle: "# Rulings for Scryfall ID " + solRingID,
+			wantPaths: "/cards/" + solRingID + "/rulings",
+		},
+		{
+			name:      "scryfall ID with a display name",
+			args:      map[string]any{"name": "Sol Ring", "scryfall_id": solRingID},
+			wantTitle: "# Rulings for Sol Ring",
+			wantPaths: "/cards/" + solRingID + "/rulings",
+		},
+		{
+			name:    "malformed scryfall ID",
+			args:    map[string]any{"scryfall_id": "../sets/mh3"},
+			wantErr: true,
+		},
+		{
+			name:    "no arguments",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			got, isErr := callTool(t, s.handleGetRulings, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("handleGetRulings() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			if tt.wantErr {
+				if len(paths) > 0 {
+					t.Errorf("handleGetRulings() made requests %v for invalid input", paths)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantTitle) || !strings.Contains(got, "mana ability") {
+				t.Errorf("handleGetRulings() output = %s, want title %q and the ruling", got, tt.wantTitle)
+			}
+			if gotPaths := strings.Join(paths, ","); gotPaths != tt.wantPaths {
+				t.Errorf("handleGetRulings() requested %s, want %s", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestHandleGetCardsBatch(t *testing.T) {
+	known := map[string]scryfall.Card{
+		"sol ring": {
+			Name: "Sol Ring", ManaCost: "{1}", TypeLine: "Artifact",
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal}, Prices: scryfall.Prices{USD: "1.50"},
+		},
+		"brazen borrower": {
+			Name: "Brazen Borrower // Petty Theft", TypeLine: "Creature — Faerie Rogue // Instant — Adventure",
+			CardFaces:  []scryfall.CardFace{{ManaCost: "{1}{U}{U}"}, {ManaCost: "{1}{U}"}},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		},
+	}
+
+	requests := 0
+	s := newTestMTGServer(t, collectionHandler(t, known, &requests))
+
+	tests := []struct {
+		name         string
+		cards        string
+		wantErr      bool
+		wantContains []string
+	}{
+		{
+			name:  "newline list with quantities and duplicates",
+			cards: "1 Sol Ring\nBrazen Borrower\nsol ring\nNot A Card",
+			wantContains: []string{
+				"# Card Lookup (2 of 3 found)",
+				"- **Sol Ring** {1} | Artifact | Commander: legal | $1.50",
+				"- **Brazen Borrower // Petty Theft** {1}{U}{U} // {1}{U} |",
+				"**Not Found (1):**\n- Not A Card",
+			},
+		},
+		{
+			name:         "JSON array",
+			cards:        `["Sol Ring", "Brazen Borrower"]`,
+			wantContains: []string{"# Card Lookup (2 of 2 found)"},
+		},
+		{
+			name:    "no names",
+			cards:   "\n// just a comment\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetCardsBatch, map[string]any{"cards": tt.cards})
+			if isErr != tt.wantErr {
+				t.Fatalf("handleGetCardsBatch() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetCardsBatch() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+
+	if requests != 2 {
+		t.Errorf("handleGetCardsBatch() made %d collection requests, want 1 per successful call", requests)
+	}
+
+	var tooMany []string
+	for i := range maxBatchCards + 1 {
+		tooMany = append(tooMany, fmt.Sprintf("Card %d", i))
+	}
+	if got, isErr := callTool(t, s.handleGetCardsBatch, map[string]any{"cards": strings.Join(tooMany, "\n")}); !isErr {
+		t.Errorf("handleGetCardsBatch() over the cap should fail, got: %s", got)
+	}
+
+	failing := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	got, isErr := callTool(t, failing.handleGetCardsBatch, map[string]any{"cards": "Sol Ring"})
+	if !isErr || !strings.Contains(got, "Failed to look up cards") {
+		t.Errorf("handleGetCardsBatch() with Scryfall down = %q, isError %v, want a tool error", got, isErr)
+	}
+}
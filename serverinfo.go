@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// serverTransport is the MCP transport the server listens on.
+	serverTransport = "stdio"
+	// serviceCheckTimeout bounds each reachability check, so server_info answers quickly
+	// even when a service is down.
+	serviceCheckTimeout = 5 * time.Second
+)
+
+// version and commit identify the build. Override them at build time with
+// -ldflags "-X main.version=... -X main.commit=..."; commit falls back to the VCS
+// revision Go embeds in the binary.
+var (
+	version = "1.0.0" //nolint:gochecknoglobals // set at build time
+	commit  = ""      //nolint:gochecknoglobals // set at build time
+)
+
+// buildCommit returns the commit the server was built from, or "unknown".
+func buildCommit() string {
+	if commit != "" {
+		return commit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// serviceEndpoint is an upstream API server_info checks.
+type serviceEndpoint struct {
+	name string
+	url  string
+}
+
+// serviceStatus is the outcome of one reachability check. statusCode is 0 when the
+// service couldn't be reached at all.
+type serviceStatus struct {
+	name       string
+	statusCode int
+	latency    time.Duration
+	err        error
+}
+
+// ok reports whether the service answered without a server error. Any other answer,
+// even 404 or 405 for a HEAD on the API root, shows the service is up.
+func (st serviceStatus) ok() bool {
+	return st.err == nil && st.statusCode < http.StatusInternalServerError
+}
+
+// serviceEndpoints returns the upstream APIs the server depends on.
+func (s *MTGCommanderServer) serviceEndpoints() []serviceEndpoint {
+	return []serviceEndpoint{
+		{name: "Scryfall", url: s.scryfallBaseURL},
+		{name: "Moxfield", url: moxfieldAPIURL},
+		{name: "EDHREC", url: s.edhrecBaseURL},
+	}
+}
+
+// checkServices sends one HEAD request to each endpoint concurrently, without
+// retries, and reports the results in endpoint order.
+func checkServices(ctx context.Context, endpoints []serviceEndpoint) []serviceStatus {
+	statuses := make([]serviceStatus, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Go(func() {
+			statuses[i] = checkService(ctx, endpoint)
+		})
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// checkService sends a HEAD request to endpoint and times the response.
+func checkService(ctx context.Context, endpoint serviceEndpoint) serviceStatus {
+	status := serviceStatus{name: endpoint.name}
+
+	ctx, cancel := context.WithTimeout(ctx, serviceCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint.url, nil)
+	if err != nil {
+		status.err = err
+		return status
+	}
+	req.Header.Set("User-Agent", "MTG-Commander-MCP-Server/1.0")
+
+	start := time.Now()
+	resp, err := sharedHTTPClient.Do(req)
+	status.latency = time.Since(start)
+	if err != nil {
+		status.err = err
+		return status
+	}
+	closeResponse(resp)
+
+	status.statusCode = resp.StatusCode
+	return status
+}
+
+// FormatServerInfoForDisplay formats the server's build details, its recent Scryfall
+// request count, and the reachability of each upstream service.
+func FormatServerInfoForDisplay(offline bool, recentScryfallRequests int, statuses []serviceStatus) string {
+	var output strings.Builder
+	output.WriteString("# Server Info\n\n")
+	output.WriteString(fmt.Sprintf("**Version:** %s\n", version))
+	output.WriteString(fmt.Sprintf("**Build Commit:** %s\n", buildCommit()))
+	output.WriteString(fmt.Sprintf("**Go Version:** %s\n", runtime.Version()))
+	output.WriteString(fmt.Sprintf("**Transport:** %s\n", serverTransport))
+	output.WriteString(fmt.Sprintf("**Offline Mode:** %t\n", offline))
+	output.WriteString(fmt.Sprintf("**go-scryfall:** v%s\n", scryfall.Version))
+
+	output.WriteString("\n## Scryfall Rate Limit\n\n")
+	output.WriteString(fmt.Sprintf("- Requests in the last %s: %d (%.2f/s on average)\n",
+		scryfallRateWindow, recentScryfallRequests, float64(recentScryfallRequests)/scryfallRateWindow.Seconds()))
+	output.WriteString(fmt.Sprintf("- Limit: %d requests/s across all tool calls, as Scryfall asks of API clients\n",
+		scryfallRPS))
+
+	output.WriteString("\n## Service Reachability\n\n")
+	for _, status := range statuses {
+		latency := status.latency.Round(time.Millisecond)
+		switch {
+		case status.err != nil:
+			output.WriteString(fmt.Sprintf("- %s: ❌ unreachable after %s (%v)\n", status.name, latency, status.err))
+		case !status.ok():
+			output.WriteString(fmt.Sprintf("- %s: ⚠️ HTTP %d in %s\n", status.name, status.statusCode, latency))
+		default:
+			output.WriteString(fmt.Sprintf("- %s: ✅ OK (HTTP %d in %s)\n", status.name, status.statusCode, latency))
+		}
+	}
+
+	return output.String()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckServices(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("checkServices() sent %s, want HEAD", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer up.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	statuses := checkServices(t.Context(), []serviceEndpoint{
+		{name: "Up", url: up.URL},
+		{name: "Failing", url: failing.URL},
+		{name: "Down", url: down.URL},
+	})
+
+	if len(statuses) != 3 {
+		t.Fatalf("checkServices() returned %d statuses, want 3", len(statuses))
+	}
+	if !statuses[0].ok() || statuses[0].statusCode != http.StatusNotFound {
+		t.Errorf("checkServices() Up = %+v, want reachable", statuses[0])
+	}
+	if statuses[1].ok() || statuses[1].statusCode != http.StatusServiceUnavailable {
+		t.Errorf("checkServices() Failing = %+v, want a server error", statuses[1])
+	}
+	if statuses[2].ok() || statuses[2].err == nil {
+		t.Errorf("checkServices() Down = %+v, want unreachable", statuses[2])
+	}
+
+	got := FormatServerInfoForDisplay(false, 42, statuses)
+	for _, want := range []string{
+		"**Version:** " + version,
+		"**Build Commit:** ",
+		"**Transport:** stdio",
+		"**Offline Mode:** false",
+		"**go-scryfall:** v0.9.1",
+		"- Requests in the last 1m0s: 42 (0.70/s on average)",
+		"- Up: ✅ OK (HTTP 404 in ",
+		"- Failing: ⚠️ HTTP 503 in ",
+		"- Down: ❌ unreachable after ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatServerInfoForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+}
+
+func TestBuildCommit(t *testing.T) {
+	original := commit
+	t.Cleanup(func() { commit = original })
+
+	commit = "abc1234"
+	if got := buildCommit(); got != "abc1234" {
+		t.Errorf("buildCommit() = %q, want the ldflags commit", got)
+	}
+
+	commit = ""
+	if got := buildCommit(); got == "" {
+		t.Error("buildCommit() without an ldflags commit should not be empty")
+	}
+}
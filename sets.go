@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+const (
+	// scryfallSearchURL is Scryfall's website search page.
+	scryfallSearchURL = "https://scryfall.com/search"
+	// maxSetMatches caps how many sets a name search lists.
+	maxSetMatches = 10
+)
+
+// setSearchURL returns the Scryfall website search for every card in a set.
+func setSearchURL(code string) string {
+	return scryfallSearchURL + "?q=" + url.QueryEscape("set:"+strings.ToLower(code))
+}
+
+// setReleaseDate returns a set's release date, or "Unknown" when Scryfall has none.
+func setReleaseDate(set scryfall.Set) string {
+	if set.ReleasedAt == nil {
+		return "Unknown"
+	}
+	return set.ReleasedAt.Format("2006-01-02")
+}
+
+// findSetsByName returns the sets whose name contains the query, ignoring case.
+// An exact name match is returned alone; otherwise matches are sorted newest first.
+func findSetsByName(sets []scryfall.Set, name string) []scryfall.Set {
+	query := strings.ToLower(strings.TrimSpace(name))
+
+	var matches []scryfall.Set
+	for _, set := range sets {
+		setName := strings.ToLower(set.Name)
+		if setName == query {
+			return []scryfall.Set{set}
+		}
+		if strings.Contains(setName, query) {
+			matches = append(matches, set)
+		}
+	}
+
+	// Sets without a release date sort last
+	released := func(set scryfall.Set) time.Time {
+		if set.ReleasedAt == nil {
+			return time.Time{}
+		}
+		return set.ReleasedAt.Time
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return released(matches[i]).After(released(matches[j]))
+	})
+	return matches
+}
+
+// FormatSetForDisplay formats a set's metadata block.
+func FormatSetForDisplay(set scryfall.Set) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# %s (%s)\n\n", set.Name, strings.ToUpper(set.Code)))
+	output.WriteString(fmt.Sprintf("**Released:** %s\n", setReleaseDate(set)))
+	output.WriteString(fmt.Sprintf("**Set Type:** %s\n", set.SetType))
+	output.WriteString(fmt.Sprintf("**Card Count:** %d\n", set.CardCount))
+
+	if set.Block != nil && *set.Block != "" {
+		output.WriteString(fmt.Sprintf("**Block:** %s\n", *set.Block))
+	}
+	if set.ParentSetCode != "" {
+		output.WriteString(fmt.Sprintf("**Parent Set:** %s\n", strings.ToUpper(set.ParentSetCode)))
+	}
+	if set.Digital {
+		output.WriteString("**Digital Only:** Yes\n")
+	}
+	if set.FoilOnly {
+		output.WriteString("**Foil Only:** Yes\n")
+	}
+	if set.IconSVGURI != "" {
+		output.WriteString(fmt.Sprintf("**Icon:** %s\n", set.IconSVGURI))
+	}
+
+	output.WriteString(fmt.Sprintf("\n**Browse Cards:** %s\n", setSearchURL(set.Code)))
+	return output.String()
+}
+
+// FormatSetMatchesForDisplay lists the sets matching a name search.
+func FormatSetMatchesForDisplay(name string, matches []scryfall.Set) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Sets matching %q\n\n", name))
+
+	shown := matches
+	if len(shown) > maxSetMatches {
+		shown = shown[:maxSetMatches]
+		output.WriteString(fmt.Sprintf("Showing %d of %d matches (newest first)\n\n", len(shown), len(matches)))
+	}
+
+	output.WriteString("| Code | Name | Released | Type | Cards |\n")
+	output.WriteString("|------|------|----------|------|-------|\n")
+	for _, set := range shown {
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d |\n",
+			strings.ToUpper(set.Code), set.Name, setReleaseDate(set), set.SetType, set.CardCount))
+	}
+
+	output.WriteString("\nCall get_set with a `code` for full details on one set.\n")
+	return output.String()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetSet(t *testing.T) {
+	const mh3 = `{"object": "set", "code": "mh3", "name": "Modern Horizons 3", "set_type": "draft_innovation",
+		"released_at": "2024-06-14", "card_count": 303,
+		"icon_svg_uri": "https://svgs.scryfall.io/sets/mh3.svg"}`
+
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sets/mh3":
+			_, _ = w.Write([]byte(mh3))
+		case "/sets":
+			_, _ = w.Write([]byte(`{"object": "list", "has_more": false, "data": [
+				{"object": "set", "code": "mh1", "name": "Modern Horizons", "set_type": "draft_innovation",
+				 "released_at": "2019-06-14", "card_count": 255},
+				` + mh3 + `,
+				{"object": "set", "code": "mh2", "name": "Modern Horizons 2", "set_type": "draft_innovation",
+				 "released_at": "2021-06-18", "card_count": 303}
+			]}`))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name: "by code",
+			args: map[string]any{"code": "MH3"},
+			wantContains: []string{
+				"# Modern Horizons 3 (MH3)",
+				"**Released:** 2024-06-14",
+				"**Set Type:** draft_innovation",
+				"**Card Count:** 303",
+				"**Icon:** https://svgs.scryfall.io/sets/mh3.svg",
+				"https://scryfall.com/search?q=set%3Amh3",
+			},
+		},
+		{
+			name:         "exact name",
+			args:         map[string]any{"name": "modern horizons"},
+			wantContains: []string{"# Modern Horizons (MH1)"},
+			wantMissing:  []string{"Modern Horizons 2"},
+		},
+		{
+			name:         "partial name lists matches newest first",
+			args:         map[string]any{"name": "Horizons"},
+			wantContains: []string{"| MH3 | Modern Horizons 3 | 2024-06-14 |", "| MH1 | Modern Horizons | 2019-06-14 |"},
+		},
+		{
+			name:         "no name matches",
+			args:         map[string]any{"name": "Nonexistent"},
+			wantContains: []string{`No sets match "Nonexistent".`},
+		},
+		{
+			name:    "unknown code",
+			args:    map[string]any{"code": "zzz"},
+			wantErr: true,
+		},
+		{
+			name:    "no arguments",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetSet, tt.args)
+
+			if isErr != tt.wantErr {
+				t.Errorf("handleGetSet() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetSet() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleGetSet() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	// Matches must come newest first
+	got, _ := callTool(t, s.handleGetSet, map[string]any{"name": "Horizons"})
+	if strings.Index(got, "MH3") > strings.Index(got, "MH2") || strings.Index(got, "MH2") > strings.Index(got, "MH1") {
+		t.Errorf("handleGetSet() matches not sorted newest first: %s", got)
+	}
+
+	failing := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	got, isErr := callTool(t, failing.handleGetSet, map[string]any{"name": "Horizons"})
+	if !isErr || !strings.Contains(got, "Failed to list sets") {
+		t.Errorf("handleGetSet() with Scryfall down = %q, isError %v, want a tool error", got, isErr)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// cardRole describes a functional role a card can fill in a deck.
+type cardRole struct {
+	Name     string
+	Query    string
+	Patterns []string
+}
+
+// SimilarCard is a candidate replacement for a card along with why it was suggested.
+type SimilarCard struct {
+	Name   string
+	Reason string
+}
+
+// cardRoles returns the functional roles recognized when looking for similar cards.
+// Order matters: the most specific roles come first.
+func cardRoles() []cardRole {
+	return []cardRole{
+		{
+			Name:     "board wipe",
+			Query:    "otag:sweeper",
+			Patterns: []string{"destroy all", "exile all", "all creatures get -", "each creature", "return all"},
+		},
+		{
+			Name:     "counterspell",
+			Query:    "otag:counterspell",
+			Patterns: []string{"counter target"},
+		},
+		{
+			Name:     "tutor",
+			Query:    "otag:tutor",
+			Patterns: []string{"search your library for a card"},
+		},
+		{
+			Name:     "land ramp",
+			Query:    "otag:ramp t:sorcery",
+			Patterns: []string{"search your library for a basic land", "search your library for up to two basic land"},
+		},
+		{
+			Name:     "mana rock",
+			Query:    "otag:mana-rock",
+			Patterns: []string{"{t}: add"},
+		},
+		{
+			Name:     "spot removal",
+			Query:    "otag:removal",
+			Patterns: []string{"destroy target", "exile target", "deals damage to any target"},
+		},
+		{
+			Name:     "card draw",
+			Query:    "otag:card-advantage",
+			Patterns: []string{"draw a card", "draw two cards", "draw three cards", "draws a card"},
+		},
+		{
+			Name:     "graveyard recursion",
+			Query:    "otag:reanimation",
+			Patterns: []string{"from your graveyard to the battlefield", "return target creature card from your graveyard"},
+		},
+	}
+}
+
+// classifyCardFunction returns the roles a card fills based on its oracle text.
+func classifyCardFunction(card scryfall.Card) []cardRole {
+	oracle := strings.ToLower(cardOracleText(card))
+	if oracle == "" {
+		return nil
+	}
+
+	var roles []cardRole
+	for _, role := range cardRoles() {
+		for _, pattern := range role.Patterns {
+			if strings.Contains(oracle, pattern) {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+
+	return roles
+}
+
+// cardOracleText returns the oracle text of a card, joining faces for multi-faced cards.
+func cardOracleText(card scryfall.Card) string {
+	if card.OracleText != "" || len(card.CardFaces) == 0 {
+		return card.OracleText
+	}
+
+	texts := make([]string, 0, len(card.CardFaces))
+	for _, face := range card.CardFaces {
+		if face.OracleText != nil {
+			texts = append(texts, *face.OracleText)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// colorIdentityQuery converts a color identity into a Scryfall color string.
+func colorIdentityQuery(colors []scryfall.Color) string {
+	if len(colors) == 0 {
+		return "c"
+	}
+
+	var query strings.Builder
+	for _, c := range colors {
+		query.WriteString(strings.ToLower(string(c)))
+	}
+
+	return query.String()
+}
+
+// buildSimilarCardsQuery builds a Scryfall query for cards filling the same role as card.
+func buildSimilarCardsQuery(card scryfall.Card, role cardRole) string {
+	return fmt.Sprintf("%s id<=%s f:commander -%s",
+		role.Query, colorIdentityQuery(card.ColorIdentity), exactNameQuery(card.Name))
+}
+
+// primaryCardType returns the first major card type in a type line.
+func primaryCardType(typeLine string) string {
+	lower := strings.ToLower(typeLine)
+	for _, t := range []string{"creature", "planeswalker", "instant", "sorcery", "artifact", "enchantment", "land"} {
+		if strings.Contains(lower, t) {
+			return t
+		}
+	}
+
+	return ""
+}
+
+// filterSimilarViews keeps the EDHREC similar cards that fit the same decks as card:
+// resolved by Scryfall, legal in Commander, and within card's color identity.
+func filterSimilarViews(card scryfall.Card, views []EDHRECCardView, lookup CardLookupResult) []EDHRECCardView {
+	var kept []EDHRECCardView
+	for _, view := range views {
+		similar, ok := lookup.Get(view.Name)
+		if !ok || similar.Legalities.Commander != scryfall.LegalityLegal {
+			continue
+		}
+		if isColorSubset(similar.ColorIdentity, card.ColorIdentity) {
+			kept = append(kept, view)
+		}
+	}
+	return kept
+}
+
+// buildSimilarCards merges EDHREC similar cards and Scryfall role matches into a ranked candidate list.
+func buildSimilarCards(
+	card scryfall.Card,
+	roles []cardRole,
+	edhrecSimilar []EDHRECCardView,
+	roleMatches [][]scryfall.Card,
+	limit int,
+) []SimilarCard {
+	seen := map[string]bool{strings.ToLower(card.Name): true}
+	var candidates []SimilarCard
+
+	add := func(name, reason string) {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		candidates = append(candidates, SimilarCard{Name: name, Reason: reason})
+	}
+
+	for _, view := range edhrecSimilar {
+		add(view.Name, "EDHREC lists it as a similar card")
+	}
+
+	cardType := primaryCardType(card.TypeLine)
+	for i, matches := range roleMatches {
+		if i >= len(roles) {
+			break
+		}
+		for _, match := range matches {
+			reason := fmt.Sprintf("Also a %s", roles[i].Name)
+			if cardType != "" && primaryCardType(match.TypeLine) == cardType {
+				reason += fmt.Sprintf(" (same card type: %s)", cardType)
+			}
+			if match.ManaCost != "" {
+				reason += fmt.Sprintf(", costs %s", match.ManaCost)
+			}
+			add(match.Name, reason)
+		}
+	}
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates
+}
+
+// FormatSimilarCardsForDisplay formats similar card candidates for text display.
+func FormatSimilarCardsForDisplay(card scryfall.Card, roles []cardRole, candidates []SimilarCard) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# Cards Similar to %s\n\n", card.Name))
+	output.WriteString(fmt.Sprintf("**Type:** %s\n", card.TypeLine))
+
+	if len(roles) > 0 {
+		names := make([]string, len(roles))
+		for i, role := range roles {
+			names[i] = role.Name
+		}
+		output.WriteString(fmt.Sprintf("**Detected Role:** %s\n", strings.Join(names, ", ")))
+	}
+
+	if len(candidates) == 0 {
+		output.WriteString("\nNo good functional analogs found for this card. ")
+		output.WriteString("It may be unique, or its role could not be identified from its rules text.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\n## Candidates (%d)\n\n", len(candidates)))
+	for i, candidate := range candidates {
+		output.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, candidate.Name))
+		output.WriteString(fmt.Sprintf("   - Why similar: %s\n", candidate.Reason))
+	}
+
+	output.WriteString("\n*Candidates are limited to the card's color identity and legal in Commander.*\n")
+
+	return output.String()
+}
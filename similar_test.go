@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestClassifyCardFunction(t *testing.T) {
+	tests := []struct {
+		name      string
+		card      scryfall.Card
+		wantRoles []string
+	}{
+		{
+			name: "board wipe",
+			card: scryfall.Card{
+				Name:       "Wrath of God",
+				OracleText: "Destroy all creatures. They can't be regenerated.",
+			},
+			wantRoles: []string{"board wipe"},
+		},
+		{
+			name: "counterspell",
+			card: scryfall.Card{
+				Name:       "Counterspell",
+				OracleText: "Counter target spell.",
+			},
+			wantRoles: []string{"counterspell"},
+		},
+		{
+			name: "removal with card draw",
+			card: scryfall.Card{
+				Name:       "Test Removal",
+				OracleText: "Exile target creature. Draw a card.",
+			},
+			wantRoles: []string{"spot removal", "card draw"},
+		},
+		{
+			name: "multi-faced card uses face text",
+			card: scryfall.Card{
+				Name: "Front // Back",
+				CardFaces: []scryfall.CardFace{
+					{Name: "Front", OracleText: stringPtr("Counter target spell.")},
+					{Name: "Back", OracleText: stringPtr("Flying")},
+				},
+			},
+			wantRoles: []string{"counterspell"},
+		},
+		{
+			name: "vanilla creature has no role",
+			card: scryfall.Card{
+				Name:       "Grizzly Bears",
+				OracleText: "",
+			},
+			wantRoles: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyCardFunction(tt.card)
+
+			if len(got) != len(tt.wantRoles) {
+				t.Fatalf("classifyCardFunction() returned %d roles, want %d", len(got), len(tt.wantRoles))
+			}
+
+			for i, role := range got {
+				if role.Name != tt.wantRoles[i] {
+					t.Errorf("classifyCardFunction() role[%d] = %v, want %v", i, role.Name, tt.wantRoles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSimilarCardsQuery(t *testing.T) {
+	card := scryfall.Card{
+		Name:          "Wrath of God",
+		ColorIdentity: []scryfall.Color{"W"},
+	}
+
+	got := buildSimilarCardsQuery(card, cardRoles()[0])
+	want := `otag:sweeper id<=w f:commander -!"Wrath of God"`
+	if got != want {
+		t.Errorf("buildSimilarCardsQuery() = %v, want %v", got, want)
+	}
+
+	colorless := buildSimilarCardsQuery(scryfall.Card{Name: "Oblivion Stone"}, cardRoles()[0])
+	if !strings.Contains(colorless, "id<=c") {
+		t.Errorf("buildSimilarCardsQuery() = %v, want colorless identity", colorless)
+	}
+
+	quoted := buildSimilarCardsQuery(scryfall.Card{Name: `Kongming, "Sleeping Dragon"`}, cardRoles()[0])
+	if !strings.HasSuffix(quoted, ` -!"Kongming, \"Sleeping Dragon\""`) {
+		t.Errorf("buildSimilarCardsQuery() = %v, want the quoted name escaped", quoted)
+	}
+}
+
+func TestBuildSimilarCards(t *testing.T) {
+	card := scryfall.Card{
+		Name:     "Wrath of God",
+		TypeLine: "Sorcery",
+	}
+	roles := []cardRole{cardRoles()[0]}
+
+	edhrecSimilar := []EDHRECCardView{
+		{Name: "Day of Judgment"},
+		{Name: "Wrath of God"},
+	}
+	roleMatches := [][]scryfall.Card{
+		{
+			{Name: "Day of Judgment", TypeLine: "Sorcery", ManaCost: "{2}{W}{W}"},
+			{Name: "Farewell", TypeLine: "Sorcery", ManaCost: "{4}{W}{W}"},
+			{Name: "Ugin, the Spirit Dragon", TypeLine: "Legendary Planeswalker — Ugin", ManaCost: "{8}"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantNames []string
+	}{
+		{
+			name:      "merges and dedupes sources",
+			limit:     0,
+			wantNames: []string{"Day of Judgment", "Farewell", "Ugin, the Spirit Dragon"},
+		},
+		{
+			name:      "respects limit",
+			limit:     2,
+			wantNames: []string{"Day of Judgment", "Farewell"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSimilarCards(card, roles, edhrecSimilar, roleMatches, tt.limit)
+
+			if len(got) != len(tt.wantNames) {
+				t.Fatalf("buildSimilarCards() returned %d candidates, want %d", len(got), len(tt.wantNames))
+			}
+
+			for i, candidate := range got {
+				if candidate.Name != tt.wantNames[i] {
+					t.Errorf("buildSimilarCards() candidate[%d] = %v, want %v", i, candidate.Name, tt.wantNames[i])
+				}
+				if candidate.Reason == "" {
+					t.Errorf("buildSimilarCards() candidate %v has no reason", candidate.Name)
+				}
+			}
+		})
+	}
+
+	got := buildSimilarCards(card, roles, nil, roleMatches, 0)
+	if !strings.Contains(got[0].Reason, "same card type: sorcery") {
+		t.Errorf("buildSimilarCards() reason = %q, want same card type note", got[0].Reason)
+	}
+	if strings.Contains(got[2].Reason, "same card type") {
+		t.Errorf("buildSimilarCards() reason = %q, should not claim same type for a planeswalker", got[2].Reason)
+	}
+}
+
+func TestFilterSimilarViews(t *testing.T) {
+	card := scryfall.Card{Name: "Wrath of God", ColorIdentity: []scryfall.Color{"W"}}
+	lookup := CardLookupResult{Cards: map[string]scryfall.Card{
+		"day of judgment": {
+			Name: "Day of Judgment", ColorIdentity: []scryfall.Color{"W"},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		},
+		"damnation": {
+			Name: "Damnation", ColorIdentity: []scryfall.Color{"B"},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		},
+		"balance": {
+			Name: "Balance", ColorIdentity: []scryfall.Color{"W"},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityBanned},
+		},
+	}}
+	views := []EDHRECCardView{{Name: "Day of Judgment"}, {Name: "Damnation"}, {Name: "Balance"}, {Name: "Unknown"}}
+
+	got := filterSimilarViews(card, views, lookup)
+	if len(got) != 1 || got[0].Name != "Day of Judgment" {
+		t.Errorf("filterSimilarViews() = %+v, want only Day of Judgment", got)
+	}
+}
+
+func TestFormatSimilarCardsForDisplay(t *testing.T) {
+	card := scryfall.Card{Name: "Wrath of God", TypeLine: "Sorcery"}
+
+	t.Run("with candidates", func(t *testing.T) {
+		got := FormatSimilarCardsForDisplay(card, []cardRole{cardRoles()[0]}, []SimilarCard{
+			{Name: "Day of Judgment", Reason: "Also a board wipe"},
+		})
+
+		for _, want := range []string{"Cards Similar to Wrath of God", "board wipe", "Day of Judgment", "Why similar"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("FormatSimilarCardsForDisplay() missing %q in output", want)
+			}
+		}
+	})
+
+	t.Run("no analogs", func(t *testing.T) {
+		got := FormatSimilarCardsForDisplay(scryfall.Card{Name: "Grizzly Bears"}, nil, nil)
+
+		if !strings.Contains(got, "No good functional analogs") {
+			t.Errorf("FormatSimilarCardsForDisplay() missing no-analog message, got %q", got)
+		}
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestHandleSimilarCards(t *testing.T) {
+	edhrec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/wrath-of-god.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"container": {"json_dict": {"card": {"name": "Wrath of God"},
+			"similar": [{"name": "Day of Judgment"}, {"name": "Damnation"}]}}}`))
+	}))
+	defer edhrec.Close()
+
+	known := map[string]scryfall.Card{
+		"day of judgment": {
+			Name: "Day of Judgment", ColorIdentity: []scryfall.Color{"W"},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		},
+		"damnation": {
+			Name: "Damnation", ColorIdentity: []scryfall.Color{"B"},
+			Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal},
+		},
+	}
+	collection := collectionHandler(t, known, nil)
+
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/named":
+			_ = json.NewEncoder(w).Encode(&scryfall.Card{
+				Name:          "Wrath of God",
+				TypeLine:      "Sorcery",
+				OracleText:    "Destroy all creatures. They can't be regenerated.",
+				ColorIdentity: []scryfall.Color{"W"},
+			})
+		case "/cards/collection":
+			collection(w, r)
+		case "/cards/search":
+			_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [
+				{"name": "Farewell", "type_line": "Sorcery", "mana_cost": "{4}{W}{W}"}
+			]}`))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+	s.edhrecBaseURL = edhrec.URL
+
+	got, isErr := callTool(t, s.handleSimilarCards, map[string]any{"name": "Wrath of God"})
+	if isErr {
+		t.Fatalf("handleSimilarCards() returned error: %s", got)
+	}
+	for _, want := range []string{"**Day of Judgment**", "EDHREC lists it as a similar card", "**Farewell**"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleSimilarCards() missing %q in output: %s", want, got)
+		}
+	}
+	if strings.Contains(got, "Damnation") {
+		t.Errorf("handleSimilarCards() kept an EDHREC card outside the color identity: %s", got)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// defaultMissingStaplesLimit is how many missing staples get_deck_missing_staples lists by default.
+	defaultMissingStaplesLimit = 15
+	// defaultCommanderStaplesLimit is how many cards get_commander_staples lists from a category by default.
+	defaultCommanderStaplesLimit = 25
+)
+
+// stapleCardListTags are the EDHREC categories treated as staples: the commander's
+// high synergy cards and its most played cards.
+func stapleCardListTags() []string {
+	return []string{"highsynergycards", "topcards"}
+}
+
+// deckCommanderNames returns the names of a deck's commanders, sorted.
+func deckCommanderNames(deck *Deck) []string {
+	names := make([]string, 0, len(deck.Commanders))
+	for _, entry := range deck.Commanders {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+// missingStaples returns the staple recommendations the deck isn't running, most
+// included first. A card in both staple categories is listed once.
+func missingStaples(deck *Deck, data *EDHRECData) []EDHRECCardView {
+	deckNames := deckCardNames(deck)
+	seen := make(map[string]bool)
+
+	var missing []EDHRECCardView
+	for _, cardList := range data.CardLists {
+		if !slices.Contains(stapleCardListTags(), cardList.Tag) {
+			continue
+		}
+		for _, view := range cardList.CardViews {
+			name := normalizeCardName(view.Name)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if _, inDeck := deckNames[name]; !inDeck {
+				missing = append(missing, view)
+			}
+		}
+	}
+
+	return sortCardViews(missing, recsSortInclusionDesc)
+}
+
+// FormatMissingStaplesForDisplay lists up to limit missing staples with their
+// inclusion percentage and synergy.
+func FormatMissingStaplesForDisplay(deck *Deck, data *EDHRECData, missing []EDHRECCardView, limit int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Missing Staples: %s\n\n", deck.Name))
+	output.WriteString(fmt.Sprintf("**Commander:** %s\n", data.Card.Name))
+	output.WriteString(fmt.Sprintf("**EDHREC Decks:** %d\n\n", data.NumDecks))
+
+	if len(missing) == 0 {
+		output.WriteString("This deck already runs every EDHREC high synergy and top card for its commander.\n")
+		return output.String()
+	}
+
+	count := len(missing)
+	if limit > 0 && count > limit {
+		count = limit
+	}
+
+	output.WriteString("High synergy and top EDHREC cards this deck isn't running, most played first:\n\n")
+	for i, card := range missing[:count] {
+		line := fmt.Sprintf("%d. **%s**", i+1, card.Name)
+		if data.NumDecks > 0 {
+			percentage := float64(card.Inclusion) / float64(data.NumDecks) * percentageMultiplier
+			line += fmt.Sprintf(" - in %.1f%% of decks", percentage)
+		}
+		if card.Synergy != 0 {
+			line += fmt.Sprintf(" (synergy %+.2f)", card.Synergy)
+		}
+		output.WriteString(line + "\n")
+	}
+
+	if len(missing) > count {
+		output.WriteString(fmt.Sprintf("\n*...and %d more cards*\n", len(missing)-count))
+	}
+
+	return output.String()
+}
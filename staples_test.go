@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMissingStaples(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Name: "Meren Recursion",
+		Commanders: map[string]MoxfieldCardEntry{
+			"Meren": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Meren of Clan Nel Toth"}},
+		},
+		Mainboard: map[string]MoxfieldCardEntry{
+			"Sakura-Tribe Elder": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Sakura-Tribe Elder"}},
+		},
+	}
+	data := &EDHRECData{
+		Card:     EDHRECCardInfo{Name: "Meren of Clan Nel Toth"},
+		NumDecks: 1000,
+		CardLists: []EDHRECCardList{
+			{Header: "High Synergy Cards", Tag: "highsynergycards", CardViews: []EDHRECCardView{
+				{Name: "Spore Frog", Inclusion: 400, Synergy: 0.45},
+				{Name: "sakura-tribe elder", Inclusion: 700, Synergy: 0.3},
+			}},
+			{Header: "Top Cards", Tag: "topcards", CardViews: []EDHRECCardView{
+				{Name: "Eternal Witness", Inclusion: 800, Synergy: 0.2},
+				{Name: "Spore Frog", Inclusion: 400, Synergy: 0.45},
+			}},
+			{Header: "Lands", Tag: "lands", CardViews: []EDHRECCardView{
+				{Name: "Command Tower", Inclusion: 950},
+			}},
+		},
+	}
+
+	missing := missingStaples(deck.ToDeck(), data)
+	var names []string
+	for _, view := range missing {
+		names = append(names, view.Name)
+	}
+	if got, want := strings.Join(names, ", "), "Eternal Witness, Spore Frog"; got != want {
+		t.Fatalf("missingStaples() = %s, want %s", got, want)
+	}
+
+	got := FormatMissingStaplesForDisplay(deck.ToDeck(), data, missing, 1)
+	for _, want := range []string{
+		"# Missing Staples: Meren Recursion",
+		"1. **Eternal Witness** - in 80.0% of decks (synergy +0.20)",
+		"*...and 1 more cards*",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatMissingStaplesForDisplay() missing %q in output: %s", want, got)
+		}
+	}
+
+	got = FormatMissingStaplesForDisplay(deck.ToDeck(), data, nil, 1)
+	if !strings.Contains(got, "already runs every") {
+		t.Errorf("FormatMissingStaplesForDisplay() with nothing missing = %s", got)
+	}
+}
+
+func TestDeckCommanderNames(t *testing.T) {
+	deck := &MoxfieldDeck{Commanders: map[string]MoxfieldCardEntry{
+		"Tymna": {Card: MoxfieldCardInfo{Name: "Tymna the Weaver"}},
+		"Kraum": {Card: MoxfieldCardInfo{Name: "Kraum, Ludevic's Opus"}},
+	}}
+	if got := strings.Join(deckCommanderNames(deck.ToDeck()), " / "); got != "Kraum, Ludevic's Opus / Tymna the Weaver" {
+		t.Errorf("deckCommanderNames() = %s", got)
+	}
+	if got := deckCommanderNames((&MoxfieldDeck{}).ToDeck()); len(got) != 0 {
+		t.Errorf("deckCommanderNames() with no commander = %v, want empty", got)
+	}
+}
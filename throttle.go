@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// scryfallAPIHost is the host whose requests are throttled; images on Scryfall's
+	// CDN aren't rate limited.
+	scryfallAPIHost = "api.scryfall.com"
+	// scryfallRPS is the request rate Scryfall asks API clients to stay under.
+	scryfallRPS = 10
+	// scryfallRateWindow is how far back the recent Scryfall request count looks.
+	scryfallRateWindow = time.Minute
+)
+
+// scryfallLimiter throttles every Scryfall API request, from go-scryfall and direct
+// HTTP calls alike, so parallel tool calls together stay under scryfallRPS.
+var scryfallLimiter = rate.NewLimiter(scryfallRPS, 1) //nolint:gochecknoglobals // shared limiter
+
+// scryfallRequests counts recent Scryfall API requests for server_info.
+var scryfallRequests = newRequestCounter(scryfallRateWindow) //nolint:gochecknoglobals // shared counter
+
+// requestCounter counts requests within a rolling time window. It is safe for concurrent use.
+type requestCounter struct {
+	mu     sync.Mutex
+	window time.Duration
+	times  []time.Time
+}
+
+// newRequestCounter creates a counter over the given rolling window.
+func newRequestCounter(window time.Duration) *requestCounter {
+	return &requestCounter{window: window}
+}
+
+// record counts a request made at now.
+func (c *requestCounter) record(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pruneLocked(now)
+	c.times = append(c.times, now)
+}
+
+// count returns how many requests were made within the window before now.
+func (c *requestCounter) count(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pruneLocked(now)
+	return len(c.times)
+}
+
+// pruneLocked drops requests older than the window. The caller must hold c.mu.
+func (c *requestCounter) pruneLocked(now time.Time) {
+	cutoff := now.Add(-c.window)
+	i := 0
+	for i < len(c.times) && !c.times[i].After(cutoff) {
+		i++
+	}
+	c.times = c.times[i:]
+}
+
+// throttledTransport waits on limiter and records each request to host in counter
+// before sending it; requests to other hosts pass straight through.
+type throttledTransport struct {
+	base    http.RoundTripper
+	host    string
+	limiter *rate.Limiter
+	counter *requestCounter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == t.host {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		t.counter.record(time.Now())
+	}
+	return t.base.RoundTrip(req)
+}
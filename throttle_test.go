@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRequestCounter(t *testing.T) {
+	counter := newRequestCounter(time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	counter.record(start)
+	counter.record(start.Add(30 * time.Second))
+	counter.record(start.Add(45 * time.Second))
+
+	if got := counter.count(start.Add(50 * time.Second)); got != 3 {
+		t.Errorf("count() within the window = %d, want 3", got)
+	}
+	if got := counter.count(start.Add(time.Minute)); got != 2 {
+		t.Errorf("count() after the first request expires = %d, want 2", got)
+	}
+	if got := counter.count(start.Add(2 * time.Minute)); got != 0 {
+		t.Errorf("count() after the window = %d, want 0", got)
+	}
+}
+
+func TestThrottledTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	srvURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter := newRequestCounter(time.Minute)
+	client := &http.Client{Transport: &throttledTransport{
+		base:    http.DefaultTransport,
+		host:    srvURL.Host,
+		limiter: rate.NewLimiter(rate.Inf, 1),
+		counter: counter,
+	}}
+
+	for range 3 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		closeResponse(resp)
+	}
+	if got := counter.count(time.Now()); got != 3 {
+		t.Errorf("count() after 3 throttled requests = %d, want 3", got)
+	}
+
+	// Requests to other hosts bypass the limiter and counter.
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+	resp, err := client.Get(other.URL)
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	closeResponse(resp)
+	if got := counter.count(time.Now()); got != 3 {
+		t.Errorf("count() after a request to another host = %d, want 3", got)
+	}
+
+	// A canceled context gives up waiting for the limiter.
+	blocked := &throttledTransport{
+		base:    http.DefaultTransport,
+		host:    srvURL.Host,
+		limiter: rate.NewLimiter(0, 0),
+		counter: counter,
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := blocked.RoundTrip(req); err == nil {
+		t.Error("RoundTrip() with a canceled context should fail while waiting for the limiter")
+	}
+}
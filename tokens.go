@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// defaultTokenLimit is how many tokens get_token lists by default.
+const defaultTokenLimit = 5
+
+// powerToughnessPattern matches a power/toughness pair such as 1/1 or 4/4.
+var powerToughnessPattern = regexp.MustCompile(`^(\d+|\*)/(\d+|\*)$`) //nolint:gochecknoglobals // compiled once
+
+// tokenColorWords maps color words in a token description to Scryfall color letters.
+func tokenColorWords() map[string]string {
+	return map[string]string{
+		"white": "w", "blue": "u", "black": "b", "red": "r", "green": "g", "colorless": "c",
+	}
+}
+
+// tokenSearchQuery turns a plain token description like "1/1 white Soldier" into a
+// Scryfall token search. Power/toughness pairs and color words become pow/tou and
+// color filters; everything else, including Scryfall syntax, passes through unchanged.
+func tokenSearchQuery(description string) string {
+	terms := []string{"t:token"}
+	for _, word := range strings.Fields(description) {
+		lower := strings.ToLower(word)
+		if match := powerToughnessPattern.FindStringSubmatch(lower); match != nil {
+			terms = append(terms, "pow="+match[1], "tou="+match[2])
+			continue
+		}
+		if color, ok := tokenColorWords()[lower]; ok {
+			terms = append(terms, "c:"+color)
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return strings.Join(terms, " ")
+}
+
+// tokenProducers returns the names of the cards that create a token, from the
+// combo pieces in its related parts.
+func tokenProducers(token scryfall.Card) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, part := range token.AllParts {
+		if part.ID == token.ID || part.Component != scryfall.ComponentComboPiece || seen[part.Name] {
+			continue
+		}
+		seen[part.Name] = true
+		names = append(names, part.Name)
+	}
+	return names
+}
+
+// FormatTokensForDisplay formats token search results with each token's colors,
+// stats, rules text, and the cards that create it.
+func FormatTokensForDisplay(description string, tokens []scryfall.Card, totalCards int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Tokens matching %q\n\n", description))
+	output.WriteString(fmt.Sprintf("Showing %d of %d tokens\n\n", len(tokens), totalCards))
+
+	for _, token := range tokens {
+		output.WriteString(fmt.Sprintf("## %s\n\n", token.Name))
+		output.WriteString(fmt.Sprintf("**Type:** %s\n", token.TypeLine))
+
+		colors := "Colorless"
+		if len(token.Colors) > 0 {
+			letters := make([]string, len(token.Colors))
+			for i, c := range token.Colors {
+				letters[i] = string(c)
+			}
+			colors = strings.Join(letters, ", ")
+		}
+		output.WriteString(fmt.Sprintf("**Colors:** %s\n", colors))
+
+		if token.Power != nil && token.Toughness != nil {
+			output.WriteString(fmt.Sprintf("**Power/Toughness:** %s/%s\n", *token.Power, *token.Toughness))
+		}
+		if token.OracleText != "" {
+			output.WriteString(fmt.Sprintf("**Text:** %s\n", token.OracleText))
+		}
+
+		if producers := tokenProducers(token); len(producers) > 0 {
+			output.WriteString("\n**Created by:**\n")
+			for _, name := range producers {
+				output.WriteString(fmt.Sprintf("- %s\n", name))
+			}
+		} else {
+			output.WriteString("\n*Scryfall lists no cards that create this token.*\n")
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTokenSearchQuery(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{description: "Treasure", want: "t:token Treasure"},
+		{description: "1/1 white Soldier", want: "t:token pow=1 tou=1 c:w Soldier"},
+		{description: "*/* Green Ooze", want: "t:token pow=* tou=* c:g Ooze"},
+		{description: "Zombie o:decayed", want: "t:token Zombie o:decayed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := tokenSearchQuery(tt.description); got != tt.want {
+				t.Errorf("tokenSearchQuery(%q) = %q, want %q", tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleGetToken(t *testing.T) {
+	const soldier = `{"object": "card", "id": "a1b2c3d4-0000-0000-0000-000000000001", "name": "Soldier",
+		"layout": "token", "type_line": "Token Creature — Soldier", "colors": ["W"],
+		"power": "1", "toughness": "1", "oracle_text": "",
+		"all_parts": [
+			{"object": "related_card", "id": "a1b2c3d4-0000-0000-0000-000000000001", "component": "token",
+			 "name": "Soldier", "type_line": "Token Creature — Soldier"},
+			{"object": "related_card", "id": "a1b2c3d4-0000-0000-0000-000000000002", "component": "combo_piece",
+			 "name": "Raise the Alarm", "type_line": "Instant"},
+			{"object": "related_card", "id": "a1b2c3d4-0000-0000-0000-000000000003", "component": "combo_piece",
+			 "name": "Secure the Wastes", "type_line": "Instant"}
+		]}`
+	const treasure = `{"object": "card", "id": "a1b2c3d4-0000-0000-0000-000000000004", "name": "Treasure",
+		"layout": "token", "type_line": "Token Artifact — Treasure", "colors": [],
+		"oracle_text": "{T}, Sacrifice this artifact: Add one mana of any color."}`
+
+	var gotQuery string
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		switch gotQuery {
+		case "t:token pow=1 tou=1 c:w Soldier":
+			_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [` + soldier + `]}`))
+		case "t:token Treasure":
+			_, _ = w.Write([]byte(`{"object": "list", "total_cards": 1, "has_more": false, "data": [` + treasure + `]}`))
+		default:
+			writeScryfallNotFound(w)
+		}
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantErr      bool
+		wantContains []string
+	}{
+		{
+			name: "described token with producers",
+			args: map[string]any{"query": "1/1 white Soldier"},
+			wantContains: []string{
+				"## Soldier",
+				"**Colors:** W",
+				"**Power/Toughness:** 1/1",
+				"**Created by:**\n- Raise the Alarm\n- Secure the Wastes\n",
+			},
+		},
+		{
+			name: "colorless token without producers",
+			args: map[string]any{"query": "Treasure"},
+			wantContains: []string{
+				"**Colors:** Colorless",
+				"**Text:** {T}, Sacrifice this artifact",
+				"Scryfall lists no cards that create this token.",
+			},
+		},
+		{
+			name:         "no matches",
+			args:         map[string]any{"query": "Dragon Egg"},
+			wantContains: []string{`No tokens found matching "Dragon Egg".`},
+		},
+		{
+			name:    "blank query",
+			args:    map[string]any{"query": "  "},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleGetToken, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("handleGetToken() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleGetToken() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+}
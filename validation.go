@@ -0,0 +1,373 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// unlimitedCopies is the copy limit of basic lands and cards like Relentless Rats.
+const unlimitedCopies = -1
+
+// 60-card constructed deck construction rules.
+const (
+	constructedMinDeckSize  = 60
+	constructedMaxSideboard = 15
+	constructedCopyLimit    = 4
+)
+
+// validationFormats returns the formats validate_deck_format checks.
+func validationFormats() []string {
+	return []string{"commander", "modern", "legacy", "pauper", "standard"}
+}
+
+// copyLimitPattern matches rules text letting a deck run more than one copy of a
+// card, e.g. "A deck can have any number of cards named Relentless Rats." or
+// "A deck can have up to seven cards named Seven Dwarves."
+var copyLimitPattern = regexp.MustCompile( //nolint:gochecknoglobals // compiled once
+	`(?i)a deck can have (any number of|up to (\w+)) cards named`,
+)
+
+// SingletonViolation is a card included more times than the deck's copy limit
+// allows: the singleton rule in Commander, four copies in 60-card formats.
+type SingletonViolation struct {
+	Name     string
+	Quantity int
+	// Limit is how many copies are allowed: the format's limit for most cards, more
+	// for cards like Seven Dwarves.
+	Limit int
+}
+
+// ColorIdentityViolation is a decklist card whose color identity falls outside
+// the commander's.
+type ColorIdentityViolation struct {
+	Name   string
+	Colors []scryfall.Color
+}
+
+// isColorSubset reports whether every color in colors is also in allowed.
+func isColorSubset(colors, allowed []scryfall.Color) bool {
+	for _, c := range colors {
+		found := false
+		for _, a := range allowed {
+			if c == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// findColorIdentityViolations returns the resolved cards in names whose color
+// identity isn't a subset of allowed. Each card is reported once, in decklist order.
+// Names missing from lookup are skipped; callers report them separately.
+func findColorIdentityViolations(
+	names []string,
+	lookup CardLookupResult,
+	allowed []scryfall.Color,
+) []ColorIdentityViolation {
+	var violations []ColorIdentityViolation
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		card, ok := lookup.Get(name)
+		if !ok || seen[card.Name] {
+			continue
+		}
+		seen[card.Name] = true
+
+		if !isColorSubset(card.ColorIdentity, allowed) {
+			violations = append(violations, ColorIdentityViolation{Name: card.Name, Colors: card.ColorIdentity})
+		}
+	}
+
+	return violations
+}
+
+// formatColors renders a color identity such as "W, U", or "Colorless" when empty.
+func formatColors(colors []scryfall.Color) string {
+	if len(colors) == 0 {
+		return "Colorless"
+	}
+	parts := make([]string, len(colors))
+	for i, c := range colors {
+		parts[i] = string(c)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// findBannedCards returns the names of resolved cards in names that are banned
+// in Commander. Each card is reported once, in decklist order.
+func findBannedCards(names []string, lookup CardLookupResult) []string {
+	var banned []string
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		card, ok := lookup.Get(name)
+		if !ok || seen[card.Name] {
+			continue
+		}
+		seen[card.Name] = true
+
+		if card.Legalities.Commander == scryfall.LegalityBanned {
+			banned = append(banned, card.Name)
+		}
+	}
+
+	return banned
+}
+
+// basicLandNames returns the names of the basic lands, used when a card couldn't be resolved.
+func basicLandNames() []string {
+	return []string{"plains", "island", "swamp", "mountain", "forest", "wastes"}
+}
+
+// copyNumberWords maps the spelled-out copy limits printed on cards to numbers.
+func copyNumberWords() map[string]int {
+	return map[string]int{"two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9}
+}
+
+// cardCopyLimit returns how many copies of a card a deck may include when the
+// format allows formatLimit copies of each card (1 in Commander, 4 in 60-card
+// formats). Basic lands and cards whose rules text allows any number are unlimited.
+func cardCopyLimit(card scryfall.Card, formatLimit int) int {
+	if strings.Contains(card.TypeLine, "Basic") {
+		return unlimitedCopies
+	}
+
+	match := copyLimitPattern.FindStringSubmatch(card.OracleText)
+	if match == nil {
+		return formatLimit
+	}
+	if match[2] == "" {
+		return unlimitedCopies
+	}
+	if n, ok := copyNumberWords()[strings.ToLower(match[2])]; ok {
+		return max(n, formatLimit)
+	}
+	return formatLimit
+}
+
+// findSingletonViolations returns the cards a Commander deck includes more than once
+// without being allowed to, in decklist order.
+func findSingletonViolations(entries []DecklistEntry, lookup CardLookupResult) []SingletonViolation {
+	return findCopyLimitViolations(entries, lookup, 1)
+}
+
+// findCopyLimitViolations returns the cards included more times than they're
+// allowed when the format allows formatLimit copies, in decklist order. Quantities
+// of repeated lines are added together. Cards missing from lookup are only exempt
+// if they're named like a basic land.
+func findCopyLimitViolations(entries []DecklistEntry, lookup CardLookupResult, formatLimit int) []SingletonViolation {
+	quantities := make(map[string]int)
+	var order []DecklistEntry
+	for _, entry := range entries {
+		key := normalizeCardName(entry.Name)
+		if _, seen := quantities[key]; !seen {
+			order = append(order, entry)
+		}
+		quantities[key] += entry.Quantity
+	}
+
+	var violations []SingletonViolation
+	for _, entry := range order {
+		quantity := quantities[normalizeCardName(entry.Name)]
+		if quantity <= formatLimit {
+			continue
+		}
+
+		limit := formatLimit
+		if card, ok := lookup.Get(entry.Name); ok {
+			limit = cardCopyLimit(card, formatLimit)
+		} else if slices.Contains(basicLandNames(), normalizeCardName(entry.Name)) {
+			limit = unlimitedCopies
+		}
+
+		if limit != unlimitedCopies && quantity > limit {
+			violations = append(violations, SingletonViolation{Name: entry.Name, Quantity: quantity, Limit: limit})
+		}
+	}
+
+	return violations
+}
+
+// deckDecklist converts a deck's mainboard into a decklist, keeping each entry's
+// quantity so repeated copies are caught by the singleton check.
+func deckDecklist(deck *Deck) DecklistParseResult {
+	result := DecklistParseResult{Entries: []DecklistEntry{}, Warnings: []string{}}
+	for i, entry := range deck.Mainboard {
+		result.Entries = append(result.Entries, DecklistEntry{Name: entry.Name, Quantity: entry.Quantity, Line: i + 1})
+		result.TotalCards += entry.Quantity
+	}
+	return result
+}
+
+// moxfieldCommanderFormats returns the Moxfield deck formats built under
+// Commander deck construction rules.
+func moxfieldCommanderFormats() []string {
+	return []string{"commander", "commanderPrecons", "duelCommander"}
+}
+
+// moxfieldFormatProblem describes why a Moxfield deck's format isn't one the
+// Commander validator applies to, or returns "" when it is. Decks without a
+// format are assumed to be Commander.
+func moxfieldFormatProblem(format string) string {
+	if format == "" {
+		return ""
+	}
+	for _, commanderFormat := range moxfieldCommanderFormats() {
+		if strings.EqualFold(format, commanderFormat) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("this deck's Moxfield format is %q, not Commander, so Commander rules may not apply", format)
+}
+
+// deckCommandZone returns the names on a deck's commanders board and the first
+// card on its companions board, if any.
+func deckCommandZone(deck *Deck) ([]string, string) {
+	var commanders []string
+	for _, entry := range deck.Commanders {
+		commanders = append(commanders, entry.Name)
+	}
+
+	companion := ""
+	if len(deck.Companions) > 0 {
+		companion = deck.Companions[0].Name
+	}
+	return commanders, companion
+}
+
+// canBeCommander reports whether a card can lead a Commander deck: a legendary card,
+// or one whose text allows it.
+func canBeCommander(card scryfall.Card) bool {
+	return strings.Contains(strings.ToLower(card.TypeLine), "legendary") ||
+		strings.Contains(strings.ToLower(card.OracleText), "can be your commander")
+}
+
+// hasKeyword reports whether a card has the given keyword ability, ignoring case.
+func hasKeyword(card scryfall.Card, keyword string) bool {
+	for _, k := range card.Keywords {
+		if strings.EqualFold(k, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBackground reports whether a card is a Background enchantment.
+func isBackground(card scryfall.Card) bool {
+	return strings.Contains(card.TypeLine, "Background")
+}
+
+// commanderPairingProblem explains why two cards can't be commanders together,
+// or returns "" when they can: both have Partner, they name each other with
+// "Partner with", both have Friends forever, or one can choose a Background
+// and the other is a Background.
+func commanderPairingProblem(a, b scryfall.Card) string {
+	partnersWith := func(card, other scryfall.Card) bool {
+		return hasKeyword(card, "Partner with") && strings.Contains(card.OracleText, "Partner with "+other.Name)
+	}
+
+	switch {
+	case hasKeyword(a, "Partner") && hasKeyword(b, "Partner"):
+		return ""
+	case partnersWith(a, b) && partnersWith(b, a):
+		return ""
+	case hasKeyword(a, "Friends forever") && hasKeyword(b, "Friends forever"):
+		return ""
+	case hasKeyword(a, "Choose a background") && isBackground(b),
+		hasKeyword(b, "Choose a background") && isBackground(a):
+		return ""
+	}
+
+	var missing []string
+	for _, card := range []scryfall.Card{a, b} {
+		if !hasKeyword(card, "Partner") && !hasKeyword(card, "Partner with") &&
+			!hasKeyword(card, "Friends forever") && !hasKeyword(card, "Choose a background") && !isBackground(card) {
+			missing = append(missing, card.Name)
+		}
+	}
+	if len(missing) > 0 {
+		verb := "has"
+		if len(missing) > 1 {
+			verb = "have"
+		}
+		return fmt.Sprintf("%s and %s can't share command: %s %s no Partner, Friends forever, or Background ability",
+			a.Name, b.Name, strings.Join(missing, " and "), verb)
+	}
+	return fmt.Sprintf("%s and %s can't share command: their partner abilities don't pair with each other",
+		a.Name, b.Name)
+}
+
+// mergeColorIdentities combines the color identities of the commanders, in WUBRG order.
+func mergeColorIdentities(commanders []scryfall.Card) []scryfall.Color {
+	present := make(map[scryfall.Color]bool)
+	for _, commander := range commanders {
+		for _, color := range commander.ColorIdentity {
+			present[color] = true
+		}
+	}
+
+	colors := []scryfall.Color{}
+	for _, color := range []scryfall.Color{
+		scryfall.ColorWhite, scryfall.ColorBlue, scryfall.ColorBlack, scryfall.ColorRed, scryfall.ColorGreen,
+	} {
+		if present[color] {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
+
+// formatLegality returns a card's legality in one of the constructed formats from
+// validationFormats.
+func formatLegality(card scryfall.Card, format string) scryfall.Legality {
+	switch format {
+	case "modern":
+		return card.Legalities.Modern
+	case "legacy":
+		return card.Legalities.Legacy
+	case "pauper":
+		return card.Legalities.Pauper
+	case "standard":
+		return card.Legalities.Standard
+	default:
+		return card.Legalities.Commander
+	}
+}
+
+// FormatLegalityViolation is a decklist card that can't be played in a format.
+type FormatLegalityViolation struct {
+	Name     string
+	Legality scryfall.Legality
+}
+
+// findFormatLegalityViolations returns the resolved cards in names that aren't legal
+// in format, whether banned or outside its card pool. Each card is reported once,
+// in decklist order.
+func findFormatLegalityViolations(names []string, lookup CardLookupResult, format string) []FormatLegalityViolation {
+	var violations []FormatLegalityViolation
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		card, ok := lookup.Get(name)
+		if !ok || seen[card.Name] {
+			continue
+		}
+		seen[card.Name] = true
+
+		if legality := formatLegality(card, format); legality != scryfall.LegalityLegal {
+			violations = append(violations, FormatLegalityViolation{Name: card.Name, Legality: legality})
+		}
+	}
+
+	return violations
+}
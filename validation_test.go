@@ -0,0 +1,586 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestIsColorSubset(t *testing.T) {
+	tests := []struct {
+		name    string
+		colors  []scryfall.Color
+		allowed []scryfall.Color
+		want    bool
+	}{
+		{name: "colorless", colors: nil, allowed: []scryfall.Color{scryfall.ColorGreen}, want: true},
+		{name: "subset", colors: []scryfall.Color{scryfall.ColorGreen}, allowed: []scryfall.Color{"G", "U"}, want: true},
+		{name: "exact", colors: []scryfall.Color{"G", "U"}, allowed: []scryfall.Color{"U", "G"}, want: true},
+		{name: "outside", colors: []scryfall.Color{"G", "B"}, allowed: []scryfall.Color{"G", "U"}, want: false},
+		{name: "colorless commander", colors: []scryfall.Color{"R"}, allowed: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isColorSubset(tt.colors, tt.allowed); got != tt.want {
+				t.Errorf("isColorSubset(%v, %v) = %v, want %v", tt.colors, tt.allowed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindColorIdentityViolations(t *testing.T) {
+	lookup := CardLookupResult{Cards: map[string]scryfall.Card{
+		"sol ring":             {Name: "Sol Ring"},
+		"cultivate":            {Name: "Cultivate", ColorIdentity: []scryfall.Color{"G"}},
+		"lightning bolt":       {Name: "Lightning Bolt", ColorIdentity: []scryfall.Color{"R"}},
+		"swords to plowshares": {Name: "Swords to Plowshares", ColorIdentity: []scryfall.Color{"W"}},
+	}}
+
+	names := []string{"Sol Ring", "Cultivate", "Lightning Bolt", "lightning bolt", "Swords to Plowshares", "Unknown"}
+	got := findColorIdentityViolations(names, lookup, []scryfall.Color{"G", "W"})
+
+	if len(got) != 1 || got[0].Name != "Lightning Bolt" {
+		t.Errorf("findColorIdentityViolations() = %+v, want only Lightning Bolt", got)
+	}
+}
+
+func TestFindBannedCards(t *testing.T) {
+	lookup := CardLookupResult{Cards: map[string]scryfall.Card{
+		"sol ring":   {Name: "Sol Ring", Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal}},
+		"mana crypt": {Name: "Mana Crypt", Legalities: scryfall.Legalities{Commander: scryfall.LegalityBanned}},
+	}}
+
+	got := findBannedCards([]string{"Sol Ring", "Mana Crypt", "mana crypt", "Unknown"}, lookup)
+
+	if len(got) != 1 || got[0] != "Mana Crypt" {
+		t.Errorf("findBannedCards() = %v, want [Mana Crypt]", got)
+	}
+}
+
+// newValidateDeckTestServer serves a fixed commander from /cards/named and
+// resolves decklist cards from known.
+func newValidateDeckTestServer(
+	t *testing.T,
+	commander *scryfall.Card,
+	known map[string]scryfall.Card,
+) *MTGCommanderServer {
+	t.Helper()
+
+	collection := collectionHandler(t, known, nil)
+	return newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cards/named" {
+			_ = json.NewEncoder(w).Encode(commander)
+			return
+		}
+		collection(w, r)
+	}))
+}
+
+func TestHandleValidateDeckColorIdentity(t *testing.T) {
+	known := map[string]scryfall.Card{
+		"sol ring":       {Name: "Sol Ring"},
+		"cultivate":      {Name: "Cultivate", ColorIdentity: []scryfall.Color{"G"}},
+		"lightning bolt": {Name: "Lightning Bolt", ColorIdentity: []scryfall.Color{"R"}},
+		"counterspell":   {Name: "Counterspell", ColorIdentity: []scryfall.Color{"U"}},
+	}
+
+	s := newValidateDeckTestServer(t, &scryfall.Card{
+		Name:          "Omnath, Locus of Mana",
+		TypeLine:      "Legendary Creature — Elemental",
+		ColorIdentity: []scryfall.Color{"G"},
+	}, known)
+
+	got, isErr := callTool(t, s.handleValidateDeck, map[string]any{
+		"commander": "Omnath, Locus of Mana",
+		"decklist":  "1 Sol Ring\n1 Cultivate\n1 Lightning Bolt\n1 Counterspell\n1 Not A Real Card",
+	})
+	if isErr {
+		t.Fatalf("handleValidateDeck() returned error: %s", got)
+	}
+
+	for _, want := range []string{
+		"Found 2 card(s) outside G",
+		"  - Lightning Bolt (R)",
+		"  - Counterspell (U)",
+		"**Unrecognized Cards (1):**",
+		"  - Not A Real Card",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handleValidateDeck() missing %q in output: %s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "Cultivate (") || strings.Contains(got, "Sol Ring (") {
+		t.Errorf("handleValidateDeck() flagged a card within the color identity: %s", got)
+	}
+}
+
+func TestHandleValidateDeckCachesCommander(t *testing.T) {
+	commander := scryfall.Card{
+		Name:          "Omnath, Locus of Mana",
+		TypeLine:      "Legendary Creature — Elemental",
+		ColorIdentity: []scryfall.Color{"G"},
+	}
+	collection := collectionHandler(t, map[string]scryfall.Card{"sol ring": {Name: "Sol Ring"}}, nil)
+
+	namedRequests := 0
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cards/named" {
+			namedRequests++
+			_ = json.NewEncoder(w).Encode(&commander)
+			return
+		}
+		collection(w, r)
+	}))
+	s.cardCache = newCardCache(time.Hour, 10)
+
+	for range 2 {
+		got, isErr := callTool(t, s.handleValidateDeck, map[string]any{
+			"commander": commander.Name,
+			"decklist":  "1 Sol Ring",
+		})
+		if isErr {
+			t.Fatalf("handleValidateDeck() returned error: %s", got)
+		}
+	}
+
+	if namedRequests != 1 {
+		t.Errorf("handleValidateDeck() looked up the commander %d times, want 1", namedRequests)
+	}
+}
+
+func TestHandleValidateDeckBannedCards(t *testing.T) {
+	commander := &scryfall.Card{
+		Name:          "Omnath, Locus of Mana",
+		TypeLine:      "Legendary Creature — Elemental",
+		ColorIdentity: []scryfall.Color{"G"},
+		Legalities:    scryfall.Legalities{Commander: scryfall.LegalityLegal},
+	}
+
+	tests := []struct {
+		name         string
+		known        map[string]scryfall.Card
+		wantContains []string
+	}{
+		{
+			name: "banned card",
+			known: map[string]scryfall.Card{
+				"sol ring":   {Name: "Sol Ring", Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal}},
+				"mana crypt": {Name: "Mana Crypt", Legalities: scryfall.Legalities{Commander: scryfall.LegalityBanned}},
+			},
+			wantContains: []string{"**Status:** ❌ INVALID", "❌ Found 1 banned card(s):", "  - Mana Crypt"},
+		},
+		{
+			name: "no banned cards",
+			known: map[string]scryfall.Card{
+				"sol ring":   {Name: "Sol Ring", Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal}},
+				"mana crypt": {Name: "Mana Crypt", Legalities: scryfall.Legalities{Commander: scryfall.LegalityLegal}},
+			},
+			wantContains: []string{"**Banned Cards:** ✅ None"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newValidateDeckTestServer(t, commander, tt.known)
+
+			got, isErr := callTool(t, s.handleValidateDeck, map[string]any{
+				"commander": commander.Name,
+				"decklist":  "1 Sol Ring\n1 Mana Crypt",
+			})
+			if isErr {
+				t.Fatalf("handleValidateDeck() returned error: %s", got)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleValidateDeck() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCardCopyLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		card scryfall.Card
+		want int
+	}{
+		{name: "regular card", card: scryfall.Card{TypeLine: "Artifact"}, want: 1},
+		{name: "basic land", card: scryfall.Card{TypeLine: "Basic Snow Land — Island"}, want: unlimitedCopies},
+		{
+			name: "any number",
+			card: scryfall.Card{OracleText: "A deck can have any number of cards named Relentless Rats."},
+			want: unlimitedCopies,
+		},
+		{
+			name: "up to seven",
+			card: scryfall.Card{OracleText: "A deck can have up to seven cards named Seven Dwarves."},
+			want: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cardCopyLimit(tt.card, 1); got != tt.want {
+				t.Errorf("cardCopyLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := cardCopyLimit(scryfall.Card{TypeLine: "Instant"}, constructedCopyLimit); got != constructedCopyLimit {
+		t.Errorf("cardCopyLimit() in a 60-card format = %d, want %d", got, constructedCopyLimit)
+	}
+}
+
+func TestFindSingletonViolations(t *testing.T) {
+	lookup := CardLookupResult{Cards: map[string]scryfall.Card{
+		"sol ring": {Name: "Sol Ring", TypeLine: "Artifact"},
+		"relentless rats": {
+			Name:       "Relentless Rats",
+			OracleText: "A deck can have any number of cards named Relentless Rats.",
+		},
+		"seven dwarves": {
+			Name:       "Seven Dwarves",
+			OracleText: "A deck can have up to seven cards named Seven Dwarves.",
+		},
+	}}
+
+	entries := []DecklistEntry{
+		{Name: "Sol Ring", Quantity: 1},
+		{Name: "Relentless Rats", Quantity: 20},
+		{Name: "Seven Dwarves", Quantity: 8},
+		{Name: "Island", Quantity: 10},
+		{Name: "Unknown Card", Quantity: 2},
+		{Name: "sol ring", Quantity: 1},
+	}
+
+	got := findSingletonViolations(entries, lookup)
+	want := []SingletonViolation{
+		{Name: "Sol Ring", Quantity: 2, Limit: 1},
+		{Name: "Seven Dwarves", Quantity: 8, Limit: 7},
+		{Name: "Unknown Card", Quantity: 2, Limit: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findSingletonViolations() = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidateMoxfieldDecklist(t *testing.T) {
+	deck := &MoxfieldDeck{Mainboard: map[string]MoxfieldCardEntry{
+		"ring":   {Quantity: 2, Card: MoxfieldCardInfo{Name: "Sol Ring"}},
+		"island": {Quantity: 10, Card: MoxfieldCardInfo{Name: "Island"}},
+	}}
+	known := map[string]scryfall.Card{
+		"sol ring": {Name: "Sol Ring", TypeLine: "Artifact"},
+		"island":   {Name: "Island", TypeLine: "Basic Land — Island", ColorIdentity: []scryfall.Color{}},
+	}
+
+	s := newValidateDeckTestServer(t, &scryfall.Card{
+		Name:          "Talrand, Sky Summoner",
+		TypeLine:      "Legendary Creature — Merfolk Wizard",
+		ColorIdentity: []scryfall.Color{"U"},
+	}, known)
+
+	parsed := deckDecklist(deck.ToDeck())
+	if parsed.TotalCards != 12 {
+		t.Errorf("deckDecklist() TotalCards = %d, want 12", parsed.TotalCards)
+	}
+
+	result, err := s.validateDeck(t.Context(), []string{"Talrand, Sky Summoner"}, "", parsed)
+	if err != nil {
+		t.Fatalf("validateDeck() error = %v", err)
+	}
+	got := result.Content[0].(mcp.TextContent).Text
+
+	if !strings.Contains(got, "  - Sol Ring (x2)") {
+		t.Errorf("validateDeck() should flag Sol Ring's quantity: %s", got)
+	}
+	if strings.Contains(got, "Island (x") {
+		t.Errorf("validateDeck() flagged basic lands: %s", got)
+	}
+}
+
+func TestMoxfieldCommandZone(t *testing.T) {
+	deck := &MoxfieldDeck{
+		Commanders: map[string]MoxfieldCardEntry{
+			"kraum": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Kraum, Ludevic's Opus"}},
+			"tymna": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Tymna the Weaver"}},
+		},
+		Companions: map[string]MoxfieldCardEntry{
+			"jegantha": {Quantity: 1, Card: MoxfieldCardInfo{Name: "Jegantha, the Wellspring"}},
+		},
+	}
+
+	commanders, companion := deckCommandZone(deck.ToDeck())
+	if want := []string{"Kraum, Ludevic's Opus", "Tymna the Weaver"}; !reflect.DeepEqual(commanders, want) {
+		t.Errorf("deckCommandZone() commanders = %v, want %v", commanders, want)
+	}
+	if companion != "Jegantha, the Wellspring" {
+		t.Errorf("deckCommandZone() companion = %q, want Jegantha, the Wellspring", companion)
+	}
+
+	if _, companion := deckCommandZone((&MoxfieldDeck{}).ToDeck()); companion != "" {
+		t.Errorf("deckCommandZone() companion without a companions board = %q, want empty", companion)
+	}
+}
+
+func TestMoxfieldFormatProblem(t *testing.T) {
+	for _, format := range []string{"commander", "commanderPrecons", "duelCommander", "DuelCommander", ""} {
+		if problem := moxfieldFormatProblem(format); problem != "" {
+			t.Errorf("moxfieldFormatProblem(%q) = %q, want no problem", format, problem)
+		}
+	}
+	for _, format := range []string{"brawl", "oathbreaker", "modern"} {
+		if problem := moxfieldFormatProblem(format); !strings.Contains(problem, format) {
+			t.Errorf("moxfieldFormatProblem(%q) = %q, want it to name the format", format, problem)
+		}
+	}
+}
+
+func TestCommanderPairingProblem(t *testing.T) {
+	tymna := scryfall.Card{Name: "Tymna the Weaver", Keywords: []string{"Lifelink", "Partner"}}
+	kraum := scryfall.Card{Name: "Kraum, Ludevic's Opus", Keywords: []string{"Flying", "Haste", "Partner"}}
+	wilson := scryfall.Card{Name: "Wilson, Refined Grizzly", Keywords: []string{"Choose a background", "Reach"}}
+	background := scryfall.Card{Name: "Raised by Giants", TypeLine: "Legendary Enchantment — Background"}
+	pir := scryfall.Card{
+		Name:       "Pir, Imaginative Rascal",
+		Keywords:   []string{"Partner with"},
+		OracleText: "Partner with Toothy, Imaginary Friend",
+	}
+	toothy := scryfall.Card{
+		Name:       "Toothy, Imaginary Friend",
+		Keywords:   []string{"Partner with"},
+		OracleText: "Partner with Pir, Imaginative Rascal",
+	}
+	omnath := scryfall.Card{Name: "Omnath, Locus of Mana"}
+
+	tests := []struct {
+		name    string
+		a, b    scryfall.Card
+		wantErr string
+	}{
+		{name: "partners", a: tymna, b: kraum},
+		{name: "background", a: background, b: wilson},
+		{name: "partner with", a: pir, b: toothy},
+		{name: "partner with the wrong card", a: pir, b: tymna, wantErr: "don't pair with each other"},
+		{name: "missing partner", a: tymna, b: omnath, wantErr: "Omnath, Locus of Mana has no Partner"},
+		{name: "background without chooser", a: background, b: tymna, wantErr: "don't pair"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := commanderPairingProblem(tt.a, tt.b)
+			if tt.wantErr == "" && got != "" {
+				t.Errorf("commanderPairingProblem() = %q, want no problem", got)
+			}
+			if tt.wantErr != "" && !strings.Contains(got, tt.wantErr) {
+				t.Errorf("commanderPairingProblem() = %q, want it to contain %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleValidateDeckPartners(t *testing.T) {
+	named := map[string]scryfall.Card{
+		"tymna the weaver": {
+			Name: "Tymna the Weaver", TypeLine: "Legendary Creature — Human Cleric",
+			Keywords: []string{"Partner"}, ColorIdentity: []scryfall.Color{"W", "B"},
+		},
+		"kraum, ludevic's opus": {
+			Name: "Kraum, Ludevic's Opus", TypeLine: "Legendary Creature — Zombie Horror",
+			Keywords: []string{"Partner"}, ColorIdentity: []scryfall.Color{"U", "R"},
+		},
+		"omnath, locus of mana": {
+			Name: "Omnath, Locus of Mana", TypeLine: "Legendary Creature — Elemental",
+			ColorIdentity: []scryfall.Color{"G"},
+		},
+		"lurrus of the dream-den": {
+			Name: "Lurrus of the Dream-Den", Keywords: []string{"Companion", "Lifelink"},
+			ColorIdentity: []scryfall.Color{"W", "B"},
+		},
+	}
+	known := map[string]scryfall.Card{
+		"counterspell":   {Name: "Counterspell", ColorIdentity: []scryfall.Color{"U"}},
+		"lightning bolt": {Name: "Lightning Bolt", ColorIdentity: []scryfall.Color{"R"}},
+		"cultivate":      {Name: "Cultivate", ColorIdentity: []scryfall.Color{"G"}},
+	}
+
+	collection := collectionHandler(t, known, nil)
+	s := newTestMTGServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/named" {
+			collection(w, r)
+			return
+		}
+		card, ok := named[normalizeCardName(r.URL.Query().Get("fuzzy"))]
+		if !ok {
+			writeScryfallNotFound(w)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&card)
+	}))
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantContains []string
+	}{
+		{
+			name: "partners combine color identity",
+			args: map[string]any{
+				"commander": "Tymna the Weaver",
+				"partner":   "Kraum, Ludevic's Opus",
+				"decklist":  "1 Counterspell\n1 Lightning Bolt\n1 Cultivate",
+				"companion": "Lurrus of the Dream-Den",
+			},
+			wantContains: []string{
+				"**Commander:** Tymna the Weaver & Kraum, Ludevic's Opus",
+				"**Color Identity:** W, U, B, R",
+				"(should be 98 cards plus 2 commanders)",
+				"Found 1 card(s) outside W, U, B, R:",
+				"  - Cultivate (G)",
+				"**Companion:** Lurrus of the Dream-Den ✅",
+			},
+		},
+		{
+			name: "missing partner keyword",
+			args: map[string]any{
+				"commander": "Tymna the Weaver",
+				"partner":   "Omnath, Locus of Mana",
+				"decklist":  "1 Cultivate",
+			},
+			wantContains: []string{"**Status:** ❌ INVALID", "Omnath, Locus of Mana has no Partner"},
+		},
+		{
+			name: "companion outside color identity",
+			args: map[string]any{
+				"commander": "Omnath, Locus of Mana",
+				"decklist":  "1 Cultivate",
+				"companion": "Lurrus of the Dream-Den",
+			},
+			wantContains: []string{"**Companion:** Lurrus of the Dream-Den ❌ (W, B is outside G)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleValidateDeck, tt.args)
+			if isErr {
+				t.Fatalf("handleValidateDeck() returned error: %s", got)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleValidateDeck() missing %q in output: %s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleValidateDeckFormat(t *testing.T) {
+	modernLegal := scryfall.Legalities{Modern: scryfall.LegalityLegal, Legacy: scryfall.LegalityLegal}
+	known := map[string]scryfall.Card{
+		"lightning bolt":       {Name: "Lightning Bolt", Legalities: modernLegal},
+		"mountain":             {Name: "Mountain", TypeLine: "Basic Land — Mountain", Legalities: modernLegal},
+		"goblin guide":         {Name: "Goblin Guide", Legalities: modernLegal},
+		"smash to smithereens": {Name: "Smash to Smithereens", Legalities: modernLegal},
+		"brainstorm": {Name: "Brainstorm", Legalities: scryfall.Legalities{
+			Modern: scryfall.LegalityNotLegal, Legacy: scryfall.LegalityLegal,
+		}},
+		"mental misstep": {Name: "Mental Misstep", Legalities: scryfall.Legalities{
+			Modern: scryfall.LegalityBanned, Legacy: scryfall.LegalityBanned,
+		}},
+	}
+	s := newTestMTGServer(t, collectionHandler(t, known, nil))
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantErr     bool
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name: "valid modern deck",
+			args: map[string]any{
+				"format":    "modern",
+				"decklist":  "4 Lightning Bolt\n4 Goblin Guide\n52 Mountain",
+				"sideboard": "4 Smash to Smithereens",
+			},
+			wantContain: []string{
+				"**Format:** modern", "**Status:** ✅ VALID", "**Deck Size:** 60 cards ✅",
+				"**Sideboard:** 4 cards ✅", "✅ All cards legal in modern",
+			},
+		},
+		{
+			name: "too small, too many copies, and illegal cards",
+			args: map[string]any{
+				"format":    "modern",
+				"decklist":  "4 Lightning Bolt\n1 Brainstorm\n1 Mental Misstep\n20 Mountain",
+				"sideboard": "1 Lightning Bolt\n15 Mountain",
+			},
+			wantContain: []string{
+				"**Status:** ❌ INVALID",
+				"**Deck Size:** 26 cards ❌ (should be at least 60 cards)",
+				"**Sideboard:** 16 cards ❌ (should be at most 15 cards)",
+				"  - Lightning Bolt (x5, max 4)",
+				"Found 2 card(s) not legal in modern:",
+				"  - Brainstorm (not_legal: ",
+				"  - Mental Misstep (banned: ",
+			},
+			wantMissing: []string{"Mountain (x"},
+		},
+		{
+			name: "legality follows the format",
+			args: map[string]any{
+				"format":   "legacy",
+				"decklist": "4 Brainstorm\n56 Mountain",
+			},
+			wantContain: []string{"**Status:** ✅ VALID", "✅ All cards legal in legacy"},
+		},
+		{
+			name:    "invalid format",
+			args:    map[string]any{"format": "vintage", "decklist": "4 Brainstorm"},
+			wantErr: true,
+		},
+		{
+			name:    "commander without a commander",
+			args:    map[string]any{"format": "commander", "decklist": "1 Sol Ring"},
+			wantErr: true,
+		},
+		{
+			name: "commander with a sideboard",
+			args: map[string]any{
+				"format": "commander", "commander": "Krenko, Mob Boss", "decklist": "1 Sol Ring", "sideboard": "1 Pyroblast",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isErr := callTool(t, s.handleValidateDeckFormat, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("handleValidateDeckFormat() isError = %v, want %v: %s", isErr, tt.wantErr, got)
+			}
+			for _, want := range tt.wantContain {
+				if !strings.Contains(got, want) {
+					t.Errorf("handleValidateDeckFormat() missing %q in output: %s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("handleValidateDeckFormat() unexpectedly contains %q: %s", unwanted, got)
+				}
+			}
+		})
+	}
+}